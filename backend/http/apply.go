@@ -0,0 +1,43 @@
+package http
+
+import (
+	"log"
+	"net/http"
+
+	"backend/k8s"
+)
+
+// maxManifestSize limita o corpo aceito em /apply.
+const maxManifestSize = 10 << 20
+
+// ApplyResponse lista o resultado de cada objeto do manifesto, na ordem em que aparecem.
+type ApplyResponse struct {
+	DryRun  bool              `json:"dryRun,omitempty"`
+	Results []k8s.ApplyResult `json:"results"`
+}
+
+// applyHandler aplica um manifesto YAML (um ou vários documentos) ou JSON enviado no corpo.
+// Parâmetros: namespace (padrão dos objetos sem namespace), force=true para assumir
+// campos gerenciados por outras ferramentas e dryRun=true para só validar.
+// Rota: POST /apply
+func applyHandler(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	opts := k8s.ApplyOptions{
+		Namespace:     query.Get("namespace"),
+		Force:         query.Get("force") == "true",
+		MutateOptions: mutateOptions(r),
+	}
+
+	body := http.MaxBytesReader(w, r.Body, maxManifestSize)
+	results, err := k8s.ApplyManifests(r.Context(), body, opts)
+	if err != nil {
+		log.Printf("❌ ERRO: Falha ao ler manifesto: %v", err)
+		http.Error(w, err.Error(), k8sErrorStatus(err))
+		return
+	}
+
+	for _, result := range results {
+		log.Printf("📦 Apply %s %s/%s: %s %s", result.Kind, result.Namespace, result.Name, result.Result, result.Error)
+	}
+	writeJSON(w, http.StatusOK, ApplyResponse{DryRun: opts.DryRun, Results: results})
+}
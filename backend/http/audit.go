@@ -0,0 +1,70 @@
+package http
+
+import (
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+)
+
+// auditLogEnv define o arquivo onde as entradas de auditoria são acrescentadas.
+// Sem ele, as entradas vão para a saída de erro junto com os demais logs.
+const auditLogEnv = "K8S_MANAGER_AUDIT_LOG"
+
+// AuditEntry registra quem acessou ou alterou dados sensíveis. Os valores nunca
+// entram na entrada, apenas os nomes das chaves.
+type AuditEntry struct {
+	Time      time.Time `json:"time"`
+	Action    string    `json:"action"`
+	Namespace string    `json:"namespace"`
+	Name      string    `json:"name"`
+	Keys      []string  `json:"keys,omitempty"`
+	Result    string    `json:"result"`
+	Error     string    `json:"error,omitempty"`
+	Remote    string    `json:"remote"`
+	Forwarded string    `json:"forwardedFor,omitempty"`
+	UserAgent string    `json:"userAgent,omitempty"`
+	DryRun    bool      `json:"dryRun,omitempty"`
+}
+
+// auditLogger abre o destino das entradas de auditoria na primeira utilização.
+var auditLogger = sync.OnceValue(func() *log.Logger {
+	var out io.Writer = os.Stderr
+	if path := os.Getenv(auditLogEnv); path != "" {
+		file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+		if err != nil {
+			log.Printf("❌ ERRO: não foi possível abrir o log de auditoria %s: %v", path, err)
+		} else {
+			out = file
+		}
+	}
+	return log.New(out, "AUDIT ", 0)
+})
+
+// audit grava uma entrada de auditoria para a requisição. err nil indica sucesso.
+func audit(r *http.Request, entry AuditEntry, err error) {
+	entry.Time = time.Now().UTC()
+	entry.Remote = r.RemoteAddr
+	entry.Forwarded = r.Header.Get("X-Forwarded-For")
+	entry.UserAgent = r.UserAgent()
+	if err != nil {
+		entry.Error = err.Error()
+	}
+	// Quem chama pode definir o resultado (ex.: "denied"); senão ele vem de err
+	if entry.Result == "" {
+		entry.Result = "success"
+		if err != nil {
+			entry.Result = "failure"
+		}
+	}
+
+	line, marshalErr := json.Marshal(entry)
+	if marshalErr != nil {
+		log.Printf("❌ ERRO: falha ao serializar auditoria: %v", marshalErr)
+		return
+	}
+	auditLogger().Println(string(line))
+}
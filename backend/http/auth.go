@@ -0,0 +1,133 @@
+package http
+
+import (
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+)
+
+// Permissões que habilitam operações sensíveis. Nenhuma vem ativa por padrão:
+// o operador lista as desejadas em K8S_MANAGER_PERMISSIONS (ex.: "exec").
+const (
+	permissionExec    = "exec"
+	permissionProxy   = "proxy"
+	permissionSecrets = "secrets"
+)
+
+// permissionsEnv lista as permissões habilitadas, separadas por vírgula.
+const permissionsEnv = "K8S_MANAGER_PERMISSIONS"
+
+// adminTokenEnv define o token exigido nas rotas protegidas. Sem ele, qualquer
+// cliente que alcance o backend pode usar as permissões habilitadas.
+const adminTokenEnv = "K8S_MANAGER_ADMIN_TOKEN"
+
+// permissionEnabled informa se a permissão foi habilitada pelo operador. As páginas
+// abertas pelo proxy têm a mesma origem do backend; com o proxy habilitado, as demais
+// permissões só valem se o token de administrador estiver definido.
+func permissionEnabled(permission string) bool {
+	if !permissionListed(permission) {
+		return false
+	}
+	if permission != permissionProxy && permissionListed(permissionProxy) && os.Getenv(adminTokenEnv) == "" {
+		log.Printf("🚫 Permissão '%s' ignorada: com '%s' habilitada, defina %s", permission, permissionProxy, adminTokenEnv)
+		return false
+	}
+	return true
+}
+
+func permissionListed(permission string) bool {
+	for _, enabled := range splitQueryList(os.Getenv(permissionsEnv)) {
+		if enabled == permission || enabled == "*" {
+			return true
+		}
+	}
+	return false
+}
+
+// allowedOriginsEnv lista as origens (esquema://host:porta) autorizadas a abrir
+// WebSockets, separadas por vírgula. O padrão é o frontend local.
+const allowedOriginsEnv = "K8S_MANAGER_ALLOWED_ORIGINS"
+
+const defaultAllowedOrigins = "http://localhost:3000,http://127.0.0.1:3000"
+
+// originAllowed recusa WebSockets abertos por outros sites: o navegador envia o cookie e
+// o token da URL junto, então sem essa verificação qualquer página visitada pelo usuário
+// poderia abrir uma sessão. Clientes fora do navegador não enviam Origin e são aceitos.
+func originAllowed(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+	parsed, err := url.Parse(origin)
+	if err != nil {
+		return false
+	}
+	if strings.EqualFold(parsed.Host, r.Host) {
+		return true
+	}
+
+	allowed := os.Getenv(allowedOriginsEnv)
+	if allowed == "" {
+		allowed = defaultAllowedOrigins
+	}
+	for _, candidate := range splitQueryList(allowed) {
+		if strings.EqualFold(strings.TrimSuffix(candidate, "/"), origin) {
+			return true
+		}
+	}
+	log.Printf("🚫 Origem não autorizada: %s %s (Origin: %s)", r.Method, r.URL.Path, origin)
+	return false
+}
+
+// tokenCookie guarda o token nas páginas abertas pelo proxy.
+const tokenCookie = "k8s_manager_token"
+
+// requestToken lê o token do header Authorization, do parâmetro 'token' ou do cookie
+// do proxy. O parâmetro existe porque o navegador não envia headers na abertura de um
+// WebSocket nem ao navegar para uma página.
+func requestToken(r *http.Request) string {
+	if token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer "); ok {
+		return token
+	}
+	if token := r.URL.Query().Get("token"); token != "" {
+		return token
+	}
+	if cookie, err := r.Cookie(tokenCookie); err == nil {
+		return cookie.Value
+	}
+	return ""
+}
+
+// permissionError verifica se a permissão está habilitada e, quando configurado, se o
+// token de administrador foi apresentado. Devolve o status HTTP e o motivo da recusa.
+func permissionError(r *http.Request, permission string) (int, error) {
+	if !permissionEnabled(permission) {
+		log.Printf("🚫 Permissão '%s' não habilitada: %s %s", permission, r.Method, r.URL.Path)
+		return http.StatusForbidden, fmt.Errorf("Operação não habilitada neste servidor (permissão '%s')", permission)
+	}
+
+	if expected := os.Getenv(adminTokenEnv); expected != "" {
+		if subtle.ConstantTimeCompare([]byte(requestToken(r)), []byte(expected)) != 1 {
+			log.Printf("🚫 Token inválido para '%s': %s %s (%s)", permission, r.Method, r.URL.Path, r.RemoteAddr)
+			return http.StatusUnauthorized, errors.New("Token de acesso inválido ou ausente")
+		}
+	}
+	return http.StatusOK, nil
+}
+
+// requirePermission só executa next se permissionError não recusar a requisição.
+// Handlers que precisam auditar as recusas chamam permissionError diretamente.
+func requirePermission(permission string, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if status, err := permissionError(r, permission); err != nil {
+			http.Error(w, err.Error(), status)
+			return
+		}
+		next(w, r)
+	}
+}
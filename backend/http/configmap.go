@@ -0,0 +1,168 @@
+package http
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"mime/multipart"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+
+	"backend/k8s"
+)
+
+// maxConfigMapSize é o limite de um ConfigMap no etcd (1 MiB); o corpo aceito tem folga
+// para o overhead do multipart e do base64.
+const maxConfigMapSize = 2 << 20
+
+// CreateConfigMapRequest é o corpo JSON de /createConfigMap. BinaryData vem em base64.
+type CreateConfigMapRequest struct {
+	Namespace  string            `json:"namespace"`
+	Name       string            `json:"name"`
+	Data       map[string]string `json:"data,omitempty"`
+	BinaryData map[string][]byte `json:"binaryData,omitempty"`
+}
+
+// UpdateConfigMapRequest altera chaves de um ConfigMap existente.
+type UpdateConfigMapRequest struct {
+	Namespace string            `json:"namespace"`
+	Name      string            `json:"name"`
+	Set       map[string]string `json:"set,omitempty"`
+	SetBinary map[string][]byte `json:"setBinary,omitempty"`
+	Remove    []string          `json:"remove,omitempty"`
+}
+
+func listConfigMapsHandler(w http.ResponseWriter, r *http.Request) {
+	log.Printf("📋 listConfigMapsHandler chamado - método: %s", r.Method)
+	serveNamespacedList(w, r, "configmaps", k8s.ListConfigMaps)
+}
+
+// createConfigMapHandler cria um ConfigMap a partir de JSON (chave/valor) ou de um
+// formulário multipart com arquivos.
+// Rota: POST /createConfigMap
+func createConfigMapHandler(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxConfigMapSize)
+
+	var req CreateConfigMapRequest
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
+		var err error
+		req, err = parseConfigMapForm(r)
+		if err != nil {
+			log.Printf("ERRO ao ler formulário: %v", err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	} else if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	if req.Namespace == "" || req.Name == "" {
+		http.Error(w, "Campos 'namespace' e 'name' são obrigatórios", http.StatusBadRequest)
+		return
+	}
+
+	opts := mutateOptions(r)
+	configMap, err := k8s.CreateConfigMap(req.Namespace, req.Name, req.Data, req.BinaryData, opts)
+	if err != nil {
+		log.Printf("ERRO ao criar configmap: %v", err)
+		http.Error(w, fmt.Sprintf("Erro ao criar configmap: %v", err), k8sErrorStatus(err))
+		return
+	}
+	writeMutation(w, http.StatusCreated, fmt.Sprintf("ConfigMap '%s' foi criado.", req.Name), opts, configMap)
+}
+
+// parseConfigMapForm lê os campos 'namespace' e 'name', valores literais no formato
+// chave=valor (campo 'literal', repetível, como o --from-literal do kubectl) e arquivos.
+// Cada arquivo vira uma chave com o nome do arquivo; conteúdos que não são UTF-8 vão
+// para binaryData.
+func parseConfigMapForm(r *http.Request) (CreateConfigMapRequest, error) {
+	req := CreateConfigMapRequest{Data: map[string]string{}, BinaryData: map[string][]byte{}}
+	if err := r.ParseMultipartForm(maxConfigMapSize); err != nil {
+		return req, fmt.Errorf("formulário inválido: %v", err)
+	}
+	req.Namespace = r.FormValue("namespace")
+	req.Name = r.FormValue("name")
+
+	for _, literal := range r.MultipartForm.Value["literal"] {
+		key, value, ok := strings.Cut(literal, "=")
+		if !ok || key == "" {
+			return req, fmt.Errorf("literal '%s' deve estar no formato chave=valor", literal)
+		}
+		req.Data[key] = value
+	}
+
+	for _, files := range r.MultipartForm.File {
+		for _, header := range files {
+			content, err := readFormFile(header)
+			if err != nil {
+				return req, err
+			}
+			key := filepath.Base(header.Filename)
+			if utf8.Valid(content) {
+				req.Data[key] = string(content)
+			} else {
+				req.BinaryData[key] = content
+			}
+		}
+	}
+	return req, nil
+}
+
+func readFormFile(header *multipart.FileHeader) ([]byte, error) {
+	file, err := header.Open()
+	if err != nil {
+		return nil, fmt.Errorf("erro ao ler arquivo '%s': %v", header.Filename, err)
+	}
+	defer file.Close()
+	return io.ReadAll(file)
+}
+
+// updateConfigMapHandler cria, substitui ou remove chaves de um ConfigMap.
+// Rota: POST /updateConfigMap
+func updateConfigMapHandler(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxConfigMapSize)
+
+	var req UpdateConfigMapRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	if req.Namespace == "" || req.Name == "" {
+		http.Error(w, "Campos 'namespace' e 'name' são obrigatórios", http.StatusBadRequest)
+		return
+	}
+
+	opts := mutateOptions(r)
+	update := k8s.ConfigMapUpdate{Set: req.Set, SetBinary: req.SetBinary, Remove: req.Remove}
+	configMap, err := k8s.UpdateConfigMapKeys(req.Namespace, req.Name, update, opts)
+	if err != nil {
+		log.Printf("ERRO ao atualizar configmap: %v", err)
+		http.Error(w, fmt.Sprintf("Erro ao atualizar configmap: %v", err), k8sErrorStatus(err))
+		return
+	}
+	writeMutation(w, http.StatusOK, fmt.Sprintf("ConfigMap '%s' foi atualizado.", req.Name), opts, configMap)
+}
+
+func deleteConfigMapHandler(w http.ResponseWriter, r *http.Request) {
+	log.Printf("deleteConfigMapHandler chamado com método: %s", r.Method)
+
+	var req ResourceDeleteRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	if req.Namespace == "" || req.Name == "" {
+		http.Error(w, "Campos 'namespace' e 'name' são obrigatórios", http.StatusBadRequest)
+		return
+	}
+
+	opts := mutateOptions(r)
+	configMap, err := k8s.DeleteConfigMap(req.Name, req.Namespace, opts)
+	if err != nil {
+		log.Printf("ERRO ao Deletar configmap: %v", err)
+		http.Error(w, fmt.Sprintf("Erro ao Deletar configmap: %v", err), k8sErrorStatus(err))
+		return
+	}
+	writeMutation(w, http.StatusCreated, fmt.Sprintf("ConfigMap '%s' foi deletado.", req.Name), opts, configMap)
+	log.Printf("ConfigMap Deletado com sucesso: %s", req.Name)
+}
@@ -0,0 +1,128 @@
+package http
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+
+	"backend/k8s"
+)
+
+// CreateCronJobRequest cria um CronJob; Job é o modelo de cada execução.
+type CreateCronJobRequest struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	k8s.CronJobSpec
+}
+
+// SuspendCronJobRequest suspende (suspend: true) ou retoma um CronJob.
+type SuspendCronJobRequest struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	Suspend   bool   `json:"suspend"`
+}
+
+func listCronJobsHandler(w http.ResponseWriter, r *http.Request) {
+	log.Printf("📋 listCronJobsHandler chamado - método: %s", r.Method)
+	serveNamespacedList(w, r, "cronjobs", k8s.ListCronJobs)
+}
+
+// createCronJobHandler valida a expressão cron e cria o CronJob.
+// Rota: POST /createCronJob
+func createCronJobHandler(w http.ResponseWriter, r *http.Request) {
+	var req CreateCronJobRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	if req.Namespace == "" || req.Name == "" || req.Schedule == "" || req.Job.Image == "" {
+		http.Error(w, "Campos 'namespace', 'name', 'schedule' e 'job.image' são obrigatórios", http.StatusBadRequest)
+		return
+	}
+
+	cj, err := k8s.BuildCronJob(req.Namespace, req.Name, req.CronJobSpec)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	opts := mutateOptions(r)
+	created, err := k8s.CreateObject(cj, opts)
+	if err != nil {
+		log.Printf("ERRO ao criar cronjob: %v", err)
+		http.Error(w, fmt.Sprintf("Erro ao criar cronjob: %v", err), k8sErrorStatus(err))
+		return
+	}
+	writeMutation(w, http.StatusCreated, fmt.Sprintf("CronJob '%s' foi criado.", req.Name), opts, created)
+}
+
+// suspendCronJobHandler suspende ou retoma os agendamentos de um CronJob.
+// Rota: POST /suspendCronJob
+func suspendCronJobHandler(w http.ResponseWriter, r *http.Request) {
+	var req SuspendCronJobRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	if req.Namespace == "" || req.Name == "" {
+		http.Error(w, "Campos 'namespace' e 'name' são obrigatórios", http.StatusBadRequest)
+		return
+	}
+
+	opts := mutateOptions(r)
+	cj, err := k8s.SetCronJobSuspend(req.Namespace, req.Name, req.Suspend, opts)
+	if err != nil {
+		log.Printf("ERRO ao atualizar cronjob: %v", err)
+		http.Error(w, fmt.Sprintf("Erro ao atualizar cronjob: %v", err), k8sErrorStatus(err))
+		return
+	}
+
+	msg := fmt.Sprintf("CronJob '%s' foi retomado.", req.Name)
+	if req.Suspend {
+		msg = fmt.Sprintf("CronJob '%s' foi suspenso.", req.Name)
+	}
+	writeMutation(w, http.StatusOK, msg, opts, cj)
+}
+
+// triggerCronJobHandler dispara uma execução imediata a partir do modelo do CronJob.
+// Rota: POST /triggerCronJob
+func triggerCronJobHandler(w http.ResponseWriter, r *http.Request) {
+	var req ResourceDeleteRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	if req.Namespace == "" || req.Name == "" {
+		http.Error(w, "Campos 'namespace' e 'name' são obrigatórios", http.StatusBadRequest)
+		return
+	}
+
+	opts := mutateOptions(r)
+	job, err := k8s.TriggerCronJob(req.Namespace, req.Name, opts)
+	if err != nil {
+		log.Printf("ERRO ao disparar cronjob: %v", err)
+		http.Error(w, fmt.Sprintf("Erro ao disparar cronjob: %v", err), k8sErrorStatus(err))
+		return
+	}
+	writeMutation(w, http.StatusCreated, fmt.Sprintf("Job '%s' foi criado a partir do CronJob '%s'.", job.Name, req.Name), opts, job)
+}
+
+func deleteCronJobHandler(w http.ResponseWriter, r *http.Request) {
+	log.Printf("deleteCronJobHandler chamado com método: %s", r.Method)
+
+	var req ResourceDeleteRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	if req.Namespace == "" || req.Name == "" {
+		http.Error(w, "Campos 'namespace' e 'name' são obrigatórios", http.StatusBadRequest)
+		return
+	}
+
+	opts := mutateOptions(r)
+	cj, err := k8s.DeleteCronJob(req.Name, req.Namespace, opts)
+	if err != nil {
+		log.Printf("ERRO ao Deletar cronjob: %v", err)
+		http.Error(w, fmt.Sprintf("Erro ao Deletar cronjob: %v", err), k8sErrorStatus(err))
+		return
+	}
+	writeMutation(w, http.StatusCreated, fmt.Sprintf("CronJob '%s' foi deletado.", req.Name), opts, cj)
+	log.Printf("CronJob Deletado com sucesso: %s", req.Name)
+}
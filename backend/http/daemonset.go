@@ -0,0 +1,129 @@
+package http
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+
+	"backend/k8s"
+)
+
+// CreateDaemonSetRequest cria um DaemonSet com um container em cada node elegível.
+type CreateDaemonSetRequest struct {
+	Namespace         string            `json:"namespace"`
+	Name              string            `json:"name"`
+	Image             string            `json:"image"`
+	ContainerPort     int32             `json:"containerPort,omitempty"`
+	Env               map[string]string `json:"env,omitempty"`
+	NodeSelector      map[string]string `json:"nodeSelector,omitempty"`
+	TolerateAllTaints bool              `json:"tolerateAllTaints,omitempty"`
+}
+
+// UpdateDaemonSetRequest troca a imagem do container indicado (ou do primeiro).
+type UpdateDaemonSetRequest struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	Image     string `json:"image"`
+	Container string `json:"container,omitempty"`
+}
+
+func listDaemonSetsHandler(w http.ResponseWriter, r *http.Request) {
+	log.Printf("📋 listDaemonSetsHandler chamado - método: %s", r.Method)
+	serveNamespacedList(w, r, "daemonsets", k8s.ListDaemonSets)
+}
+
+// createDaemonSetHandler cria um DaemonSet.
+// Rota: POST /createDaemonSet
+func createDaemonSetHandler(w http.ResponseWriter, r *http.Request) {
+	var req CreateDaemonSetRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	if req.Namespace == "" || req.Name == "" || req.Image == "" {
+		http.Error(w, "Campos 'namespace', 'name' e 'image' são obrigatórios", http.StatusBadRequest)
+		return
+	}
+
+	opts := mutateOptions(r)
+	ds := k8s.BuildDaemonSet(req.Namespace, req.Name, k8s.DaemonSetSpec{
+		Image:             req.Image,
+		ContainerPort:     req.ContainerPort,
+		Env:               req.Env,
+		NodeSelector:      req.NodeSelector,
+		TolerateAllTaints: req.TolerateAllTaints,
+	})
+	created, err := k8s.CreateObject(ds, opts)
+	if err != nil {
+		log.Printf("ERRO ao criar daemonset: %v", err)
+		http.Error(w, fmt.Sprintf("Erro ao criar daemonset: %v", err), k8sErrorStatus(err))
+		return
+	}
+	writeMutation(w, http.StatusCreated, fmt.Sprintf("DaemonSet '%s' está sendo criado.", req.Name), opts, created)
+}
+
+// updateDaemonSetHandler troca a imagem; o rollout segue a estratégia do DaemonSet.
+// Rota: POST /updateDaemonSet
+func updateDaemonSetHandler(w http.ResponseWriter, r *http.Request) {
+	var req UpdateDaemonSetRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	if req.Namespace == "" || req.Name == "" || req.Image == "" {
+		http.Error(w, "Campos 'namespace', 'name' e 'image' são obrigatórios", http.StatusBadRequest)
+		return
+	}
+
+	opts := mutateOptions(r)
+	ds, err := k8s.UpdateDaemonSetImage(req.Namespace, req.Name, req.Container, req.Image, opts)
+	if err != nil {
+		log.Printf("ERRO ao atualizar daemonset: %v", err)
+		http.Error(w, fmt.Sprintf("Erro ao atualizar daemonset: %v", err), k8sErrorStatus(err))
+		return
+	}
+	writeMutation(w, http.StatusOK, fmt.Sprintf("DaemonSet '%s' foi atualizado.", req.Name), opts, ds)
+}
+
+// restartDaemonSetHandler recria os pods do DaemonSet, como kubectl rollout restart.
+// Rota: POST /restartDaemonSet
+func restartDaemonSetHandler(w http.ResponseWriter, r *http.Request) {
+	var req ResourceDeleteRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	if req.Namespace == "" || req.Name == "" {
+		http.Error(w, "Campos 'namespace' e 'name' são obrigatórios", http.StatusBadRequest)
+		return
+	}
+
+	opts := mutateOptions(r)
+	ds, err := k8s.RestartDaemonSet(req.Namespace, req.Name, opts)
+	if err != nil {
+		log.Printf("ERRO ao reiniciar daemonset: %v", err)
+		http.Error(w, fmt.Sprintf("Erro ao reiniciar daemonset: %v", err), k8sErrorStatus(err))
+		return
+	}
+	writeMutation(w, http.StatusOK, fmt.Sprintf("DaemonSet '%s' está sendo reiniciado.", req.Name), opts, ds)
+}
+
+func deleteDaemonSetHandler(w http.ResponseWriter, r *http.Request) {
+	log.Printf("deleteDaemonSetHandler chamado com método: %s", r.Method)
+
+	var req ResourceDeleteRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	if req.Namespace == "" || req.Name == "" {
+		http.Error(w, "Campos 'namespace' e 'name' são obrigatórios", http.StatusBadRequest)
+		return
+	}
+
+	opts := mutateOptions(r)
+	ds, err := k8s.DeleteDaemonSet(req.Name, req.Namespace, opts)
+	if err != nil {
+		log.Printf("ERRO ao Deletar daemonset: %v", err)
+		http.Error(w, fmt.Sprintf("Erro ao Deletar daemonset: %v", err), k8sErrorStatus(err))
+		return
+	}
+	writeMutation(w, http.StatusCreated, fmt.Sprintf("DaemonSet '%s' foi deletado.", req.Name), opts, ds)
+	log.Printf("DaemonSet Deletado com sucesso: %s", req.Name)
+}
@@ -0,0 +1,77 @@
+package http
+
+import (
+	"log"
+	"net/http"
+
+	"backend/k8s"
+)
+
+// DiffResponse lista o diff de cada objeto do manifesto, na ordem em que aparecem.
+type DiffResponse struct {
+	Results []k8s.DiffResult `json:"results"`
+}
+
+// diffManifestHandler mostra o que /apply mudaria no cluster, sem alterar nada.
+// Aceita o mesmo corpo e os mesmos parâmetros (namespace, force) de /apply.
+// Rota: POST /diff
+func diffManifestHandler(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	opts := k8s.ApplyOptions{
+		Namespace: query.Get("namespace"),
+		Force:     query.Get("force") == "true",
+	}
+
+	body := http.MaxBytesReader(w, r.Body, maxManifestSize)
+	results, err := k8s.DiffManifests(r.Context(), body, opts)
+	if err != nil {
+		log.Printf("❌ ERRO: Falha ao ler manifesto: %v", err)
+		http.Error(w, err.Error(), k8sErrorStatus(err))
+		return
+	}
+	writeJSON(w, http.StatusOK, DiffResponse{Results: results})
+}
+
+// diffCreateResourceHandler compara o objeto descrito por um CreateResourceRequest
+// com o objeto existente, como se ele fosse substituído.
+// Rota: POST /diff/createResource
+func diffCreateResourceHandler(w http.ResponseWriter, r *http.Request) {
+	var req CreateResourceRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	obj, err := req.object()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	result, err := k8s.DiffObject(r.Context(), obj)
+	if err != nil {
+		log.Printf("❌ ERRO: Falha ao calcular diff de %s %s/%s: %v", req.Kind, req.Namespace, req.Name, err)
+		http.Error(w, err.Error(), k8sErrorStatus(err))
+		return
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+// diffUpdateDeploymentHandler mostra o que /updateDeployment mudaria no deployment.
+// Rota: POST /diff/updateDeployment
+func diffUpdateDeploymentHandler(w http.ResponseWriter, r *http.Request) {
+	var req ResourceUpdateRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	if req.Namespace == "" || req.Name == "" {
+		http.Error(w, "Campos 'namespace' e 'name' são obrigatórios", http.StatusBadRequest)
+		return
+	}
+
+	result, err := k8s.DiffDeploymentUpdate(r.Context(), req.Namespace, req.Name, k8s.DeploymentUpdate{Image: req.Image, Replicas: req.Replicas})
+	if err != nil {
+		log.Printf("❌ ERRO: Falha ao calcular diff do deployment %s/%s: %v", req.Namespace, req.Name, err)
+		http.Error(w, err.Error(), k8sErrorStatus(err))
+		return
+	}
+	writeJSON(w, http.StatusOK, result)
+}
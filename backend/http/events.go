@@ -0,0 +1,69 @@
+package http
+
+import (
+	"context"
+	"log"
+	"net/http"
+
+	"backend/k8s"
+)
+
+// eventFilter lê os filtros 'type' e 'reason' da query string.
+func eventFilter(r *http.Request) k8s.EventFilter {
+	query := r.URL.Query()
+	return k8s.EventFilter{Type: query.Get("type"), Reason: query.Get("reason")}
+}
+
+// listEventsHandler devolve uma página de eventos do namespace, filtrável por 'type' e 'reason'.
+func listEventsHandler(w http.ResponseWriter, r *http.Request) {
+	log.Printf("📋 listEventsHandler chamado - método: %s", r.Method)
+
+	filter := eventFilter(r)
+	serveNamespacedList(w, r, "eventos", func(namespace string, opts k8s.ListOptions) (k8s.ListResult[k8s.EventInfo], error) {
+		return k8s.ListEvents(namespace, opts, filter)
+	})
+}
+
+// objectEventsHandler devolve a linha do tempo de eventos de um objeto.
+// Rota: GET /events/{namespace}/{kind}/{name}
+func objectEventsHandler(w http.ResponseWriter, r *http.Request) {
+	namespace := r.PathValue("namespace")
+	log.Printf("📋 objectEventsHandler chamado - %s %s/%s", r.PathValue("kind"), namespace, r.PathValue("name"))
+
+	filter := eventFilter(r)
+	filter.Kind = r.PathValue("kind")
+	filter.Name = r.PathValue("name")
+	if namespace == "" || filter.Kind == "" || filter.Name == "" {
+		http.Error(w, "Namespace, kind e nome não podem estar vazios", http.StatusBadRequest)
+		return
+	}
+
+	events, err := k8s.ObjectEvents(namespace, filter)
+	if err != nil {
+		log.Printf("❌ ERRO: Falha ao listar eventos: %v", err)
+		writeK8sError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, events)
+}
+
+// watchEventsHandler transmite os eventos do namespace via Server-Sent Events.
+// Aceita 'type' e 'reason' e, para acompanhar um único objeto, 'kind' e 'name'.
+func watchEventsHandler(w http.ResponseWriter, r *http.Request) {
+	namespace := r.PathValue("namespace")
+	log.Printf("📡 watchEventsHandler chamado - namespace: %s", namespace)
+
+	if namespace == "" {
+		http.Error(w, "O namespace não pode estar vazio", http.StatusBadRequest)
+		return
+	}
+	filter := eventFilter(r)
+	filter.Kind = r.URL.Query().Get("kind")
+	filter.Name = r.URL.Query().Get("name")
+
+	serveSSE(w, r, func(ctx context.Context, send func(any) error) error {
+		return k8s.WatchEvents(ctx, namespace, filter, func(ev k8s.WatchEvent[k8s.EventInfo]) error {
+			return send(ev)
+		})
+	})
+}
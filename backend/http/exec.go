@@ -0,0 +1,195 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"backend/k8s"
+
+	"github.com/gorilla/websocket"
+)
+
+// Canais do protocolo de exec. Cada mensagem binária do WebSocket começa com um
+// byte indicando o canal, no mesmo formato do channel.k8s.io usado pelo kubectl.
+const (
+	execStdin  byte = 0
+	execStdout byte = 1
+	execStderr byte = 2
+	execStatus byte = 3
+	execResize byte = 4
+)
+
+// execPingInterval mantém a conexão viva através de proxies durante sessões ociosas.
+const execPingInterval = 30 * time.Second
+
+// execStatusMessage é enviada no canal de status quando o comando termina.
+type execStatusMessage struct {
+	ExitCode int    `json:"exitCode"`
+	Error    string `json:"error,omitempty"`
+}
+
+var execUpgrader = websocket.Upgrader{
+	// Só o frontend (ou a própria origem do backend) pode abrir sessões pelo navegador
+	CheckOrigin: originAllowed,
+}
+
+// execConn serializa as escritas no WebSocket, que não aceita escritas concorrentes.
+type execConn struct {
+	mu   sync.Mutex
+	conn *websocket.Conn
+}
+
+func (c *execConn) send(channel byte, data []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.conn.WriteMessage(websocket.BinaryMessage, append([]byte{channel}, data...))
+}
+
+func (c *execConn) ping() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(10*time.Second))
+}
+
+// channelWriter direciona stdout/stderr do container para um canal do WebSocket.
+type channelWriter struct {
+	conn    *execConn
+	channel byte
+}
+
+func (w channelWriter) Write(p []byte) (int, error) {
+	if err := w.conn.send(w.channel, p); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// resizeQueue entrega ao remotecommand os redimensionamentos recebidos do navegador.
+type resizeQueue struct {
+	ctx   context.Context
+	sizes chan k8s.TerminalSize
+}
+
+func (q resizeQueue) Next() *k8s.TerminalSize {
+	select {
+	case size := <-q.sizes:
+		return &size
+	case <-q.ctx.Done():
+		return nil
+	}
+}
+
+// execHandler abre um shell (ou o comando informado) em um container e faz a ponte
+// com o WebSocket. Parâmetros: container, command (repetido, um argumento por vez) e tty.
+func execHandler(w http.ResponseWriter, r *http.Request) {
+	namespace := r.PathValue("namespace")
+	pod := r.PathValue("pod")
+	query := r.URL.Query()
+	opts := k8s.ExecOptions{
+		Container: query.Get("container"),
+		Command:   query["command"],
+		TTY:       query.Get("tty") != "false",
+	}
+
+	ws, err := execUpgrader.Upgrade(w, r, nil)
+	if err != nil {
+		// Upgrade já respondeu com o erro HTTP
+		log.Printf("❌ ERRO: Falha ao abrir WebSocket de exec: %v", err)
+		return
+	}
+	defer ws.Close()
+	log.Printf("🖥️  Exec em %s/%s (container=%q, comando=%q) de %s", namespace, pod, opts.Container, opts.Command, r.RemoteAddr)
+
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+
+	conn := &execConn{conn: ws}
+	stdin, stdinWriter := io.Pipe()
+	resize := resizeQueue{ctx: ctx, sizes: make(chan k8s.TerminalSize, 1)}
+
+	// Leitura do navegador: stdin e redimensionamentos. Fechar o WebSocket encerra a sessão.
+	go func() {
+		defer cancel()
+		defer stdinWriter.Close()
+		for {
+			_, message, err := ws.ReadMessage()
+			if err != nil {
+				return
+			}
+			if len(message) == 0 {
+				continue
+			}
+			switch message[0] {
+			case execStdin:
+				if _, err := stdinWriter.Write(message[1:]); err != nil {
+					return
+				}
+			case execResize:
+				var size k8s.TerminalSize
+				if err := json.Unmarshal(message[1:], &size); err != nil || size.Width == 0 || size.Height == 0 {
+					continue
+				}
+				// Só o tamanho mais recente importa; descarta o pendente
+				select {
+				case <-resize.sizes:
+				default:
+				}
+				resize.sizes <- size
+			}
+		}
+	}()
+
+	go func() {
+		ticker := time.NewTicker(execPingInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := conn.ping(); err != nil {
+					cancel()
+					return
+				}
+			}
+		}
+	}()
+
+	streams := k8s.ExecStreams{
+		Stdin:  stdin,
+		Stdout: channelWriter{conn: conn, channel: execStdout},
+		Stderr: channelWriter{conn: conn, channel: execStderr},
+	}
+	if opts.TTY {
+		streams.Resize = resize
+	}
+	err = k8s.ExecInPod(ctx, namespace, pod, opts, streams)
+
+	status := execStatusMessage{}
+	if err != nil {
+		if code, ok := k8s.ExitCode(err); ok {
+			status.ExitCode = code
+		} else if ctx.Err() == nil {
+			log.Printf("❌ ERRO: Falha no exec em %s/%s: %v", namespace, pod, err)
+			status.ExitCode = -1
+			status.Error = err.Error()
+		}
+	}
+	log.Printf("🖥️  Exec em %s/%s encerrado (código %d)", namespace, pod, status.ExitCode)
+
+	if ctx.Err() == nil {
+		payload, _ := json.Marshal(status)
+		conn.send(execStatus, payload)
+		conn.mu.Lock()
+		ws.WriteControl(websocket.CloseMessage,
+			websocket.FormatCloseMessage(websocket.CloseNormalClosure, fmt.Sprintf("exit %d", status.ExitCode)),
+			time.Now().Add(time.Second))
+		conn.mu.Unlock()
+	}
+}
@@ -0,0 +1,55 @@
+package http
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+
+	"backend/k8s"
+)
+
+// parseExportOptions lê os parâmetros 'secrets' (redact ou omit) e 'kinds' da exportação.
+func parseExportOptions(r *http.Request) k8s.ExportOptions {
+	query := r.URL.Query()
+	return k8s.ExportOptions{
+		Secrets: query.Get("secrets"),
+		Kinds:   splitQueryList(query.Get("kinds")),
+	}
+}
+
+// writeYAML escreve um YAML exportado. Com 'download=true', o navegador salva o arquivo.
+func writeYAML(w http.ResponseWriter, r *http.Request, filename string, data []byte) {
+	w.Header().Set("Content-Type", "application/yaml")
+	if r.URL.Query().Get("download") == "true" {
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
+
+// exportResourceHandler devolve o YAML limpo de um objeto de qualquer tipo.
+// Chamado por getResourceHandler com 'format=yaml'.
+func exportResourceHandler(w http.ResponseWriter, r *http.Request, namespace, kind, name string) {
+	data, err := k8s.ExportResource(r.Context(), namespace, kind, name, parseExportOptions(r))
+	if err != nil {
+		log.Printf("❌ ERRO: Falha ao exportar %s %s/%s: %v", kind, namespace, name, err)
+		writeK8sError(w, err)
+		return
+	}
+	writeYAML(w, r, fmt.Sprintf("%s-%s.yaml", kind, name), data)
+}
+
+// exportNamespaceHandler devolve em um único YAML os recursos do namespace.
+// Rota: GET /export/{namespace}
+func exportNamespaceHandler(w http.ResponseWriter, r *http.Request) {
+	namespace := r.PathValue("namespace")
+	log.Printf("📦 exportNamespaceHandler chamado - namespace: %s", namespace)
+
+	data, err := k8s.ExportNamespace(r.Context(), namespace, parseExportOptions(r))
+	if err != nil {
+		log.Printf("❌ ERRO: Falha ao exportar namespace %s: %v", namespace, err)
+		writeK8sError(w, err)
+		return
+	}
+	writeYAML(w, r, namespace+".yaml", data)
+}
@@ -0,0 +1,67 @@
+package http
+
+import (
+	"log"
+	"net/http"
+
+	"backend/k8s"
+)
+
+// getResourceHandler devolve o modelo de detalhes de um único recurso,
+// com objetos relacionados e eventos recentes.
+// Rota: GET /resource/{namespace}/{kind}/{name}
+func getResourceHandler(w http.ResponseWriter, r *http.Request) {
+	namespace := r.PathValue("namespace")
+	kind := r.PathValue("kind")
+	name := r.PathValue("name")
+	log.Printf("🔍 getResourceHandler chamado - %s %s/%s", kind, namespace, name)
+
+	if namespace == "" || name == "" {
+		http.Error(w, "Namespace e nome não podem estar vazios", http.StatusBadRequest)
+		return
+	}
+
+	// Com format=yaml, qualquer tipo é aceito e a resposta é o YAML reaplicável do objeto
+	if r.URL.Query().Get("format") == "yaml" {
+		exportResourceHandler(w, r, namespace, kind, name)
+		return
+	}
+
+	var detail any
+	var err error
+	switch kind {
+	case "pod":
+		detail, err = k8s.GetPod(namespace, name)
+	case "deployment":
+		detail, err = k8s.GetDeployment(namespace, name)
+	case "service":
+		detail, err = k8s.GetService(namespace, name)
+	case "configmap":
+		detail, err = k8s.GetConfigMap(namespace, name)
+	case "secret":
+		detail, err = k8s.GetSecret(namespace, name)
+	case "statefulset":
+		detail, err = k8s.GetStatefulSet(namespace, name)
+	case "daemonset":
+		detail, err = k8s.GetDaemonSet(namespace, name)
+	case "job":
+		detail, err = k8s.GetJob(namespace, name)
+	case "cronjob":
+		detail, err = k8s.GetCronJob(namespace, name)
+	case "pvc":
+		detail, err = k8s.GetPVC(namespace, name)
+	case "hpa":
+		detail, err = k8s.GetHPA(namespace, name)
+	default:
+		http.Error(w, "'kind' inválido. Use: pod, deployment, statefulset, daemonset, job, cronjob, service, configmap, secret, pvc, hpa", http.StatusBadRequest)
+		return
+	}
+
+	if err != nil {
+		log.Printf("❌ ERRO: Falha ao buscar %s %s/%s: %v", kind, namespace, name, err)
+		writeK8sError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, detail)
+}
@@ -0,0 +1,89 @@
+package http
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+
+	"backend/k8s"
+)
+
+// HPARequest cria ou altera um HPA (autoscaling/v2) sobre um deployment. Na alteração,
+// 'deployment' é ignorado e as metas informadas substituem as atuais.
+type HPARequest struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	k8s.HPASpec
+}
+
+func listHPAsHandler(w http.ResponseWriter, r *http.Request) {
+	log.Printf("📋 listHPAsHandler chamado - método: %s", r.Method)
+	serveNamespacedList(w, r, "hpas", k8s.ListHPAs)
+}
+
+// createHPAHandler cria um HPA para um deployment.
+// Rota: POST /createHPA
+func createHPAHandler(w http.ResponseWriter, r *http.Request) {
+	var req HPARequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	if req.Namespace == "" || req.Name == "" || req.Deployment == "" {
+		http.Error(w, "Campos 'namespace', 'name' e 'deployment' são obrigatórios", http.StatusBadRequest)
+		return
+	}
+
+	opts := mutateOptions(r)
+	hpa, err := k8s.CreateHPA(req.Namespace, req.Name, req.HPASpec, opts)
+	if err != nil {
+		log.Printf("ERRO ao criar hpa: %v", err)
+		http.Error(w, fmt.Sprintf("Erro ao criar hpa: %v", err), k8sErrorStatus(err))
+		return
+	}
+	writeMutation(w, http.StatusCreated, fmt.Sprintf("HPA '%s' foi criado para o deployment '%s'.", req.Name, req.Deployment), opts, hpa)
+}
+
+// updateHPAHandler altera as réplicas mínima e máxima e as metas de um HPA.
+// Rota: POST /updateHPA
+func updateHPAHandler(w http.ResponseWriter, r *http.Request) {
+	var req HPARequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	if req.Namespace == "" || req.Name == "" {
+		http.Error(w, "Campos 'namespace' e 'name' são obrigatórios", http.StatusBadRequest)
+		return
+	}
+
+	opts := mutateOptions(r)
+	hpa, err := k8s.UpdateHPA(req.Namespace, req.Name, req.HPASpec, opts)
+	if err != nil {
+		log.Printf("ERRO ao atualizar hpa: %v", err)
+		http.Error(w, fmt.Sprintf("Erro ao atualizar hpa: %v", err), k8sErrorStatus(err))
+		return
+	}
+	writeMutation(w, http.StatusOK, fmt.Sprintf("HPA '%s' foi atualizado.", req.Name), opts, hpa)
+}
+
+func deleteHPAHandler(w http.ResponseWriter, r *http.Request) {
+	log.Printf("deleteHPAHandler chamado com método: %s", r.Method)
+
+	var req ResourceDeleteRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	if req.Namespace == "" || req.Name == "" {
+		http.Error(w, "Campos 'namespace' e 'name' são obrigatórios", http.StatusBadRequest)
+		return
+	}
+
+	opts := mutateOptions(r)
+	hpa, err := k8s.DeleteHPA(req.Name, req.Namespace, opts)
+	if err != nil {
+		log.Printf("ERRO ao Deletar hpa: %v", err)
+		http.Error(w, fmt.Sprintf("Erro ao Deletar hpa: %v", err), k8sErrorStatus(err))
+		return
+	}
+	writeMutation(w, http.StatusCreated, fmt.Sprintf("HPA '%s' foi deletado; o deployment mantém as réplicas atuais.", req.Name), opts, hpa)
+	log.Printf("HPA Deletado com sucesso: %s", req.Name)
+}
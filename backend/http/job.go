@@ -0,0 +1,86 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+
+	"backend/k8s"
+)
+
+// CreateJobRequest cria um Job avulso com um container.
+type CreateJobRequest struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	k8s.JobSpec
+}
+
+func listJobsHandler(w http.ResponseWriter, r *http.Request) {
+	log.Printf("📋 listJobsHandler chamado - método: %s", r.Method)
+	serveNamespacedList(w, r, "jobs", k8s.ListJobs)
+}
+
+// createJobHandler cria um Job avulso.
+// Rota: POST /createJob
+func createJobHandler(w http.ResponseWriter, r *http.Request) {
+	var req CreateJobRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	if req.Namespace == "" || req.Name == "" || req.Image == "" {
+		http.Error(w, "Campos 'namespace', 'name' e 'image' são obrigatórios", http.StatusBadRequest)
+		return
+	}
+
+	opts := mutateOptions(r)
+	created, err := k8s.CreateObject(k8s.BuildJob(req.Namespace, req.Name, req.JobSpec), opts)
+	if err != nil {
+		log.Printf("ERRO ao criar job: %v", err)
+		http.Error(w, fmt.Sprintf("Erro ao criar job: %v", err), k8sErrorStatus(err))
+		return
+	}
+	writeMutation(w, http.StatusCreated, fmt.Sprintf("Job '%s' está sendo criado.", req.Name), opts, created)
+}
+
+func deleteJobHandler(w http.ResponseWriter, r *http.Request) {
+	log.Printf("deleteJobHandler chamado com método: %s", r.Method)
+
+	var req ResourceDeleteRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	if req.Namespace == "" || req.Name == "" {
+		http.Error(w, "Campos 'namespace' e 'name' são obrigatórios", http.StatusBadRequest)
+		return
+	}
+
+	opts := mutateOptions(r)
+	job, err := k8s.DeleteJob(req.Name, req.Namespace, opts)
+	if err != nil {
+		log.Printf("ERRO ao Deletar job: %v", err)
+		http.Error(w, fmt.Sprintf("Erro ao Deletar job: %v", err), k8sErrorStatus(err))
+		return
+	}
+	writeMutation(w, http.StatusCreated, fmt.Sprintf("Job '%s' foi deletado.", req.Name), opts, job)
+	log.Printf("Job Deletado com sucesso: %s", req.Name)
+}
+
+// jobLogsHandler junta os logs de todos os pods de um Job, inclusive das tentativas
+// que falharam, prefixando cada linha com o pod e o container de origem.
+// Rota: GET /jobLogs/{namespace}/{name}
+func jobLogsHandler(w http.ResponseWriter, r *http.Request) {
+	namespace := r.PathValue("namespace")
+	name := r.PathValue("name")
+	log.Printf("📜 jobLogsHandler chamado - %s/%s", namespace, name)
+
+	opts, err := parseLogOptions(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	serveLogs(w, r, true, func(ctx context.Context, send func(k8s.LogLine) error) error {
+		return k8s.StreamJobLogs(ctx, namespace, name, opts, send)
+	})
+}
@@ -0,0 +1,84 @@
+package http
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"backend/k8s"
+)
+
+// parseListOptions lê os parâmetros de paginação 'limit' e 'continue' e
+// a ordenação 'sort' da query string.
+func parseListOptions(r *http.Request) (k8s.ListOptions, error) {
+	query := r.URL.Query()
+	opts := k8s.ListOptions{
+		Continue: query.Get("continue"),
+		Sort:     splitQueryList(query.Get("sort")),
+	}
+
+	if raw := query.Get("limit"); raw != "" {
+		limit, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil || limit < 1 {
+			return opts, fmt.Errorf("parâmetro 'limit' deve ser um inteiro positivo")
+		}
+		opts.Limit = limit
+	}
+	return opts, nil
+}
+
+// splitQueryList separa um parâmetro de query no formato "a,b,c", ignorando itens vazios.
+func splitQueryList(raw string) []string {
+	var values []string
+	for _, value := range strings.Split(raw, ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// serveList executa uma listagem paginada e escreve o envelope JSON na resposta.
+// Com o parâmetro 'fields', cada item é reduzido aos campos pedidos.
+func serveList[T any](w http.ResponseWriter, r *http.Request, resource string, list func(k8s.ListOptions) (k8s.ListResult[T], error)) {
+	opts, err := parseListOptions(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	result, err := list(opts)
+	if err != nil {
+		log.Printf("❌ ERRO: Falha ao listar %s: %v", resource, err)
+		writeK8sError(w, err)
+		return
+	}
+
+	var payload any = result
+	if fields := splitQueryList(r.URL.Query().Get("fields")); len(fields) > 0 {
+		projected, err := k8s.ProjectFields(result, fields)
+		if err != nil {
+			http.Error(w, err.Error(), k8sErrorStatus(err))
+			return
+		}
+		payload = projected
+	}
+
+	log.Printf("📤 Enviando %d %s", len(result.Items), resource)
+	writeJSON(w, http.StatusOK, payload)
+}
+
+// serveNamespacedList valida o namespace da URL e delega para serveList.
+func serveNamespacedList[T any](w http.ResponseWriter, r *http.Request, resource string, list func(string, k8s.ListOptions) (k8s.ListResult[T], error)) {
+	namespace := r.PathValue("namespace")
+	if namespace == "" {
+		http.Error(w, "O namespace não pode estar vazio", http.StatusBadRequest)
+		return
+	}
+
+	serveList(w, r, resource, func(opts k8s.ListOptions) (k8s.ListResult[T], error) {
+		return list(namespace, opts)
+	})
+}
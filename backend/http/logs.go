@@ -0,0 +1,153 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"backend/k8s"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// parseLogOptions lê da query string os parâmetros de PodLogOptions:
+// container, follow, tailLines, sinceSeconds, sinceTime (RFC3339), timestamps e previous.
+func parseLogOptions(r *http.Request) (k8s.LogOptions, error) {
+	query := r.URL.Query()
+	opts := k8s.LogOptions{
+		Container:  query.Get("container"),
+		Follow:     query.Get("follow") == "true",
+		Timestamps: query.Get("timestamps") == "true",
+		Previous:   query.Get("previous") == "true",
+	}
+
+	for param, target := range map[string]**int64{"tailLines": &opts.TailLines, "sinceSeconds": &opts.SinceSeconds} {
+		raw := query.Get(param)
+		if raw == "" {
+			continue
+		}
+		value, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil || value < 0 {
+			return opts, fmt.Errorf("parâmetro '%s' deve ser um inteiro não negativo", param)
+		}
+		*target = &value
+	}
+
+	if raw := query.Get("sinceTime"); raw != "" {
+		sinceTime, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			return opts, fmt.Errorf("parâmetro 'sinceTime' deve estar no formato RFC3339")
+		}
+		opts.SinceTime = &metav1.Time{Time: sinceTime}
+	}
+	return opts, nil
+}
+
+// serveLogs escreve as linhas de log como texto (padrão) ou, com 'format=sse',
+// como Server-Sent Events com um LogLine em JSON por mensagem. Com prefix, cada
+// linha de texto recebe "[pod/container]" para identificar a origem.
+func serveLogs(w http.ResponseWriter, r *http.Request, prefix bool, stream func(ctx context.Context, send func(k8s.LogLine) error) error) {
+	if r.URL.Query().Get("format") == "sse" {
+		serveSSE(w, r, func(ctx context.Context, send func(any) error) error {
+			return stream(ctx, func(line k8s.LogLine) error { return send(line) })
+		})
+		return
+	}
+
+	serveTextStream(w, r, func(ctx context.Context, write func(string) error) error {
+		return stream(ctx, func(line k8s.LogLine) error {
+			if prefix {
+				return write(fmt.Sprintf("[%s/%s] %s", line.Pod, line.Container, line.Line))
+			}
+			return write(line.Line)
+		})
+	})
+}
+
+// podLogsHandler transmite os logs de um container de um pod.
+// Rota: GET /logs/{namespace}/{pod}
+func podLogsHandler(w http.ResponseWriter, r *http.Request) {
+	namespace := r.PathValue("namespace")
+	pod := r.PathValue("pod")
+	log.Printf("📜 podLogsHandler chamado - %s/%s", namespace, pod)
+
+	opts, err := parseLogOptions(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	serveLogs(w, r, false, func(ctx context.Context, send func(k8s.LogLine) error) error {
+		return k8s.StreamPodLogs(ctx, namespace, pod, opts, send)
+	})
+}
+
+// deploymentLogsHandler junta os logs de todos os pods de um deployment,
+// prefixando cada linha com o pod e o container de origem.
+// Rota: GET /deploymentLogs/{namespace}/{name}
+func deploymentLogsHandler(w http.ResponseWriter, r *http.Request) {
+	namespace := r.PathValue("namespace")
+	name := r.PathValue("name")
+	log.Printf("📜 deploymentLogsHandler chamado - %s/%s", namespace, name)
+
+	opts, err := parseLogOptions(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	serveLogs(w, r, true, func(ctx context.Context, send func(k8s.LogLine) error) error {
+		return k8s.StreamDeploymentLogs(ctx, namespace, name, opts, send)
+	})
+}
+
+// archiveWriter só envia os headers do download na primeira escrita, permitindo
+// responder com o status de erro adequado enquanto nada foi escrito.
+type archiveWriter struct {
+	w        http.ResponseWriter
+	filename string
+	started  bool
+}
+
+func (a *archiveWriter) Write(p []byte) (int, error) {
+	if !a.started {
+		a.w.Header().Set("Content-Type", "application/gzip")
+		a.w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", a.filename))
+		a.w.WriteHeader(http.StatusOK)
+		a.started = true
+	}
+	return a.w.Write(p)
+}
+
+// deploymentLogsArchiveHandler baixa um .tar.gz com os logs atuais e anteriores de
+// todos os containers dos pods do deployment, na janela definida por sinceSeconds/sinceTime.
+// Rota: GET /deploymentLogs/{namespace}/{name}/archive
+func deploymentLogsArchiveHandler(w http.ResponseWriter, r *http.Request) {
+	namespace := r.PathValue("namespace")
+	name := r.PathValue("name")
+	log.Printf("📦 deploymentLogsArchiveHandler chamado - %s/%s", namespace, name)
+
+	opts, err := parseLogOptions(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	filename := fmt.Sprintf("%s-%s-logs-%s.tar.gz", namespace, name, time.Now().UTC().Format("20060102-150405"))
+	archive := &archiveWriter{w: w, filename: filename}
+	err = k8s.WriteDeploymentLogsArchive(r.Context(), namespace, name, opts, archive)
+	if err != nil {
+		log.Printf("❌ ERRO ao gerar arquivo de logs de %s/%s: %v", namespace, name, err)
+		if !archive.started {
+			writeK8sError(w, err)
+		}
+		return
+	}
+	if !archive.started {
+		w.WriteHeader(http.StatusOK)
+	}
+	log.Printf("✅ Arquivo de logs enviado: %s", filename)
+}
@@ -0,0 +1,65 @@
+package http
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+
+	"backend/k8s"
+)
+
+// proxyHandler encaminha qualquer requisição HTTP para a porta de um pod ou service.
+// Rota: /proxy/{namespace}/{kind}/{target}/{path...}, com target no formato nome:porta.
+func proxyHandler(w http.ResponseWriter, r *http.Request) {
+	namespace := r.PathValue("namespace")
+	kind := r.PathValue("kind")
+	target := r.PathValue("target")
+	prefix := fmt.Sprintf("/proxy/%s/%s/%s", namespace, kind, target)
+
+	// Sem a barra final, os links relativos da página apontariam para fora do proxy
+	if r.URL.Path == prefix {
+		http.Redirect(w, r, prefix+"/", http.StatusMovedPermanently)
+		return
+	}
+
+	proxy, err := k8s.NewProxy(namespace, kind, target, prefix)
+	if err != nil {
+		log.Printf("❌ ERRO: Falha ao criar proxy para %s: %v", r.URL.Path, err)
+		http.Error(w, err.Error(), k8sErrorStatus(err))
+		return
+	}
+
+	// O navegador não reenvia o token nos recursos da página; guarda-o em um cookie
+	// restrito ao caminho deste proxy
+	if token := r.URL.Query().Get("token"); token != "" {
+		http.SetCookie(w, &http.Cookie{
+			Name:     tokenCookie,
+			Value:    token,
+			Path:     prefix + "/",
+			HttpOnly: true,
+			SameSite: http.SameSiteStrictMode,
+		})
+	}
+
+	removeProxyCredentials(r)
+	log.Printf("🔀 Proxy %s %s -> %s %s/%s", r.Method, r.URL.Path, kind, namespace, target)
+	proxy.ServeHTTP(w, r)
+}
+
+// removeProxyCredentials tira da requisição o token de acesso ao k8s-manager para
+// que ele não chegue à aplicação. Os demais cookies são mantidos.
+func removeProxyCredentials(r *http.Request) {
+	query := r.URL.Query()
+	if query.Has("token") {
+		query.Del("token")
+		r.URL.RawQuery = query.Encode()
+	}
+
+	cookies := r.Cookies()
+	r.Header.Del("Cookie")
+	for _, cookie := range cookies {
+		if cookie.Name != tokenCookie {
+			r.AddCookie(cookie)
+		}
+	}
+}
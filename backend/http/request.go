@@ -8,6 +8,8 @@ import (
 	"net/http"
 
 	"backend/k8s" // Verifique se o nome do pacote está correto
+
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
 // CORS middleware para permitir requisições do frontend
@@ -35,131 +37,72 @@ func corsMiddleware(next http.HandlerFunc) http.HandlerFunc {
 	}
 }
 
-// listPodsHandler agora lê o namespace da URL
-func listPodsHandler(w http.ResponseWriter, r *http.Request) {
-	// 1. Extrai o valor do placeholder "{namespace}" da URL
-	namespace := r.PathValue("namespace")
-
-	// 2. [IMPORTANTE] Validação: Verifica se o namespace não está vazio
-	if namespace == "" {
-		http.Error(w, "O namespace não pode estar vazio", http.StatusBadRequest)
-		return
-	}
-
-	// 3. [IMPORTANTE] Trate o erro da sua função! Não ignore com _
-	jsonAllPods, err := k8s.ListPods(namespace)
-	if err != nil {
-		// Loga o erro no servidor
-		log.Printf("ERRO: Falha ao listar pods no namespace '%s': %v", namespace, err)
-		http.Error(w, "Erro ao buscar dados do Kubernetes", http.StatusInternalServerError)
-		return
-	}
+// restrictedCorsMiddleware é o corsMiddleware das rotas que devolvem dados sensíveis:
+// em vez de '*', só libera as origens aceitas por originAllowed, para que outros sites
+// não consigam ler as respostas.
+func restrictedCorsMiddleware(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log.Printf("Requisição recebida: %s %s", r.Method, r.URL.Path)
 
-	// 4. [MELHORIA] Defina o Content-Type para que os clientes saibam que é JSON
-	w.Header().Set("Content-Type", "application/json")
+		w.Header().Add("Vary", "Origin")
+		if origin := r.Header.Get("Origin"); origin != "" && originAllowed(r) {
+			w.Header().Set("Access-Control-Allow-Origin", origin)
+			w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
+			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-Requested-With")
+			w.Header().Set("Access-Control-Max-Age", "86400")
+		}
 
-	// 5. Converte para JSON
-	jsonResponse, err := json.Marshal(jsonAllPods)
-	if err != nil {
-		// Se a serialização falhar (raro, mas possível), avise o servidor.
-		// Não use log.Fatal(), pois isso derruba o servidor!
-		log.Printf("ERRO: Falha ao serializar JSON: %v", err)
-		http.Error(w, "Erro ao formatar resposta", http.StatusInternalServerError)
-		return
+		if r.Method == "OPTIONS" {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		next(w, r)
 	}
+}
 
-	// 6. Envia a resposta
-	w.Write(jsonResponse)
+// listPodsHandler lê o namespace da URL e devolve uma página de pods.
+// Aceita os parâmetros de query 'limit' e 'continue' para paginação.
+func listPodsHandler(w http.ResponseWriter, r *http.Request) {
+	log.Printf("📋 listPodsHandler chamado - método: %s", r.Method)
+	serveNamespacedList(w, r, "pods", k8s.ListPods)
 }
 
 func listDeploymentsHandler(w http.ResponseWriter, r *http.Request) {
 	log.Printf("📋 listDeploymentsHandler chamado - método: %s", r.Method)
-
-	namespace := r.PathValue("namespace")
-	if namespace == "" {
-		http.Error(w, "O namespace não pode estar vazio", http.StatusBadRequest)
-		return
-	}
-
-	jsonAllDeployments, err := k8s.ListDeployments(namespace)
-	if err != nil {
-		log.Printf("❌ ERRO: Falha ao listar deployments: %v", err)
-		http.Error(w, "Erro ao buscar dados do Kubernetes", http.StatusInternalServerError)
-		return
-	}
-
-	log.Printf("✅ Deployments encontrados: %v", jsonAllDeployments)
-
-	w.Header().Set("Content-Type", "application/json")
-	jsonResponse, err := json.Marshal(jsonAllDeployments)
-	if err != nil {
-		log.Printf("❌ ERRO: Falha ao serializar JSON: %v", err)
-		http.Error(w, "Erro ao formatar resposta", http.StatusInternalServerError)
-		return
-	}
-
-	w.Write(jsonResponse)
+	serveNamespacedList(w, r, "deployments", k8s.ListDeployments)
 }
 
 func listServicesHandler(w http.ResponseWriter, r *http.Request) {
 	log.Printf("📋 listServicesHandler chamado - método: %s", r.Method)
-
-	namespace := r.PathValue("namespace")
-	if namespace == "" {
-		http.Error(w, "O namespace não pode estar vazio", http.StatusBadRequest)
-		return
-	}
-
-	jsonAllServices, err := k8s.ListServices(namespace)
-	if err != nil {
-		log.Printf("❌ ERRO: Falha ao listar services: %v", err)
-		http.Error(w, "Erro ao buscar dados do Kubernetes", http.StatusInternalServerError)
-		return
-	}
-
-	log.Printf("✅ Services encontrados: %v", jsonAllServices)
-
-	w.Header().Set("Content-Type", "application/json")
-	jsonResponse, err := json.Marshal(jsonAllServices)
-	if err != nil {
-		log.Printf("❌ ERRO: Falha ao serializar JSON: %v", err)
-		http.Error(w, "Erro ao formatar resposta", http.StatusInternalServerError)
-		return
-	}
-
-	w.Write(jsonResponse)
+	serveNamespacedList(w, r, "services", k8s.ListServices)
 }
 
 func listNsHandler(w http.ResponseWriter, r *http.Request) {
 	log.Printf("📋 listNsHandler chamado - método: %s", r.Method)
+	// 'counts=true' inclui a contagem de recursos e o uso das ResourceQuotas de cada namespace
+	withCounts := r.URL.Query().Get("counts") == "true"
+	serveList(w, r, "namespaces", func(opts k8s.ListOptions) (k8s.ListResult[k8s.NamespaceInfo], error) {
+		return k8s.ListNamespaces(opts, withCounts)
+	})
+}
 
-	// 3. [IMPORTANTE] Trate o erro da sua função! Não ignore com _
-	jsonAllNs, err := k8s.ListNamespaces()
-	if err != nil {
-		// Loga o erro no servidor
-		log.Printf("❌ ERRO: Falha ao listar namespaces: %v", err)
-		http.Error(w, "Erro ao buscar dados do Kubernetes", http.StatusInternalServerError)
-		return
-	}
-
-	log.Printf("✅ Namespaces encontrados: %v", jsonAllNs)
-
-	// 4. [MELHORIA] Defina o Content-Type para que os clientes saibam que é JSON
-	w.Header().Set("Content-Type", "application/json")
+// mutateOptions lê o parâmetro 'dryRun' das operações que alteram o cluster.
+func mutateOptions(r *http.Request) k8s.MutateOptions {
+	return k8s.MutateOptions{DryRun: r.URL.Query().Get("dryRun") == "true"}
+}
 
-	// 5. Converte para JSON
-	jsonResponse, err := json.Marshal(jsonAllNs)
-	if err != nil {
-		// Se a serialização falhar (raro, mas possível), avise o servidor.
-		// Não use log.Fatal(), pois isso derruba o servidor!
-		log.Printf("❌ ERRO: Falha ao serializar JSON: %v", err)
-		http.Error(w, "Erro ao formatar resposta", http.StatusInternalServerError)
-		return
+// decodeJSON lê o corpo JSON em v. Se falhar, já responde com 400 e devolve false.
+func decodeJSON(w http.ResponseWriter, r *http.Request, v any) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		if err == io.EOF {
+			http.Error(w, "Corpo da requisição não pode ser vazio", http.StatusBadRequest)
+			return false
+		}
+		log.Printf("ERRO ao decodificar JSON: %v", err)
+		http.Error(w, "JSON mal formatado", http.StatusBadRequest)
+		return false
 	}
-
-	// 6. Envia a resposta
-	log.Printf("📤 Enviando resposta com %d namespaces", len(jsonAllNs))
-	w.Write(jsonResponse)
+	return true
 }
 
 type ResourceDeleteRequest struct {
@@ -209,21 +152,17 @@ func deletePodHandler(w http.ResponseWriter, r *http.Request) {
 
 	log.Printf("Deletando pod: %s no namespace: %s ", podReq.Name, podReq.Namespace)
 
-	// Chama a função de criação do pod
-	err = k8s.DeletePod(podReq.Name, podReq.Namespace)
+	// Chama a função de exclusão do pod
+	opts := mutateOptions(r)
+	pod, err := k8s.DeletePod(podReq.Name, podReq.Namespace, opts)
 	if err != nil {
 		log.Printf("ERRO ao Deletar pod: %v", err)
-		http.Error(w, fmt.Sprintf("Erro ao Deletar pod: %v", err), http.StatusInternalServerError)
+		http.Error(w, fmt.Sprintf("Erro ao Deletar pod: %v", err), k8sErrorStatus(err))
 		return
 	}
 
 	// 7. Envie uma resposta de sucesso para o cliente.
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusCreated) // Status 201 Created é apropriado aqui.
-
-	// Você pode retornar uma mensagem de sucesso simples
-	response := map[string]string{"status": "sucesso", "message": fmt.Sprintf("Pod '%s' foi deletado.", podReq.Name)}
-	json.NewEncoder(w).Encode(response)
+	writeMutation(w, http.StatusCreated, fmt.Sprintf("Pod '%s' foi deletado.", podReq.Name), opts, pod)
 
 	log.Printf("Pod Deletado com sucesso: %s", podReq.Name)
 }
@@ -248,18 +187,15 @@ func deleteDeploymentHandler(w http.ResponseWriter, r *http.Request) {
 
 	log.Printf("Deletando deployment: %s no namespace: %s", deploymentReq.Name, deploymentReq.Namespace)
 
-	err = k8s.DeleteDeployment(deploymentReq.Name, deploymentReq.Namespace)
+	opts := mutateOptions(r)
+	deployment, err := k8s.DeleteDeployment(deploymentReq.Name, deploymentReq.Namespace, opts)
 	if err != nil {
 		log.Printf("ERRO ao Deletar deployment: %v", err)
-		http.Error(w, fmt.Sprintf("Erro ao Deletar deployment: %v", err), http.StatusInternalServerError)
+		http.Error(w, fmt.Sprintf("Erro ao Deletar deployment: %v", err), k8sErrorStatus(err))
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusCreated)
-
-	response := map[string]string{"status": "sucesso", "message": fmt.Sprintf("Deployment '%s' foi deletado.", deploymentReq.Name)}
-	json.NewEncoder(w).Encode(response)
+	writeMutation(w, http.StatusCreated, fmt.Sprintf("Deployment '%s' foi deletado.", deploymentReq.Name), opts, deployment)
 	log.Printf("Deployment Deletado com sucesso: %s", deploymentReq.Name)
 }
 
@@ -283,18 +219,15 @@ func deleteServiceHandler(w http.ResponseWriter, r *http.Request) {
 
 	log.Printf("Deletando service: %s no namespace: %s", serviceReq.Name, serviceReq.Namespace)
 
-	err = k8s.DeleteService(serviceReq.Name, serviceReq.Namespace)
+	opts := mutateOptions(r)
+	service, err := k8s.DeleteService(serviceReq.Name, serviceReq.Namespace, opts)
 	if err != nil {
 		log.Printf("ERRO ao Deletar service: %v", err)
-		http.Error(w, fmt.Sprintf("Erro ao Deletar service: %v", err), http.StatusInternalServerError)
+		http.Error(w, fmt.Sprintf("Erro ao Deletar service: %v", err), k8sErrorStatus(err))
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusCreated)
-
-	response := map[string]string{"status": "sucesso", "message": fmt.Sprintf("Service '%s' foi deletado.", serviceReq.Name)}
-	json.NewEncoder(w).Encode(response)
+	writeMutation(w, http.StatusCreated, fmt.Sprintf("Service '%s' foi deletado.", serviceReq.Name), opts, service)
 	log.Printf("Service Deletado com sucesso: %s", serviceReq.Name)
 }
 
@@ -318,23 +251,20 @@ func deleteSecretHandler(w http.ResponseWriter, r *http.Request) {
 
 	log.Printf("Deletando secret: %s no namespace: %s", secretReq.Name, secretReq.Namespace)
 
-	err = k8s.DeleteSecret(secretReq.Name, secretReq.Namespace)
+	opts := mutateOptions(r)
+	secret, err := k8s.DeleteSecret(secretReq.Name, secretReq.Namespace, opts)
 	if err != nil {
 		log.Printf("ERRO ao Deletar secret: %v", err)
-		http.Error(w, fmt.Sprintf("Erro ao Deletar secret: %v", err), http.StatusInternalServerError)
+		http.Error(w, fmt.Sprintf("Erro ao Deletar secret: %v", err), k8sErrorStatus(err))
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusCreated)
-
-	response := map[string]string{"status": "sucesso", "message": fmt.Sprintf("Secret '%s' foi deletado.", secretReq.Name)}
-	json.NewEncoder(w).Encode(response)
+	writeMutation(w, http.StatusCreated, fmt.Sprintf("Secret '%s' foi deletado.", secretReq.Name), opts, secret)
 	log.Printf("Secret Deletado com sucesso: %s", secretReq.Name)
 }
 
 // CreateResourceRequest define um payload unificado para criação de recursos
-// kind: "container" | "deployment" | "secret" | "ingress"
+// kind: "container" | "deployment" | "secret" | "configmap" | "ingress" | "service" | "namespace"
 type CreateResourceRequest struct {
 	Kind          string            `json:"kind"`
 	Namespace     string            `json:"namespace"`
@@ -352,6 +282,52 @@ type CreateResourceRequest struct {
 	Env           map[string]string `json:"env,omitempty"`
 }
 
+// object valida o pedido e monta o objeto correspondente, sem enviá-lo ao cluster.
+func (req CreateResourceRequest) object() (runtime.Object, error) {
+	if req.Kind != "namespace" {
+		if req.Kind == "" || req.Namespace == "" || req.Name == "" {
+			return nil, fmt.Errorf("Campos 'kind', 'namespace' e 'name' são obrigatórios")
+		}
+	}
+	switch req.Kind {
+	case "container", "pod":
+		if req.Image == "" {
+			return nil, fmt.Errorf("Campo 'image' é obrigatório para container/pod")
+		}
+		return k8s.BuildPod(req.Namespace, req.Image, req.Name), nil
+	case "deployment":
+		if req.Image == "" || req.Replicas == nil {
+			return nil, fmt.Errorf("Campos 'image' e 'replicas' são obrigatórios para deployment")
+		}
+		var cport int32 = 0
+		if req.ContainerPort != nil {
+			cport = *req.ContainerPort
+		}
+		return k8s.BuildDeployment(req.Namespace, req.Name, req.Image, *req.Replicas, cport, req.Env), nil
+	case "secret":
+		if req.SecretType == "" {
+			req.SecretType = "Opaque"
+		}
+		return k8s.BuildSecret(req.Namespace, req.Name, req.SecretType, req.Data)
+	case "ingress":
+		if req.Host == "" || req.ServiceName == "" || req.Port == nil {
+			return nil, fmt.Errorf("Campos 'host', 'serviceName' e 'servicePort' são obrigatórios para ingress")
+		}
+		return k8s.BuildIngress(req.Namespace, req.Name, req.Host, req.ServiceName, *req.Port), nil
+	case "namespace":
+		return k8s.BuildNamespace(req.Name), nil
+	case "configmap":
+		return k8s.BuildConfigMap(req.Namespace, req.Name, req.Data, nil)
+	case "service":
+		if req.ServiceType == "" || req.Port == nil || req.TargetPort == nil {
+			return nil, fmt.Errorf("Campos 'serviceType', 'port' e 'targetPort' são obrigatórios para service")
+		}
+		return k8s.BuildService(req.Namespace, req.Name, req.ServiceType, *req.Port, *req.TargetPort), nil
+	default:
+		return nil, fmt.Errorf("'kind' inválido. Use: container, deployment, secret, configmap, ingress, service, namespace")
+	}
+}
+
 // CreateApplicationRequest define o payload para criar uma aplicação (deployment + service)
 type CreateApplicationRequest struct {
 	Namespace     string            `json:"namespace"`
@@ -385,64 +361,21 @@ func createResourceHandler(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "JSON mal formatado", http.StatusBadRequest)
 		return
 	}
-	if req.Kind != "namespace" {
-		if req.Kind == "" || req.Namespace == "" || req.Name == "" {
-			http.Error(w, "Campos 'kind', 'namespace' e 'name' são obrigatórios", http.StatusBadRequest)
-			return
-		}
-	}
-	var err error
-	switch req.Kind {
-	case "container", "pod":
-		if req.Image == "" {
-			http.Error(w, "Campo 'image' é obrigatório para container/pod", http.StatusBadRequest)
-			return
-		}
-		err = k8s.CreatePod(req.Namespace, req.Image, req.Name)
-	case "deployment":
-		if req.Image == "" || req.Replicas == nil {
-			http.Error(w, "Campos 'image' e 'replicas' são obrigatórios para deployment", http.StatusBadRequest)
-			return
-		}
-		var cport int32 = 0
-		if req.ContainerPort != nil {
-			cport = *req.ContainerPort
-		}
-		err = k8s.CreateDeployment(req.Namespace, req.Name, req.Image, *req.Replicas, cport, req.Env)
-	case "secret":
-		if req.SecretType == "" {
-			req.SecretType = "Opaque"
-		}
-		err = k8s.CreateSecret(req.Namespace, req.Name, req.SecretType, req.Data)
-	case "ingress":
-		if req.Host == "" || req.ServiceName == "" || req.Port == nil {
-			http.Error(w, "Campos 'host', 'serviceName' e 'servicePort' são obrigatórios para ingress", http.StatusBadRequest)
-			return
-		}
-		err = k8s.CreateIngress(req.Namespace, req.Name, req.Host, req.ServiceName, *req.Port)
-	case "namespace":
-		err = k8s.CreateNs(req.Name)
-	case "service":
-		if req.ServiceType == "" || req.Port == nil || req.TargetPort == nil {
-			http.Error(w, "Campos 'serviceType', 'port' e 'targetPort' são obrigatórios para service", http.StatusBadRequest)
-			return
-		}
-		err = k8s.CreateService(req.Namespace, req.Name, req.ServiceType, *req.Port, *req.TargetPort)
-	default:
-		http.Error(w, "'kind' inválido. Use: container, deployment, secret, ingress, service, namespace", http.StatusBadRequest)
+	obj, err := req.object()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
 
+	opts := mutateOptions(r)
+	object, err := k8s.CreateObject(obj, opts)
 	if err != nil {
 		log.Printf("ERRO ao criar recurso: %v", err)
-		http.Error(w, fmt.Sprintf("Erro ao criar recurso: %v", err), http.StatusInternalServerError)
+		http.Error(w, fmt.Sprintf("Erro ao criar recurso: %v", err), k8sErrorStatus(err))
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusCreated)
-	response := map[string]string{"status": "sucesso", "message": fmt.Sprintf("Recurso '%s' (%s) está sendo criado.", req.Name, req.Kind)}
-	json.NewEncoder(w).Encode(response)
+	writeMutation(w, http.StatusCreated, fmt.Sprintf("Recurso '%s' (%s) está sendo criado.", req.Name, req.Kind), opts, object)
 }
 
 func createApplicationHandler(w http.ResponseWriter, r *http.Request) {
@@ -492,7 +425,8 @@ func createApplicationHandler(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Cria a aplicação (deployment + service)
-	err := k8s.CreateApplication(
+	opts := mutateOptions(r)
+	app, err := k8s.CreateApplication(
 		req.Namespace,
 		req.Name,
 		req.Image,
@@ -502,21 +436,16 @@ func createApplicationHandler(w http.ResponseWriter, r *http.Request) {
 		req.ServicePort,
 		req.TargetPort,
 		req.Env,
+		opts,
 	)
 
 	if err != nil {
 		log.Printf("ERRO ao criar aplicação: %v", err)
-		http.Error(w, fmt.Sprintf("Erro ao criar aplicação: %v", err), http.StatusInternalServerError)
+		http.Error(w, fmt.Sprintf("Erro ao criar aplicação: %v", err), k8sErrorStatus(err))
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusCreated)
-	response := map[string]string{
-		"status":  "sucesso",
-		"message": fmt.Sprintf("Aplicação '%s' (Deployment + Service) está sendo criada.", req.Name),
-	}
-	json.NewEncoder(w).Encode(response)
+	writeMutation(w, http.StatusCreated, fmt.Sprintf("Aplicação '%s' (Deployment + Service) está sendo criada.", req.Name), opts, app)
 }
 
 type ResourceUpdateRequest struct {
@@ -561,26 +490,14 @@ func updateDeploymentHandler(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "Campo 'image' é obrigatório para deployment", http.StatusBadRequest)
 		return
 	}
-	if req.Image != nil {
-		err := k8s.UpdateDeploymentImage(req.Namespace, req.Name, *req.Image)
-		if err != nil {
-			log.Printf("ERRO ao atualizar imagem do deployment: %v", err)
-			http.Error(w, fmt.Sprintf("Erro ao atualizar imagem do deployment: %v", err), http.StatusInternalServerError)
-			return
-		}
-	}
-	if req.Replicas != nil {
-		err := k8s.ScaleDeployment(req.Namespace, req.Name, *req.Replicas)
-		if err != nil {
-			log.Printf("ERRO ao escalar deployment: %v", err)
-			http.Error(w, fmt.Sprintf("Erro ao escalar deployment: %v", err), http.StatusInternalServerError)
-			return
-		}
+	opts := mutateOptions(r)
+	deployment, err := k8s.UpdateDeployment(req.Namespace, req.Name, k8s.DeploymentUpdate{Image: req.Image, Replicas: req.Replicas}, opts)
+	if err != nil {
+		log.Printf("ERRO ao atualizar deployment: %v", err)
+		http.Error(w, fmt.Sprintf("Erro ao atualizar deployment: %v", err), k8sErrorStatus(err))
+		return
 	}
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusCreated)
-	response := map[string]string{"status": "sucesso", "message": fmt.Sprintf("Deployment '%s' foi atualizado.", req.Name)}
-	json.NewEncoder(w).Encode(response)
+	writeMutation(w, http.StatusCreated, fmt.Sprintf("Deployment '%s' foi atualizado.", req.Name), opts, deployment)
 	log.Printf("Deployment foi atualizado com sucesso: %s", req.Name)
 }
 
@@ -591,11 +508,65 @@ func Listen() {
 	http.HandleFunc("GET /listAllServices/{namespace}", corsMiddleware(listServicesHandler))
 	http.HandleFunc("POST /createResource", corsMiddleware(createResourceHandler))
 	http.HandleFunc("POST /createApplication", corsMiddleware(createApplicationHandler))
+	http.HandleFunc("POST /apply", corsMiddleware(applyHandler))
+	http.HandleFunc("POST /diff", corsMiddleware(diffManifestHandler))
+	http.HandleFunc("POST /diff/createResource", corsMiddleware(diffCreateResourceHandler))
+	http.HandleFunc("POST /diff/updateDeployment", corsMiddleware(diffUpdateDeploymentHandler))
 	http.HandleFunc("GET /listAllNs", corsMiddleware(listNsHandler))
+	http.HandleFunc("GET /listAllConfigMaps/{namespace}", corsMiddleware(listConfigMapsHandler))
+	http.HandleFunc("POST /createConfigMap", corsMiddleware(createConfigMapHandler))
+	http.HandleFunc("POST /updateConfigMap", corsMiddleware(updateConfigMapHandler))
+	http.HandleFunc("GET /listAllSecrets/{namespace}", corsMiddleware(listSecretsHandler))
+	http.HandleFunc("POST /revealSecret", restrictedCorsMiddleware(revealSecretHandler))
+	http.HandleFunc("POST /updateSecret", corsMiddleware(updateSecretHandler))
+	http.HandleFunc("POST /createSecret", corsMiddleware(createSecretHandler))
+	http.HandleFunc("GET /listAllStatefulSets/{namespace}", corsMiddleware(listStatefulSetsHandler))
+	http.HandleFunc("POST /createStatefulSet", corsMiddleware(createStatefulSetHandler))
+	http.HandleFunc("POST /updateStatefulSet", corsMiddleware(updateStatefulSetHandler))
+	http.HandleFunc("POST /restartStatefulSet", corsMiddleware(restartStatefulSetHandler))
+	http.HandleFunc("GET /listAllDaemonSets/{namespace}", corsMiddleware(listDaemonSetsHandler))
+	http.HandleFunc("POST /createDaemonSet", corsMiddleware(createDaemonSetHandler))
+	http.HandleFunc("POST /updateDaemonSet", corsMiddleware(updateDaemonSetHandler))
+	http.HandleFunc("POST /restartDaemonSet", corsMiddleware(restartDaemonSetHandler))
+	http.HandleFunc("GET /listAllJobs/{namespace}", corsMiddleware(listJobsHandler))
+	http.HandleFunc("POST /createJob", corsMiddleware(createJobHandler))
+	http.HandleFunc("GET /listAllCronJobs/{namespace}", corsMiddleware(listCronJobsHandler))
+	http.HandleFunc("POST /createCronJob", corsMiddleware(createCronJobHandler))
+	http.HandleFunc("POST /suspendCronJob", corsMiddleware(suspendCronJobHandler))
+	http.HandleFunc("POST /triggerCronJob", corsMiddleware(triggerCronJobHandler))
+	http.HandleFunc("GET /listAllPVCs/{namespace}", corsMiddleware(listPVCsHandler))
+	http.HandleFunc("GET /listAllStorageClasses", corsMiddleware(listStorageClassesHandler))
+	http.HandleFunc("GET /listAllPVs", corsMiddleware(listPersistentVolumesHandler))
+	http.HandleFunc("POST /createPVC", corsMiddleware(createPVCHandler))
+	http.HandleFunc("POST /expandPVC", corsMiddleware(expandPVCHandler))
+	http.HandleFunc("GET /listAllHPAs/{namespace}", corsMiddleware(listHPAsHandler))
+	http.HandleFunc("POST /createHPA", corsMiddleware(createHPAHandler))
+	http.HandleFunc("POST /updateHPA", corsMiddleware(updateHPAHandler))
+	http.HandleFunc("GET /resource/{namespace}/{kind}/{name}", corsMiddleware(getResourceHandler))
+	http.HandleFunc("GET /export/{namespace}", corsMiddleware(exportNamespaceHandler))
+	http.HandleFunc("GET /listAllEvents/{namespace}", corsMiddleware(listEventsHandler))
+	http.HandleFunc("GET /events/{namespace}/{kind}/{name}", corsMiddleware(objectEventsHandler))
+	http.HandleFunc("GET /watchEvents/{namespace}", corsMiddleware(watchEventsHandler))
+	http.HandleFunc("GET /logs/{namespace}/{pod}", corsMiddleware(podLogsHandler))
+	http.HandleFunc("GET /deploymentLogs/{namespace}/{name}", corsMiddleware(deploymentLogsHandler))
+	http.HandleFunc("GET /deploymentLogs/{namespace}/{name}/archive", corsMiddleware(deploymentLogsArchiveHandler))
+	http.HandleFunc("GET /jobLogs/{namespace}/{name}", corsMiddleware(jobLogsHandler))
+	http.HandleFunc("GET /exec/{namespace}/{pod}", corsMiddleware(requirePermission(permissionExec, execHandler)))
+	// Sem método no padrão: o proxy encaminha GET, POST, PUT, DELETE...
+	// Sem corsMiddleware: outras origens não podem ler as respostas das aplicações
+	http.HandleFunc("/proxy/{namespace}/{kind}/{target}", requirePermission(permissionProxy, proxyHandler))
+	http.HandleFunc("/proxy/{namespace}/{kind}/{target}/{path...}", requirePermission(permissionProxy, proxyHandler))
 	http.HandleFunc("POST /deletePod", corsMiddleware(deletePodHandler))
 	http.HandleFunc("POST /deleteDeployment", corsMiddleware(deleteDeploymentHandler))
 	http.HandleFunc("POST /deleteService", corsMiddleware(deleteServiceHandler))
 	http.HandleFunc("POST /deleteSecret", corsMiddleware(deleteSecretHandler))
+	http.HandleFunc("POST /deleteConfigMap", corsMiddleware(deleteConfigMapHandler))
+	http.HandleFunc("POST /deleteStatefulSet", corsMiddleware(deleteStatefulSetHandler))
+	http.HandleFunc("POST /deleteDaemonSet", corsMiddleware(deleteDaemonSetHandler))
+	http.HandleFunc("POST /deleteJob", corsMiddleware(deleteJobHandler))
+	http.HandleFunc("POST /deleteCronJob", corsMiddleware(deleteCronJobHandler))
+	http.HandleFunc("POST /deletePVC", corsMiddleware(deletePVCHandler))
+	http.HandleFunc("POST /deleteHPA", corsMiddleware(deleteHPAHandler))
 	http.HandleFunc("POST /updateDeployment", corsMiddleware(updateDeploymentHandler))
 	// Adiciona handler para requisições OPTIONS (preflight) para ambas as rotas
 	http.HandleFunc("OPTIONS /listAllPods/{namespace}", corsMiddleware(func(w http.ResponseWriter, r *http.Request) {}))
@@ -603,11 +574,61 @@ func Listen() {
 	http.HandleFunc("OPTIONS /listAllServices/{namespace}", corsMiddleware(func(w http.ResponseWriter, r *http.Request) {}))
 	http.HandleFunc("OPTIONS /createResource", corsMiddleware(func(w http.ResponseWriter, r *http.Request) {}))
 	http.HandleFunc("OPTIONS /createApplication", corsMiddleware(func(w http.ResponseWriter, r *http.Request) {}))
+	http.HandleFunc("OPTIONS /apply", corsMiddleware(func(w http.ResponseWriter, r *http.Request) {}))
+	http.HandleFunc("OPTIONS /diff", corsMiddleware(func(w http.ResponseWriter, r *http.Request) {}))
+	http.HandleFunc("OPTIONS /diff/createResource", corsMiddleware(func(w http.ResponseWriter, r *http.Request) {}))
+	http.HandleFunc("OPTIONS /diff/updateDeployment", corsMiddleware(func(w http.ResponseWriter, r *http.Request) {}))
 	http.HandleFunc("OPTIONS /listAllNs", corsMiddleware(func(w http.ResponseWriter, r *http.Request) {}))
+	http.HandleFunc("OPTIONS /listAllConfigMaps/{namespace}", corsMiddleware(func(w http.ResponseWriter, r *http.Request) {}))
+	http.HandleFunc("OPTIONS /createConfigMap", corsMiddleware(func(w http.ResponseWriter, r *http.Request) {}))
+	http.HandleFunc("OPTIONS /updateConfigMap", corsMiddleware(func(w http.ResponseWriter, r *http.Request) {}))
+	http.HandleFunc("OPTIONS /listAllSecrets/{namespace}", corsMiddleware(func(w http.ResponseWriter, r *http.Request) {}))
+	http.HandleFunc("OPTIONS /revealSecret", restrictedCorsMiddleware(func(w http.ResponseWriter, r *http.Request) {}))
+	http.HandleFunc("OPTIONS /updateSecret", corsMiddleware(func(w http.ResponseWriter, r *http.Request) {}))
+	http.HandleFunc("OPTIONS /createSecret", corsMiddleware(func(w http.ResponseWriter, r *http.Request) {}))
+	http.HandleFunc("OPTIONS /listAllStatefulSets/{namespace}", corsMiddleware(func(w http.ResponseWriter, r *http.Request) {}))
+	http.HandleFunc("OPTIONS /createStatefulSet", corsMiddleware(func(w http.ResponseWriter, r *http.Request) {}))
+	http.HandleFunc("OPTIONS /updateStatefulSet", corsMiddleware(func(w http.ResponseWriter, r *http.Request) {}))
+	http.HandleFunc("OPTIONS /restartStatefulSet", corsMiddleware(func(w http.ResponseWriter, r *http.Request) {}))
+	http.HandleFunc("OPTIONS /listAllDaemonSets/{namespace}", corsMiddleware(func(w http.ResponseWriter, r *http.Request) {}))
+	http.HandleFunc("OPTIONS /createDaemonSet", corsMiddleware(func(w http.ResponseWriter, r *http.Request) {}))
+	http.HandleFunc("OPTIONS /updateDaemonSet", corsMiddleware(func(w http.ResponseWriter, r *http.Request) {}))
+	http.HandleFunc("OPTIONS /restartDaemonSet", corsMiddleware(func(w http.ResponseWriter, r *http.Request) {}))
+	http.HandleFunc("OPTIONS /listAllJobs/{namespace}", corsMiddleware(func(w http.ResponseWriter, r *http.Request) {}))
+	http.HandleFunc("OPTIONS /createJob", corsMiddleware(func(w http.ResponseWriter, r *http.Request) {}))
+	http.HandleFunc("OPTIONS /listAllCronJobs/{namespace}", corsMiddleware(func(w http.ResponseWriter, r *http.Request) {}))
+	http.HandleFunc("OPTIONS /createCronJob", corsMiddleware(func(w http.ResponseWriter, r *http.Request) {}))
+	http.HandleFunc("OPTIONS /suspendCronJob", corsMiddleware(func(w http.ResponseWriter, r *http.Request) {}))
+	http.HandleFunc("OPTIONS /triggerCronJob", corsMiddleware(func(w http.ResponseWriter, r *http.Request) {}))
+	http.HandleFunc("OPTIONS /listAllPVCs/{namespace}", corsMiddleware(func(w http.ResponseWriter, r *http.Request) {}))
+	http.HandleFunc("OPTIONS /listAllStorageClasses", corsMiddleware(func(w http.ResponseWriter, r *http.Request) {}))
+	http.HandleFunc("OPTIONS /listAllPVs", corsMiddleware(func(w http.ResponseWriter, r *http.Request) {}))
+	http.HandleFunc("OPTIONS /createPVC", corsMiddleware(func(w http.ResponseWriter, r *http.Request) {}))
+	http.HandleFunc("OPTIONS /expandPVC", corsMiddleware(func(w http.ResponseWriter, r *http.Request) {}))
+	http.HandleFunc("OPTIONS /listAllHPAs/{namespace}", corsMiddleware(func(w http.ResponseWriter, r *http.Request) {}))
+	http.HandleFunc("OPTIONS /createHPA", corsMiddleware(func(w http.ResponseWriter, r *http.Request) {}))
+	http.HandleFunc("OPTIONS /updateHPA", corsMiddleware(func(w http.ResponseWriter, r *http.Request) {}))
+	http.HandleFunc("OPTIONS /resource/{namespace}/{kind}/{name}", corsMiddleware(func(w http.ResponseWriter, r *http.Request) {}))
+	http.HandleFunc("OPTIONS /export/{namespace}", corsMiddleware(func(w http.ResponseWriter, r *http.Request) {}))
+	http.HandleFunc("OPTIONS /listAllEvents/{namespace}", corsMiddleware(func(w http.ResponseWriter, r *http.Request) {}))
+	http.HandleFunc("OPTIONS /events/{namespace}/{kind}/{name}", corsMiddleware(func(w http.ResponseWriter, r *http.Request) {}))
+	http.HandleFunc("OPTIONS /watchEvents/{namespace}", corsMiddleware(func(w http.ResponseWriter, r *http.Request) {}))
+	http.HandleFunc("OPTIONS /logs/{namespace}/{pod}", corsMiddleware(func(w http.ResponseWriter, r *http.Request) {}))
+	http.HandleFunc("OPTIONS /deploymentLogs/{namespace}/{name}", corsMiddleware(func(w http.ResponseWriter, r *http.Request) {}))
+	http.HandleFunc("OPTIONS /deploymentLogs/{namespace}/{name}/archive", corsMiddleware(func(w http.ResponseWriter, r *http.Request) {}))
+	http.HandleFunc("OPTIONS /jobLogs/{namespace}/{name}", corsMiddleware(func(w http.ResponseWriter, r *http.Request) {}))
+	http.HandleFunc("OPTIONS /exec/{namespace}/{pod}", corsMiddleware(func(w http.ResponseWriter, r *http.Request) {}))
 	http.HandleFunc("OPTIONS /deletePod", corsMiddleware(func(w http.ResponseWriter, r *http.Request) {}))
 	http.HandleFunc("OPTIONS /deleteDeployment", corsMiddleware(func(w http.ResponseWriter, r *http.Request) {}))
 	http.HandleFunc("OPTIONS /deleteService", corsMiddleware(func(w http.ResponseWriter, r *http.Request) {}))
 	http.HandleFunc("OPTIONS /deleteSecret", corsMiddleware(func(w http.ResponseWriter, r *http.Request) {}))
+	http.HandleFunc("OPTIONS /deleteConfigMap", corsMiddleware(func(w http.ResponseWriter, r *http.Request) {}))
+	http.HandleFunc("OPTIONS /deleteStatefulSet", corsMiddleware(func(w http.ResponseWriter, r *http.Request) {}))
+	http.HandleFunc("OPTIONS /deleteDaemonSet", corsMiddleware(func(w http.ResponseWriter, r *http.Request) {}))
+	http.HandleFunc("OPTIONS /deleteJob", corsMiddleware(func(w http.ResponseWriter, r *http.Request) {}))
+	http.HandleFunc("OPTIONS /deleteCronJob", corsMiddleware(func(w http.ResponseWriter, r *http.Request) {}))
+	http.HandleFunc("OPTIONS /deletePVC", corsMiddleware(func(w http.ResponseWriter, r *http.Request) {}))
+	http.HandleFunc("OPTIONS /deleteHPA", corsMiddleware(func(w http.ResponseWriter, r *http.Request) {}))
 	http.HandleFunc("OPTIONS /updateDeployment", corsMiddleware(func(w http.ResponseWriter, r *http.Request) {}))
 	log.Println("Servidor iniciado na porta 7000 com CORS habilitado")
 	log.Fatal(http.ListenAndServe(":7000", nil))
@@ -0,0 +1,87 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+
+	"backend/k8s"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// k8sErrorStatus escolhe o status HTTP adequado para um erro vindo do pacote k8s.
+func k8sErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, k8s.ErrInvalidContinue), errors.Is(err, k8s.ErrInvalidField),
+		errors.Is(err, k8s.ErrInvalidProxyTarget), errors.Is(err, k8s.ErrInvalidManifest),
+		errors.Is(err, k8s.ErrUnknownKind), errors.Is(err, k8s.ErrInvalidExport),
+		errors.Is(err, k8s.ErrInvalidData), errors.Is(err, k8s.ErrInvalidUpdate),
+		errors.Is(err, k8s.ErrInvalidSchedule):
+		return http.StatusBadRequest
+	case apierrors.IsNotFound(err), errors.Is(err, k8s.ErrNoPods):
+		return http.StatusNotFound
+	case apierrors.IsResourceExpired(err), apierrors.IsGone(err):
+		// O token 'continue' do Kubernetes expirou; o cliente deve recomeçar a listagem
+		return http.StatusGone
+	case apierrors.IsForbidden(err):
+		return http.StatusForbidden
+	case apierrors.IsAlreadyExists(err), apierrors.IsConflict(err):
+		return http.StatusConflict
+	case apierrors.IsInvalid(err):
+		// Inclui rejeições de admission webhooks de validação
+		return http.StatusUnprocessableEntity
+	case apierrors.IsBadRequest(err):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// writeK8sError responde com o status adequado ao erro. Erros internos recebem
+// uma mensagem genérica para não expor detalhes do cluster.
+func writeK8sError(w http.ResponseWriter, err error) {
+	status := k8sErrorStatus(err)
+	if status == http.StatusInternalServerError {
+		http.Error(w, "Erro ao buscar dados do Kubernetes", status)
+		return
+	}
+	http.Error(w, err.Error(), status)
+}
+
+// writeJSON serializa v e escreve a resposta com o status informado.
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	jsonResponse, err := json.Marshal(v)
+	if err != nil {
+		log.Printf("❌ ERRO: Falha ao serializar JSON: %v", err)
+		http.Error(w, "Erro ao formatar resposta", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(jsonResponse)
+}
+
+// MutationResponse é a resposta das operações que alteram o cluster. Em dry-run,
+// Object traz o objeto como o API server o persistiria (ou o objeto que seria removido).
+type MutationResponse struct {
+	Status  string `json:"status"`
+	Message string `json:"message"`
+	DryRun  bool   `json:"dryRun,omitempty"`
+	Object  any    `json:"object,omitempty"`
+}
+
+// writeMutation responde a uma operação concluída. Em dry-run o status é 200,
+// já que nada foi criado ou removido.
+func writeMutation(w http.ResponseWriter, status int, message string, opts k8s.MutateOptions, object any) {
+	response := MutationResponse{Status: "sucesso", Message: message}
+	if opts.DryRun {
+		status = http.StatusOK
+		response.DryRun = true
+		response.Message += " (dry-run: nada foi alterado)"
+		response.Object = object
+	}
+	writeJSON(w, status, response)
+}
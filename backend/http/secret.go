@@ -0,0 +1,224 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"time"
+
+	"backend/k8s"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+// Modos de /createSecret, equivalentes aos subcomandos de kubectl create secret.
+const (
+	secretModeGeneric        = "generic"
+	secretModeDockerRegistry = "docker-registry"
+	secretModeTLS            = "tls"
+	secretModeBasicAuth      = "basic-auth"
+	secretModeSSHAuth        = "ssh-auth"
+)
+
+// CreateSecretRequest cria um Secret tipado. Registry é usado no modo docker-registry,
+// TLS no modo tls e Data nos demais (basic-auth: username/password; ssh-auth: ssh-privatekey).
+type CreateSecretRequest struct {
+	Namespace  string                  `json:"namespace"`
+	Name       string                  `json:"name"`
+	Mode       string                  `json:"mode"`
+	SecretType string                  `json:"secretType,omitempty"`
+	Data       map[string]string       `json:"data,omitempty"`
+	Registry   *k8s.DockerRegistryAuth `json:"registry,omitempty"`
+	TLS        *TLSSecretRequest       `json:"tls,omitempty"`
+}
+
+// TLSSecretRequest traz o certificado (com a cadeia) e a chave em PEM. Hosts, quando
+// informados, precisam estar cobertos pelos SANs do certificado.
+type TLSSecretRequest struct {
+	Cert  string   `json:"cert"`
+	Key   string   `json:"key"`
+	Hosts []string `json:"hosts,omitempty"`
+}
+
+// RevealSecretRequest pede os valores de um Secret. Sem Keys, todas as chaves são reveladas.
+type RevealSecretRequest struct {
+	Namespace string   `json:"namespace"`
+	Name      string   `json:"name"`
+	Keys      []string `json:"keys,omitempty"`
+}
+
+// UpdateSecretRequest altera chaves de um Secret existente. SetBinary vem em base64.
+type UpdateSecretRequest struct {
+	Namespace string            `json:"namespace"`
+	Name      string            `json:"name"`
+	Set       map[string]string `json:"set,omitempty"`
+	SetBinary map[string][]byte `json:"setBinary,omitempty"`
+	Remove    []string          `json:"remove,omitempty"`
+}
+
+func listSecretsHandler(w http.ResponseWriter, r *http.Request) {
+	log.Printf("📋 listSecretsHandler chamado - método: %s", r.Method)
+	serveNamespacedList(w, r, "secrets", k8s.ListSecrets)
+}
+
+// createSecretHandler cria um Secret montado e validado conforme o modo pedido.
+// Rota: POST /createSecret
+func createSecretHandler(w http.ResponseWriter, r *http.Request) {
+	var req CreateSecretRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	if req.Namespace == "" || req.Name == "" {
+		http.Error(w, "Campos 'namespace' e 'name' são obrigatórios", http.StatusBadRequest)
+		return
+	}
+
+	secret, certificate, err := req.secret()
+	if err != nil {
+		http.Error(w, err.Error(), k8sErrorStatus(err))
+		return
+	}
+
+	opts := mutateOptions(r)
+	keys := make([]string, 0, len(secret.Data))
+	for key := range secret.Data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	created, err := k8s.CreateObject(secret, opts)
+	audit(r, AuditEntry{Action: "create", Namespace: req.Namespace, Name: req.Name, Keys: keys, DryRun: opts.DryRun}, err)
+	if err != nil {
+		log.Printf("ERRO ao criar secret: %v", err)
+		http.Error(w, fmt.Sprintf("Erro ao criar secret: %v", err), k8sErrorStatus(err))
+		return
+	}
+
+	message := fmt.Sprintf("Secret '%s' (%s) foi criado.", req.Name, secret.Type)
+	if certificate != nil {
+		message = fmt.Sprintf("Secret '%s' (%s) foi criado; certificado válido até %s.", req.Name, secret.Type, certificate.NotAfter.UTC().Format(time.RFC3339))
+	}
+	writeMutation(w, http.StatusCreated, message, opts, created)
+}
+
+// secret monta o Secret do modo pedido. Para TLS, também devolve o resumo do certificado.
+func (req CreateSecretRequest) secret() (*v1.Secret, *k8s.CertificateInfo, error) {
+	switch req.Mode {
+	case secretModeGeneric, "":
+		if req.SecretType == "" {
+			req.SecretType = string(v1.SecretTypeOpaque)
+		}
+		secret, err := k8s.BuildSecret(req.Namespace, req.Name, req.SecretType, req.Data)
+		return secret, nil, err
+	case secretModeDockerRegistry:
+		if req.Registry == nil {
+			return nil, nil, fmt.Errorf("%w: campo 'registry' é obrigatório no modo docker-registry", k8s.ErrInvalidData)
+		}
+		secret, err := k8s.BuildDockerRegistrySecret(req.Namespace, req.Name, *req.Registry)
+		return secret, nil, err
+	case secretModeTLS:
+		if req.TLS == nil {
+			return nil, nil, fmt.Errorf("%w: campo 'tls' é obrigatório no modo tls", k8s.ErrInvalidData)
+		}
+		return k8s.BuildTLSSecret(req.Namespace, req.Name, []byte(req.TLS.Cert), []byte(req.TLS.Key), req.TLS.Hosts)
+	case secretModeBasicAuth:
+		secret, err := k8s.BuildSecret(req.Namespace, req.Name, string(v1.SecretTypeBasicAuth), req.Data)
+		return secret, nil, err
+	case secretModeSSHAuth:
+		secret, err := k8s.BuildSecret(req.Namespace, req.Name, string(v1.SecretTypeSSHAuth), req.Data)
+		return secret, nil, err
+	default:
+		return nil, nil, fmt.Errorf("%w: 'mode' inválido. Use: generic, docker-registry, tls, basic-auth, ssh-auth", k8s.ErrInvalidData)
+	}
+}
+
+// revealSecretHandler devolve os valores de um Secret. Exige a permissão 'secrets' e
+// uma origem aceita por originAllowed, e registra cada tentativa no log de auditoria.
+// Rota: POST /revealSecret
+func revealSecretHandler(w http.ResponseWriter, r *http.Request) {
+	var req RevealSecretRequest
+	// Uma página de outro site não pode revelar valores pelo navegador do operador
+	status, err := http.StatusForbidden, fmt.Errorf("Origem '%s' não autorizada", r.Header.Get("Origin"))
+	if originAllowed(r) {
+		status, err = permissionError(r, permissionSecrets)
+	}
+	if err != nil {
+		// Tentativas recusadas também entram na auditoria, com o que puder ser lido do corpo
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		audit(r, AuditEntry{Action: "reveal", Namespace: req.Namespace, Name: req.Name, Keys: req.Keys, Result: "denied"}, err)
+		http.Error(w, err.Error(), status)
+		return
+	}
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	if req.Namespace == "" || req.Name == "" {
+		http.Error(w, "Campos 'namespace' e 'name' são obrigatórios", http.StatusBadRequest)
+		return
+	}
+
+	values, err := k8s.RevealSecret(req.Namespace, req.Name, req.Keys)
+	entry := AuditEntry{Action: "reveal", Namespace: req.Namespace, Name: req.Name, Keys: req.Keys}
+	if err == nil {
+		entry.Keys = revealedKeys(values)
+	}
+	audit(r, entry, err)
+	if err != nil {
+		log.Printf("❌ ERRO: Falha ao revelar secret %s/%s: %v", req.Namespace, req.Name, err)
+		writeK8sError(w, err)
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	writeJSON(w, http.StatusOK, values)
+}
+
+func revealedKeys(values k8s.SecretValues) []string {
+	keys := make([]string, 0, len(values.Data)+len(values.BinaryData))
+	for key := range values.Data {
+		keys = append(keys, key)
+	}
+	for key := range values.BinaryData {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// updateSecretHandler cria, substitui ou remove chaves de um Secret. A resposta não
+// inclui os valores; a alteração é registrada no log de auditoria.
+// Rota: POST /updateSecret
+func updateSecretHandler(w http.ResponseWriter, r *http.Request) {
+	var req UpdateSecretRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	if req.Namespace == "" || req.Name == "" {
+		http.Error(w, "Campos 'namespace' e 'name' são obrigatórios", http.StatusBadRequest)
+		return
+	}
+
+	opts := mutateOptions(r)
+	update := k8s.SecretUpdate{Set: req.Set, SetBinary: req.SetBinary, Remove: req.Remove}
+	secret, err := k8s.UpdateSecretKeys(req.Namespace, req.Name, update, opts)
+	audit(r, AuditEntry{Action: "update", Namespace: req.Namespace, Name: req.Name, Keys: updatedKeys(req), DryRun: opts.DryRun}, err)
+	if err != nil {
+		log.Printf("ERRO ao atualizar secret: %v", err)
+		http.Error(w, fmt.Sprintf("Erro ao atualizar secret: %v", err), k8sErrorStatus(err))
+		return
+	}
+	writeMutation(w, http.StatusOK, fmt.Sprintf("Secret '%s' foi atualizado.", req.Name), opts, secret)
+}
+
+func updatedKeys(req UpdateSecretRequest) []string {
+	keys := append([]string{}, req.Remove...)
+	for key := range req.Set {
+		keys = append(keys, key)
+	}
+	for key := range req.SetBinary {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
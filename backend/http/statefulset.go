@@ -0,0 +1,151 @@
+package http
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	"backend/k8s"
+)
+
+// CreateStatefulSetRequest cria um StatefulSet com um container e, opcionalmente,
+// um PVC por pod para cada item de volumeClaimTemplates.
+type CreateStatefulSetRequest struct {
+	Namespace            string                    `json:"namespace"`
+	Name                 string                    `json:"name"`
+	Image                string                    `json:"image"`
+	Replicas             *int32                    `json:"replicas"`
+	ContainerPort        int32                     `json:"containerPort,omitempty"`
+	Env                  map[string]string         `json:"env,omitempty"`
+	ServiceName          string                    `json:"serviceName,omitempty"`
+	PodManagementPolicy  string                    `json:"podManagementPolicy,omitempty"`
+	VolumeClaimTemplates []k8s.VolumeClaimTemplate `json:"volumeClaimTemplates,omitempty"`
+}
+
+// UpdateStatefulSetRequest altera imagem, réplicas e partition; campos ausentes não mudam.
+type UpdateStatefulSetRequest struct {
+	Namespace string  `json:"namespace"`
+	Name      string  `json:"name"`
+	Image     *string `json:"image,omitempty"`
+	Container string  `json:"container,omitempty"`
+	Replicas  *int32  `json:"replicas,omitempty"`
+	Partition *int32  `json:"partition,omitempty"`
+}
+
+func listStatefulSetsHandler(w http.ResponseWriter, r *http.Request) {
+	log.Printf("📋 listStatefulSetsHandler chamado - método: %s", r.Method)
+	serveNamespacedList(w, r, "statefulsets", k8s.ListStatefulSets)
+}
+
+// createStatefulSetHandler cria o StatefulSet e o service headless, se ainda não existir.
+// Rota: POST /createStatefulSet
+func createStatefulSetHandler(w http.ResponseWriter, r *http.Request) {
+	var req CreateStatefulSetRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	if req.Namespace == "" || req.Name == "" || req.Image == "" || req.Replicas == nil {
+		http.Error(w, "Campos 'namespace', 'name', 'image' e 'replicas' são obrigatórios", http.StatusBadRequest)
+		return
+	}
+	if *req.Replicas < 0 {
+		http.Error(w, "Número de réplicas não pode ser negativo", http.StatusBadRequest)
+		return
+	}
+
+	opts := mutateOptions(r)
+	spec := k8s.StatefulSetSpec{
+		Image:                req.Image,
+		Replicas:             *req.Replicas,
+		ContainerPort:        req.ContainerPort,
+		Env:                  req.Env,
+		ServiceName:          req.ServiceName,
+		PodManagementPolicy:  req.PodManagementPolicy,
+		VolumeClaimTemplates: req.VolumeClaimTemplates,
+	}
+	sts, err := k8s.CreateStatefulSet(req.Namespace, req.Name, spec, opts)
+	if err != nil {
+		log.Printf("ERRO ao criar statefulset: %v", err)
+		http.Error(w, fmt.Sprintf("Erro ao criar statefulset: %v", err), k8sErrorStatus(err))
+		return
+	}
+	writeMutation(w, http.StatusCreated, fmt.Sprintf("StatefulSet '%s' está sendo criado.", req.Name), opts, sts)
+}
+
+// updateStatefulSetHandler escala, troca a imagem e controla a partition do rollout.
+// Rota: POST /updateStatefulSet
+func updateStatefulSetHandler(w http.ResponseWriter, r *http.Request) {
+	var req UpdateStatefulSetRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	if req.Namespace == "" || req.Name == "" {
+		http.Error(w, "Campos 'namespace' e 'name' são obrigatórios", http.StatusBadRequest)
+		return
+	}
+	if req.Image != nil && *req.Image == "" {
+		http.Error(w, "Campo 'image' não pode ser vazio", http.StatusBadRequest)
+		return
+	}
+
+	opts := mutateOptions(r)
+	update := k8s.StatefulSetUpdate{Image: req.Image, Container: req.Container, Replicas: req.Replicas, Partition: req.Partition}
+	sts, err := k8s.UpdateStatefulSet(req.Namespace, req.Name, update, opts)
+	if err != nil {
+		log.Printf("ERRO ao atualizar statefulset: %v", err)
+		http.Error(w, fmt.Sprintf("Erro ao atualizar statefulset: %v", err), k8sErrorStatus(err))
+		return
+	}
+	writeMutation(w, http.StatusOK, fmt.Sprintf("StatefulSet '%s' foi atualizado.", req.Name), opts, sts)
+}
+
+// restartStatefulSetHandler recria os pods do StatefulSet, como kubectl rollout restart.
+// Rota: POST /restartStatefulSet
+func restartStatefulSetHandler(w http.ResponseWriter, r *http.Request) {
+	var req ResourceDeleteRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	if req.Namespace == "" || req.Name == "" {
+		http.Error(w, "Campos 'namespace' e 'name' são obrigatórios", http.StatusBadRequest)
+		return
+	}
+
+	opts := mutateOptions(r)
+	sts, err := k8s.RestartStatefulSet(req.Namespace, req.Name, opts)
+	if err != nil {
+		log.Printf("ERRO ao reiniciar statefulset: %v", err)
+		http.Error(w, fmt.Sprintf("Erro ao reiniciar statefulset: %v", err), k8sErrorStatus(err))
+		return
+	}
+	writeMutation(w, http.StatusOK, fmt.Sprintf("StatefulSet '%s' está sendo reiniciado.", req.Name), opts, sts)
+}
+
+func deleteStatefulSetHandler(w http.ResponseWriter, r *http.Request) {
+	log.Printf("deleteStatefulSetHandler chamado com método: %s", r.Method)
+
+	var req ResourceDeleteRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	if req.Namespace == "" || req.Name == "" {
+		http.Error(w, "Campos 'namespace' e 'name' são obrigatórios", http.StatusBadRequest)
+		return
+	}
+
+	opts := mutateOptions(r)
+	sts, retained, err := k8s.DeleteStatefulSet(req.Name, req.Namespace, opts)
+	if err != nil {
+		log.Printf("ERRO ao Deletar statefulset: %v", err)
+		http.Error(w, fmt.Sprintf("Erro ao Deletar statefulset: %v", err), k8sErrorStatus(err))
+		return
+	}
+
+	message := fmt.Sprintf("StatefulSet '%s' foi deletado.", req.Name)
+	if len(retained) > 0 {
+		message += fmt.Sprintf(" PVCs mantidos: %s.", strings.Join(retained, ", "))
+	}
+	writeMutation(w, http.StatusCreated, message, opts, sts)
+	log.Printf("StatefulSet Deletado com sucesso: %s", req.Name)
+}
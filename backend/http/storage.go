@@ -0,0 +1,118 @@
+package http
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	"backend/k8s"
+)
+
+// CreatePVCRequest cria um PersistentVolumeClaim.
+type CreatePVCRequest struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	k8s.PVCSpec
+}
+
+// ExpandPVCRequest aumenta o tamanho pedido pelo PVC (ex.: "storage": "20Gi").
+type ExpandPVCRequest struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	Storage   string `json:"storage"`
+}
+
+func listPVCsHandler(w http.ResponseWriter, r *http.Request) {
+	log.Printf("📋 listPVCsHandler chamado - método: %s", r.Method)
+	serveNamespacedList(w, r, "pvcs", k8s.ListPVCs)
+}
+
+func listStorageClassesHandler(w http.ResponseWriter, r *http.Request) {
+	log.Printf("📋 listStorageClassesHandler chamado - método: %s", r.Method)
+	serveList(w, r, "storageclasses", k8s.ListStorageClasses)
+}
+
+func listPersistentVolumesHandler(w http.ResponseWriter, r *http.Request) {
+	log.Printf("📋 listPersistentVolumesHandler chamado - método: %s", r.Method)
+	serveList(w, r, "persistentvolumes", k8s.ListPersistentVolumes)
+}
+
+// createPVCHandler cria um PVC.
+// Rota: POST /createPVC
+func createPVCHandler(w http.ResponseWriter, r *http.Request) {
+	var req CreatePVCRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	if req.Namespace == "" || req.Name == "" || req.Storage == "" {
+		http.Error(w, "Campos 'namespace', 'name' e 'storage' são obrigatórios", http.StatusBadRequest)
+		return
+	}
+
+	claim, err := k8s.BuildPVC(req.Namespace, req.Name, req.PVCSpec)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	opts := mutateOptions(r)
+	created, err := k8s.CreateObject(claim, opts)
+	if err != nil {
+		log.Printf("ERRO ao criar pvc: %v", err)
+		http.Error(w, fmt.Sprintf("Erro ao criar pvc: %v", err), k8sErrorStatus(err))
+		return
+	}
+	writeMutation(w, http.StatusCreated, fmt.Sprintf("PVC '%s' foi criado.", req.Name), opts, created)
+}
+
+// expandPVCHandler aumenta o tamanho de um PVC cuja storage class permite expansão.
+// Rota: POST /expandPVC
+func expandPVCHandler(w http.ResponseWriter, r *http.Request) {
+	var req ExpandPVCRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	if req.Namespace == "" || req.Name == "" || req.Storage == "" {
+		http.Error(w, "Campos 'namespace', 'name' e 'storage' são obrigatórios", http.StatusBadRequest)
+		return
+	}
+
+	opts := mutateOptions(r)
+	claim, err := k8s.ExpandPVC(req.Namespace, req.Name, req.Storage, opts)
+	if err != nil {
+		log.Printf("ERRO ao expandir pvc: %v", err)
+		http.Error(w, fmt.Sprintf("Erro ao expandir pvc: %v", err), k8sErrorStatus(err))
+		return
+	}
+	writeMutation(w, http.StatusOK, fmt.Sprintf("PVC '%s' está sendo expandido para %s.", req.Name, req.Storage), opts, claim)
+}
+
+func deletePVCHandler(w http.ResponseWriter, r *http.Request) {
+	log.Printf("deletePVCHandler chamado com método: %s", r.Method)
+
+	var req ResourceDeleteRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	if req.Namespace == "" || req.Name == "" {
+		http.Error(w, "Campos 'namespace' e 'name' são obrigatórios", http.StatusBadRequest)
+		return
+	}
+
+	opts := mutateOptions(r)
+	claim, mountedBy, err := k8s.DeletePVC(req.Name, req.Namespace, opts)
+	if err != nil {
+		log.Printf("ERRO ao Deletar pvc: %v", err)
+		http.Error(w, fmt.Sprintf("Erro ao Deletar pvc: %v", err), k8sErrorStatus(err))
+		return
+	}
+
+	message := fmt.Sprintf("PVC '%s' foi deletado.", req.Name)
+	if len(mountedBy) > 0 {
+		message += fmt.Sprintf(" Atenção: ainda montado pelos pods em execução %s; o PVC fica em Terminating até que eles parem.",
+			strings.Join(mountedBy, ", "))
+	}
+	writeMutation(w, http.StatusCreated, message, opts, claim)
+	log.Printf("PVC Deletado com sucesso: %s", req.Name)
+}
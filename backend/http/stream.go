@@ -0,0 +1,88 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+)
+
+// serveSSE mantém a conexão aberta como Server-Sent Events e repassa a run uma
+// função que envia cada valor como uma mensagem 'data:' em JSON. A conexão é
+// encerrada quando o cliente desconecta ou run termina.
+func serveSSE(w http.ResponseWriter, r *http.Request, run func(ctx context.Context, send func(any) error) error) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "Streaming não suportado", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	// Evita que proxies (ex.: nginx) acumulem a resposta em buffer
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	send := func(v any) error {
+		data, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintf(w, "data: %s\n\n", data); err != nil {
+			return err
+		}
+		flusher.Flush()
+		return nil
+	}
+
+	if err := run(r.Context(), send); err != nil && r.Context().Err() == nil {
+		log.Printf("❌ ERRO no stream %s: %v", r.URL.Path, err)
+		// Informa o erro ao cliente antes de encerrar o stream
+		fmt.Fprintf(w, "event: error\ndata: %q\n\n", err.Error())
+		flusher.Flush()
+	}
+}
+
+// serveTextStream envia texto puro com transfer-encoding chunked, descarregando cada
+// linha assim que é escrita. Os headers só são enviados na primeira linha, para que
+// erros anteriores ao stream (ex.: pod inexistente) ainda recebam o status adequado.
+func serveTextStream(w http.ResponseWriter, r *http.Request, run func(ctx context.Context, write func(string) error) error) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "Streaming não suportado", http.StatusInternalServerError)
+		return
+	}
+
+	started := false
+	write := func(line string) error {
+		if !started {
+			w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+			w.Header().Set("X-Content-Type-Options", "nosniff")
+			w.Header().Set("X-Accel-Buffering", "no")
+			w.WriteHeader(http.StatusOK)
+			started = true
+		}
+		if _, err := fmt.Fprintln(w, line); err != nil {
+			return err
+		}
+		flusher.Flush()
+		return nil
+	}
+
+	err := run(r.Context(), write)
+	switch {
+	case err == nil || r.Context().Err() != nil:
+		if !started {
+			w.WriteHeader(http.StatusOK)
+		}
+	case !started:
+		log.Printf("❌ ERRO no stream %s: %v", r.URL.Path, err)
+		writeK8sError(w, err)
+	default:
+		log.Printf("❌ ERRO no stream %s: %v", r.URL.Path, err)
+		fmt.Fprintf(w, "<erro: %v>\n", err)
+	}
+}
@@ -0,0 +1,190 @@
+package k8s
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/yaml"
+)
+
+// fieldManager identifica o k8s-manager nos managedFields dos objetos aplicados.
+const fieldManager = "k8s-manager"
+
+// ErrInvalidManifest indica um manifesto que não pôde ser lido.
+var ErrInvalidManifest = errors.New("manifesto inválido")
+
+// Resultados possíveis da aplicação de um objeto.
+const (
+	ApplyCreated    = "created"
+	ApplyConfigured = "configured"
+	ApplyUnchanged  = "unchanged"
+	ApplyError      = "error"
+)
+
+// ApplyOptions controla a aplicação de manifestos. Namespace é usado nos objetos
+// namespaced que não informam o próprio; Force assume campos de outros gerenciadores.
+type ApplyOptions struct {
+	Namespace string
+	Force     bool
+	MutateOptions
+}
+
+// ApplyResult é o resultado da aplicação de um objeto do manifesto.
+type ApplyResult struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Namespace  string `json:"namespace,omitempty"`
+	Name       string `json:"name"`
+	Result     string `json:"result"`
+	Error      string `json:"error,omitempty"`
+	// Object é o objeto como o API server o persistiria, preenchido em dry-run
+	Object map[string]any `json:"object,omitempty"`
+}
+
+// ApplyManifests aplica, na ordem, cada objeto de um manifesto YAML (com vários documentos
+// separados por ---) ou JSON usando server-side apply. Um manifesto ilegível não aplica nada;
+// falhas em objetos individuais aparecem no resultado e não interrompem os demais.
+func ApplyManifests(ctx context.Context, manifest io.Reader, opts ApplyOptions) ([]ApplyResult, error) {
+	objects, err := decodeManifests(manifest)
+	if err != nil {
+		return nil, err
+	}
+	if len(objects) == 0 {
+		return nil, fmt.Errorf("%w: nenhum objeto encontrado", ErrInvalidManifest)
+	}
+	if opts.Namespace == "" {
+		opts.Namespace = metav1.NamespaceDefault
+	}
+
+	results := make([]ApplyResult, 0, len(objects))
+	for _, obj := range objects {
+		results = append(results, applyObject(ctx, obj, opts))
+	}
+	return results, nil
+}
+
+// decodeManifests lê todos os documentos do manifesto, expandindo objetos do tipo List.
+func decodeManifests(manifest io.Reader) ([]*unstructured.Unstructured, error) {
+	decoder := yaml.NewYAMLOrJSONDecoder(manifest, 4096)
+	var objects []*unstructured.Unstructured
+	for i := 1; ; i++ {
+		var raw map[string]any
+		if err := decoder.Decode(&raw); err != nil {
+			if err == io.EOF {
+				return objects, nil
+			}
+			return nil, fmt.Errorf("%w: documento %d: %v", ErrInvalidManifest, i, err)
+		}
+		// Documentos vazios (ex.: um --- no fim do arquivo) são ignorados
+		if len(raw) == 0 {
+			continue
+		}
+
+		obj := &unstructured.Unstructured{Object: raw}
+		if obj.GetAPIVersion() == "" || obj.GetKind() == "" {
+			return nil, fmt.Errorf("%w: documento %d não informa apiVersion e kind", ErrInvalidManifest, i)
+		}
+		if !obj.IsList() {
+			objects = append(objects, obj)
+			continue
+		}
+		err := obj.EachListItem(func(item runtime.Object) error {
+			objects = append(objects, item.(*unstructured.Unstructured))
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("%w: documento %d: %v", ErrInvalidManifest, i, err)
+		}
+	}
+}
+
+func applyObject(ctx context.Context, obj *unstructured.Unstructured, opts ApplyOptions) ApplyResult {
+	result := ApplyResult{
+		APIVersion: obj.GetAPIVersion(),
+		Kind:       obj.GetKind(),
+		Namespace:  obj.GetNamespace(),
+		Name:       obj.GetName(),
+	}
+	fail := func(err error) ApplyResult {
+		result.Result = ApplyError
+		result.Error = err.Error()
+		return result
+	}
+
+	existing, applied, err := applyLive(ctx, obj, opts)
+	if err != nil {
+		return fail(err)
+	}
+	result.Namespace = applied.GetNamespace()
+
+	switch {
+	case existing == nil:
+		result.Result = ApplyCreated
+	case sameContent(existing, applied):
+		result.Result = ApplyUnchanged
+	default:
+		result.Result = ApplyConfigured
+	}
+	if opts.DryRun {
+		if isSecret(applied) {
+			redactSecret(applied)
+		}
+		result.Object = applied.Object
+	}
+	return result
+}
+
+// applyLive aplica o objeto e devolve o estado anterior (nil se ele não existia) e o
+// resultado do apply. Objetos namespaced sem namespace recebem opts.Namespace.
+func applyLive(ctx context.Context, obj *unstructured.Unstructured, opts ApplyOptions) (*unstructured.Unstructured, *unstructured.Unstructured, error) {
+	if obj.GetName() == "" {
+		return nil, nil, errors.New("metadata.name é obrigatório (generateName não é suportado no apply)")
+	}
+
+	resource, err := resourceFor(obj)
+	if err != nil {
+		return nil, nil, err
+	}
+	if obj.GetNamespace() == "" && resource.namespaced {
+		obj.SetNamespace(opts.Namespace)
+	}
+	// Objetos exportados do cluster trazem managedFields, que o apply rejeita
+	obj.SetManagedFields(nil)
+
+	resourceClient := resource.client(obj.GetNamespace())
+	existing, err := resourceClient.Get(ctx, obj.GetName(), metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		existing, err = nil, nil
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	applied, err := resourceClient.Apply(ctx, obj.GetName(), obj, metav1.ApplyOptions{
+		FieldManager: fieldManager,
+		Force:        opts.Force,
+		DryRun:       opts.dryRun(),
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return existing, applied, nil
+}
+
+// sameContent compara dois estados de um objeto ignorando resourceVersion e managedFields.
+// A comparação por resourceVersion não serve em dry-run, em que a versão nunca muda.
+func sameContent(a, b *unstructured.Unstructured) bool {
+	a, b = a.DeepCopy(), b.DeepCopy()
+	for _, obj := range []*unstructured.Unstructured{a, b} {
+		obj.SetResourceVersion("")
+		obj.SetManagedFields(nil)
+	}
+	return equality.Semantic.DeepEqual(a.Object, b.Object)
+}
@@ -0,0 +1,105 @@
+package k8s
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"path"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// WriteDeploymentLogsArchive escreve em w um .tar.gz com os logs atuais e anteriores de
+// todos os containers (inclusive init containers) dos pods selecionados pelo deployment.
+// Cada container gera "<pod>/<container>.log" e, se já reiniciou, "<pod>/<container>.previous.log".
+// opts.SinceSeconds/SinceTime definem a janela de tempo; Follow e Previous são ignorados.
+// Nada é escrito em w se o deployment ou os pods não puderem ser buscados.
+func WriteDeploymentLogsArchive(ctx context.Context, namespace, name string, opts LogOptions, w io.Writer) error {
+	deployment, err := client.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("deployment não encontrado: %w", err)
+	}
+	selector, err := metav1.LabelSelectorAsSelector(deployment.Spec.Selector)
+	if err != nil {
+		return fmt.Errorf("seletor inválido: %w", err)
+	}
+
+	// Mesma busca de pods feita por ListPods, restrita ao seletor do deployment
+	pods, _, err := listPods(namespace, metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return err
+	}
+	if len(pods) == 0 {
+		return fmt.Errorf("%w: deployment '%s' não possui pods", ErrNoPods, name)
+	}
+
+	opts.Follow = false
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+	now := time.Now()
+
+	for _, pod := range pods {
+		containers := append(append([]ContainerInfo{}, pod.InitContainers...), pod.Containers...)
+		for _, c := range containers {
+			if opts.Container != "" && c.Name != opts.Container {
+				continue
+			}
+
+			current := opts
+			current.Previous = false
+			file := path.Join(pod.Nome, c.Name+".log")
+			if err := addLogFile(ctx, tw, file, now, namespace, pod.Nome, c.Name, current); err != nil {
+				return err
+			}
+
+			// Só existe log anterior para containers que já terminaram ao menos uma vez
+			if c.LastTermination != nil {
+				previous := opts
+				previous.Previous = true
+				file := path.Join(pod.Nome, c.Name+".previous.log")
+				if err := addLogFile(ctx, tw, file, now, namespace, pod.Nome, c.Name, previous); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gz.Close()
+}
+
+// addLogFile lê os logs de um container e os adiciona ao tar. O tar exige o tamanho
+// antes do conteúdo, então o log é lido inteiro para memória. Falhas de leitura viram
+// o conteúdo do arquivo, para que um container sem logs não invalide o arquivo inteiro.
+func addLogFile(ctx context.Context, tw *tar.Writer, name string, modTime time.Time, namespace, pod, container string, opts LogOptions) error {
+	var buf bytes.Buffer
+	err := streamContainerLogs(ctx, namespace, pod, container, opts, func(line LogLine) error {
+		buf.WriteString(line.Line)
+		buf.WriteByte('\n')
+		return nil
+	})
+	if err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		buf.WriteString(fmt.Sprintf("<erro ao ler logs: %v>\n", err))
+	}
+
+	header := &tar.Header{
+		Name:    name,
+		Mode:    0o644,
+		Size:    int64(buf.Len()),
+		ModTime: modTime,
+	}
+	if err := tw.WriteHeader(header); err != nil {
+		return err
+	}
+	_, err = tw.Write(buf.Bytes())
+	return err
+}
@@ -4,12 +4,31 @@ import (
 	"os"
 	"path/filepath"
 
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/metadata"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/restmapper"
 	"k8s.io/client-go/tools/clientcmd"
 )
 
 var client *kubernetes.Clientset
 
+// restConfig é a configuração usada pelos clientes, necessária para exec e port-forward
+var restConfig *rest.Config
+
+// metadataClient lista apenas os metadados dos objetos, usado em contagens
+var metadataClient metadata.Interface
+
+// dynamicClient e restMapper aplicam manifestos de qualquer tipo, inclusive CRDs
+var dynamicClient dynamic.Interface
+var restMapper *restmapper.DeferredDiscoveryRESTMapper
+
+// cachedDiscovery guarda em memória os tipos que o API server oferece
+var cachedDiscovery discovery.CachedDiscoveryInterface
+
 func init() {
 	home, _ := os.UserHomeDir()
 	kubeConfigPath := filepath.Join(home, ".kube", "config")
@@ -18,5 +37,10 @@ func init() {
 	if err != nil {
 		panic(err.Error())
 	}
+	restConfig = config
 	client = kubernetes.NewForConfigOrDie(config)
+	metadataClient = metadata.NewForConfigOrDie(config)
+	dynamicClient = dynamic.NewForConfigOrDie(config)
+	cachedDiscovery = memory.NewMemCacheClient(client.Discovery())
+	restMapper = restmapper.NewDeferredDiscoveryRESTMapper(cachedDiscovery)
 }
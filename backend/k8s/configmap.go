@@ -0,0 +1,288 @@
+package k8s
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// ErrInvalidData indica chaves ou valores inválidos em um ConfigMap ou Secret.
+var ErrInvalidData = errors.New("dados inválidos")
+
+// ConfigMapInfo resume um ConfigMap: as chaves (sem os valores) e os deployments que o usam.
+type ConfigMapInfo struct {
+	Nome              string            `json:"nome"`
+	Namespace         string            `json:"namespace"`
+	Keys              []DataKeyInfo     `json:"keys"`
+	Immutable         bool              `json:"immutable"`
+	UsedBy            []string          `json:"usedBy"`
+	Labels            map[string]string `json:"labels,omitempty"`
+	CreationTimestamp metav1.Time       `json:"creationTimestamp"`
+	Age               string            `json:"age"`
+}
+
+// DataKeyInfo descreve uma chave de ConfigMap ou Secret. Size é o tamanho do valor em bytes;
+// Binary indica valores de binaryData.
+type DataKeyInfo struct {
+	Name   string `json:"name"`
+	Size   int    `json:"size"`
+	Binary bool   `json:"binary,omitempty"`
+}
+
+// ConfigMapDetail inclui os valores do ConfigMap. BinaryData é serializado em base64.
+type ConfigMapDetail struct {
+	ConfigMapInfo
+	Annotations map[string]string `json:"annotations,omitempty"`
+	Data        map[string]string `json:"data"`
+	BinaryData  map[string][]byte `json:"binaryData,omitempty"`
+	Events      []EventInfo       `json:"events"`
+}
+
+// ConfigMapUpdate altera chaves de um ConfigMap: Set e SetBinary criam ou substituem
+// chaves e Remove as apaga. As demais chaves são mantidas.
+type ConfigMapUpdate struct {
+	Set       map[string]string
+	SetBinary map[string][]byte
+	Remove    []string
+}
+
+func (c ConfigMapInfo) GetNamespace() string { return c.Namespace }
+func (c ConfigMapInfo) GetName() string      { return c.Nome }
+
+// ListConfigMaps retorna uma página de ConfigMapInfo do namespace informado (ou de todos, com AllNamespaces).
+func ListConfigMaps(namespace string, opts ListOptions) (ListResult[ConfigMapInfo], error) {
+	return listNamespacedPage(namespace, opts, listConfigMaps)
+}
+
+func listConfigMaps(namespace string, listOpts metav1.ListOptions) ([]ConfigMapInfo, metav1.ListMeta, error) {
+	configMaps, err := client.CoreV1().ConfigMaps(namespace).List(context.TODO(), listOpts)
+	if err != nil {
+		return nil, metav1.ListMeta{}, fmt.Errorf("erro ao listar configmaps: %w", err)
+	}
+	usedBy, err := configMapUsers(namespace)
+	if err != nil {
+		return nil, metav1.ListMeta{}, err
+	}
+
+	infos := make([]ConfigMapInfo, 0, len(configMaps.Items))
+	for _, configMap := range configMaps.Items {
+		infos = append(infos, newConfigMapInfo(configMap, usedBy))
+	}
+	return infos, configMaps.ListMeta, nil
+}
+
+// GetConfigMap retorna o ConfigMap com os valores, os deployments que o usam e eventos recentes.
+func GetConfigMap(namespace, name string) (ConfigMapDetail, error) {
+	configMap, err := client.CoreV1().ConfigMaps(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+	if err != nil {
+		return ConfigMapDetail{}, fmt.Errorf("configmap não encontrado: %w", err)
+	}
+	usedBy, err := configMapUsers(namespace)
+	if err != nil {
+		return ConfigMapDetail{}, err
+	}
+
+	detail := ConfigMapDetail{
+		ConfigMapInfo: newConfigMapInfo(*configMap, usedBy),
+		Annotations:   withoutLastApplied(configMap.Annotations),
+		Data:          configMap.Data,
+		BinaryData:    configMap.BinaryData,
+	}
+	if detail.Data == nil {
+		detail.Data = map[string]string{}
+	}
+
+	detail.Events = detailEvents(namespace, v1.SchemeGroupVersion.WithKind("ConfigMap"), configMap.Name, configMap.UID)
+	return detail, nil
+}
+
+// BuildConfigMap monta um ConfigMap depois de validar os nomes das chaves.
+func BuildConfigMap(namespace, name string, data map[string]string, binaryData map[string][]byte) (*v1.ConfigMap, error) {
+	if err := validateDataKeys(data, binaryData); err != nil {
+		return nil, err
+	}
+	return &v1.ConfigMap{
+		TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Data:       data,
+		BinaryData: binaryData,
+	}, nil
+}
+
+// CreateConfigMap cria um ConfigMap com valores de texto (data) e binários (binaryData).
+func CreateConfigMap(namespace, name string, data map[string]string, binaryData map[string][]byte, opts MutateOptions) (*v1.ConfigMap, error) {
+	configMap, err := BuildConfigMap(namespace, name, data, binaryData)
+	if err != nil {
+		return nil, err
+	}
+	return client.CoreV1().ConfigMaps(namespace).Create(context.Background(), configMap, opts.createOptions())
+}
+
+// UpdateConfigMapKeys cria, substitui ou remove chaves do ConfigMap, mantendo as demais.
+func UpdateConfigMapKeys(namespace, name string, update ConfigMapUpdate, opts MutateOptions) (*v1.ConfigMap, error) {
+	if err := validateDataKeys(update.Set, update.SetBinary); err != nil {
+		return nil, err
+	}
+
+	configMaps := client.CoreV1().ConfigMaps(namespace)
+	configMap, err := configMaps.Get(context.TODO(), name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("configmap não encontrado: %w", err)
+	}
+	if configMap.Immutable != nil && *configMap.Immutable {
+		return nil, apierrors.NewConflict(v1.Resource("configmaps"), name, errors.New("ConfigMap imutável; crie um novo ConfigMap"))
+	}
+
+	for _, key := range update.Remove {
+		delete(configMap.Data, key)
+		delete(configMap.BinaryData, key)
+	}
+	// Uma chave não pode estar em data e binaryData ao mesmo tempo
+	for key, value := range update.Set {
+		if configMap.Data == nil {
+			configMap.Data = map[string]string{}
+		}
+		configMap.Data[key] = value
+		delete(configMap.BinaryData, key)
+	}
+	for key, value := range update.SetBinary {
+		if configMap.BinaryData == nil {
+			configMap.BinaryData = map[string][]byte{}
+		}
+		configMap.BinaryData[key] = value
+		delete(configMap.Data, key)
+	}
+
+	updated, err := configMaps.Update(context.TODO(), configMap, opts.updateOptions())
+	if err != nil {
+		return nil, fmt.Errorf("erro ao atualizar configmap: %w", err)
+	}
+	return updated, nil
+}
+
+func DeleteConfigMap(name string, namespace string, opts MutateOptions) (*v1.ConfigMap, error) {
+	configMaps := client.CoreV1().ConfigMaps(namespace)
+	configMap, err := configMaps.Get(context.Background(), name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("configmap não encontrado: %w", err)
+	}
+	if err := configMaps.Delete(context.Background(), name, opts.deleteOptions(configMap.UID)); err != nil {
+		return nil, fmt.Errorf("erro ao deletar configmap: %w", err)
+	}
+	return configMap, nil
+}
+
+func newConfigMapInfo(configMap v1.ConfigMap, usedBy map[string][]string) ConfigMapInfo {
+	info := ConfigMapInfo{
+		Nome:              configMap.Name,
+		Namespace:         configMap.Namespace,
+		Keys:              []DataKeyInfo{},
+		Immutable:         configMap.Immutable != nil && *configMap.Immutable,
+		UsedBy:            usedBy[configMap.Namespace+"/"+configMap.Name],
+		Labels:            configMap.Labels,
+		CreationTimestamp: configMap.CreationTimestamp,
+		Age:               age(configMap.CreationTimestamp),
+	}
+	if info.UsedBy == nil {
+		info.UsedBy = []string{}
+	}
+
+	for key, value := range configMap.Data {
+		info.Keys = append(info.Keys, DataKeyInfo{Name: key, Size: len(value)})
+	}
+	for key, value := range configMap.BinaryData {
+		info.Keys = append(info.Keys, DataKeyInfo{Name: key, Size: len(value), Binary: true})
+	}
+	sort.Slice(info.Keys, func(i, j int) bool { return info.Keys[i].Name < info.Keys[j].Name })
+	return info
+}
+
+// configMapUsers mapeia "namespace/nome" de cada ConfigMap para os deployments que o usam.
+func configMapUsers(namespace string) (map[string][]string, error) {
+	deployments, err := client.AppsV1().Deployments(namespace).List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("erro ao listar deployments: %w", err)
+	}
+	return deploymentReferences(deployments.Items, podSpecConfigMaps), nil
+}
+
+// deploymentReferences mapeia "namespace/nome" de cada objeto referenciado para os
+// deployments que o usam, de acordo com refs.
+func deploymentReferences(deployments []appsv1.Deployment, refs func(v1.PodSpec) map[string]bool) map[string][]string {
+	usedBy := map[string][]string{}
+	for _, deployment := range deployments {
+		for name := range refs(deployment.Spec.Template.Spec) {
+			key := deployment.Namespace + "/" + name
+			usedBy[key] = append(usedBy[key], deployment.Name)
+		}
+	}
+	for _, names := range usedBy {
+		sort.Strings(names)
+	}
+	return usedBy
+}
+
+// podSpecConfigMaps devolve os ConfigMaps usados pelo pod em variáveis de ambiente
+// (env e envFrom) e volumes, inclusive volumes projetados.
+func podSpecConfigMaps(spec v1.PodSpec) map[string]bool {
+	names := map[string]bool{}
+	for _, c := range append(append([]v1.Container{}, spec.InitContainers...), spec.Containers...) {
+		for _, envFrom := range c.EnvFrom {
+			if envFrom.ConfigMapRef != nil {
+				names[envFrom.ConfigMapRef.Name] = true
+			}
+		}
+		for _, env := range c.Env {
+			if env.ValueFrom != nil && env.ValueFrom.ConfigMapKeyRef != nil {
+				names[env.ValueFrom.ConfigMapKeyRef.Name] = true
+			}
+		}
+	}
+	for _, volume := range spec.Volumes {
+		if volume.ConfigMap != nil {
+			names[volume.ConfigMap.Name] = true
+		}
+		if volume.Projected != nil {
+			for _, source := range volume.Projected.Sources {
+				if source.ConfigMap != nil {
+					names[source.ConfigMap.Name] = true
+				}
+			}
+		}
+	}
+	return names
+}
+
+// validateDataKeys valida os nomes das chaves e impede a mesma chave em data e binaryData.
+func validateDataKeys(data map[string]string, binaryData map[string][]byte) error {
+	var problems []string
+	for key := range data {
+		for _, msg := range validation.IsConfigMapKey(key) {
+			problems = append(problems, fmt.Sprintf("chave '%s': %s", key, msg))
+		}
+	}
+	for key := range binaryData {
+		for _, msg := range validation.IsConfigMapKey(key) {
+			problems = append(problems, fmt.Sprintf("chave '%s': %s", key, msg))
+		}
+		if _, ok := data[key]; ok {
+			problems = append(problems, fmt.Sprintf("chave '%s' aparece como texto e como binário", key))
+		}
+	}
+	if len(problems) > 0 {
+		sort.Strings(problems)
+		return fmt.Errorf("%w: %s", ErrInvalidData, strings.Join(problems, "; "))
+	}
+	return nil
+}
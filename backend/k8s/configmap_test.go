@@ -0,0 +1,93 @@
+package k8s
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestValidateDataKeys(t *testing.T) {
+	tests := []struct {
+		name       string
+		data       map[string]string
+		binaryData map[string][]byte
+		wantErr    []string
+	}{
+		{
+			name: "chaves válidas",
+			data: map[string]string{"LOG_LEVEL": "info", "app.properties": "a=1", "config-file_v2": "x"},
+		},
+		{
+			name:       "texto e binário em chaves diferentes",
+			data:       map[string]string{"config.yaml": "a: 1"},
+			binaryData: map[string][]byte{"logo.png": {0x89, 0x50}},
+		},
+		{
+			name: "sem chaves",
+		},
+		{
+			name:    "chave com barra",
+			data:    map[string]string{"dir/arquivo": "x"},
+			wantErr: []string{"'dir/arquivo'"},
+		},
+		{
+			name:    "chave com espaço",
+			data:    map[string]string{"minha chave": "x"},
+			wantErr: []string{"'minha chave'"},
+		},
+		{
+			name:       "chave binária inválida",
+			binaryData: map[string][]byte{"..": {0x00}},
+			wantErr:    []string{"'..'"},
+		},
+		{
+			name:       "mesma chave em data e binaryData",
+			data:       map[string]string{"cert": "texto"},
+			binaryData: map[string][]byte{"cert": {0x01}},
+			wantErr:    []string{"'cert' aparece como texto e como binário"},
+		},
+		{
+			name:    "todos os problemas na mensagem",
+			data:    map[string]string{"a b": "x", "c/d": "y"},
+			wantErr: []string{"'a b'", "'c/d'"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateDataKeys(tt.data, tt.binaryData)
+			if len(tt.wantErr) == 0 {
+				if err != nil {
+					t.Fatalf("validateDataKeys() erro inesperado: %v", err)
+				}
+				return
+			}
+			if !errors.Is(err, ErrInvalidData) {
+				t.Fatalf("validateDataKeys() erro = %v, esperado ErrInvalidData", err)
+			}
+			for _, want := range tt.wantErr {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("validateDataKeys() erro = %q, esperado mencionar %s", err, want)
+				}
+			}
+		})
+	}
+}
+
+func TestBuildConfigMap(t *testing.T) {
+	configMap, err := BuildConfigMap("default", "app", map[string]string{"LOG_LEVEL": "info"}, map[string][]byte{"logo.png": {0x89}})
+	if err != nil {
+		t.Fatalf("BuildConfigMap() erro inesperado: %v", err)
+	}
+	if configMap.Kind != "ConfigMap" || configMap.Namespace != "default" || configMap.Name != "app" {
+		t.Errorf("BuildConfigMap() = %s %s/%s, esperado ConfigMap default/app", configMap.Kind, configMap.Namespace, configMap.Name)
+	}
+	if configMap.Data["LOG_LEVEL"] != "info" || len(configMap.BinaryData["logo.png"]) != 1 {
+		t.Errorf("BuildConfigMap() data = %v, binaryData = %v", configMap.Data, configMap.BinaryData)
+	}
+
+	// O mesmo formato usado por /createResource precisa passar pela validação das chaves
+	if _, err := BuildConfigMap("default", "app", map[string]string{"dir/arquivo": "x"}, nil); !errors.Is(err, ErrInvalidData) {
+		t.Errorf("BuildConfigMap() com chave inválida: erro = %v, esperado ErrInvalidData", err)
+	}
+}
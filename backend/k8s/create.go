@@ -2,17 +2,25 @@ package k8s
 
 import (
 	"context"
+	"fmt"
 
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	batchv1 "k8s.io/api/batch/v1"
 	v1 "k8s.io/api/core/v1"
 	netv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
-func CreatePod(namespace string, image string, name string) error {
+// As funções Build* montam os objetos sem enviá-los ao cluster, para que possam ser
+// criados (CreateObject) ou comparados com o objeto existente (DiffObject).
+
+func BuildPod(namespace string, image string, name string) *v1.Pod {
 	// create a pod definition
-	podDefinition := &v1.Pod{
+	return &v1.Pod{
+		TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "Pod"},
 		ObjectMeta: metav1.ObjectMeta{
 			GenerateName: "go-api-",
 			Namespace:    namespace,
@@ -27,16 +35,11 @@ func CreatePod(namespace string, image string, name string) error {
 			},
 		},
 	}
-	// create a new pod
-	_, err := client.CoreV1().Pods(namespace).Create(context.Background(), podDefinition, metav1.CreateOptions{})
-	if err != nil {
-		return err
-	}
-	return nil
 }
 
-func CreateDeployment(namespace, name, image string, replicas int32, containerPort int32, env map[string]string) error {
-	dep := &appsv1.Deployment{
+func BuildDeployment(namespace, name, image string, replicas int32, containerPort int32, env map[string]string) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		TypeMeta: metav1.TypeMeta{APIVersion: "apps/v1", Kind: "Deployment"},
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      name,
 			Namespace: namespace,
@@ -77,15 +80,16 @@ func CreateDeployment(namespace, name, image string, replicas int32, containerPo
 			},
 		},
 	}
-	_, err := client.AppsV1().Deployments(namespace).Create(context.Background(), dep, metav1.CreateOptions{})
-	if err != nil {
-		return err
-	}
-	return nil
 }
 
-func CreateService(namespace, name, serviceType string, port int32, targetPort int) error {
-	svc := &v1.Service{
+func CreateDeployment(namespace, name, image string, replicas int32, containerPort int32, env map[string]string, opts MutateOptions) (*appsv1.Deployment, error) {
+	dep := BuildDeployment(namespace, name, image, replicas, containerPort, env)
+	return client.AppsV1().Deployments(namespace).Create(context.Background(), dep, opts.createOptions())
+}
+
+func BuildService(namespace, name, serviceType string, port int32, targetPort int) *v1.Service {
+	return &v1.Service{
+		TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "Service"},
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      name,
 			Namespace: namespace,
@@ -101,48 +105,40 @@ func CreateService(namespace, name, serviceType string, port int32, targetPort i
 			},
 		},
 	}
-	_, err := client.CoreV1().Services(namespace).Create(context.Background(), svc, metav1.CreateOptions{})
-	if err != nil {
-		return err
-	}
-	return nil
 }
 
-func CreateSecret(namespace, name, secretType string, data map[string]string) error {
+func CreateService(namespace, name, serviceType string, port int32, targetPort int, opts MutateOptions) (*v1.Service, error) {
+	svc := BuildService(namespace, name, serviceType, port, targetPort)
+	return client.CoreV1().Services(namespace).Create(context.Background(), svc, opts.createOptions())
+}
+
+// BuildSecret monta um Secret do tipo informado, validando as chaves exigidas pelos
+// tipos embutidos (veja ValidateSecretData).
+func BuildSecret(namespace, name, secretType string, data map[string]string) (*v1.Secret, error) {
 	byteData := map[string][]byte{}
 	for k, v := range data {
 		byteData[k] = []byte(v)
 	}
-	stype := v1.SecretType(secretType)
-	sec := &v1.Secret{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      name,
-			Namespace: namespace,
-		},
-		Type: stype,
-		Data: byteData,
-	}
-	_, err := client.CoreV1().Secrets(namespace).Create(context.Background(), sec, metav1.CreateOptions{})
-	if err != nil {
-		return err
+	secret := newTypedSecret(namespace, name, v1.SecretType(secretType), byteData)
+	if err := ValidateSecretData(secret.Type, secret.Data); err != nil {
+		return nil, err
 	}
-	return nil
+	return secret, nil
 }
-func CreateNs(name string) error {
-	ns := &v1.Namespace{
+
+func BuildNamespace(name string) *v1.Namespace {
+	return &v1.Namespace{
+		TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "Namespace"},
 		ObjectMeta: metav1.ObjectMeta{
 			Name: name,
 		},
 	}
-	_, err := client.CoreV1().Namespaces().Create(context.Background(), ns, metav1.CreateOptions{})
-	if err != nil {
-	}
-	return nil
 }
 
-func CreateIngress(namespace, name, host, serviceName string, servicePort int32) error {
+func BuildIngress(namespace, name, host, serviceName string, servicePort int32) *netv1.Ingress {
 	pathType := netv1.PathTypePrefix
-	ing := &netv1.Ingress{
+	return &netv1.Ingress{
+		TypeMeta: metav1.TypeMeta{APIVersion: "networking.k8s.io/v1", Kind: "Ingress"},
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      name,
 			Namespace: namespace,
@@ -171,28 +167,68 @@ func CreateIngress(namespace, name, host, serviceName string, servicePort int32)
 			},
 		},
 	}
-	_, err := client.NetworkingV1().Ingresses(namespace).Create(context.Background(), ing, metav1.CreateOptions{})
-	if err != nil {
-		return err
+}
+
+// CreateObject cria um objeto montado por uma das funções Build*.
+func CreateObject(obj runtime.Object, opts MutateOptions) (runtime.Object, error) {
+	ctx := context.Background()
+	switch o := obj.(type) {
+	case *v1.Pod:
+		return client.CoreV1().Pods(o.Namespace).Create(ctx, o, opts.createOptions())
+	case *appsv1.Deployment:
+		return client.AppsV1().Deployments(o.Namespace).Create(ctx, o, opts.createOptions())
+	case *appsv1.StatefulSet:
+		return client.AppsV1().StatefulSets(o.Namespace).Create(ctx, o, opts.createOptions())
+	case *appsv1.DaemonSet:
+		return client.AppsV1().DaemonSets(o.Namespace).Create(ctx, o, opts.createOptions())
+	case *batchv1.Job:
+		return client.BatchV1().Jobs(o.Namespace).Create(ctx, o, opts.createOptions())
+	case *batchv1.CronJob:
+		return client.BatchV1().CronJobs(o.Namespace).Create(ctx, o, opts.createOptions())
+	case *v1.Service:
+		return client.CoreV1().Services(o.Namespace).Create(ctx, o, opts.createOptions())
+	case *v1.Secret:
+		secret, err := client.CoreV1().Secrets(o.Namespace).Create(ctx, o, opts.createOptions())
+		if err != nil {
+			return nil, err
+		}
+		return withoutSecretValues(secret), nil
+	case *v1.Namespace:
+		return client.CoreV1().Namespaces().Create(ctx, o, opts.createOptions())
+	case *autoscalingv2.HorizontalPodAutoscaler:
+		return client.AutoscalingV2().HorizontalPodAutoscalers(o.Namespace).Create(ctx, o, opts.createOptions())
+	case *v1.PersistentVolumeClaim:
+		return client.CoreV1().PersistentVolumeClaims(o.Namespace).Create(ctx, o, opts.createOptions())
+	case *v1.ConfigMap:
+		return client.CoreV1().ConfigMaps(o.Namespace).Create(ctx, o, opts.createOptions())
+	case *netv1.Ingress:
+		return client.NetworkingV1().Ingresses(o.Namespace).Create(ctx, o, opts.createOptions())
+	default:
+		return nil, fmt.Errorf("tipo %T não suportado", obj)
 	}
-	return nil
+}
+
+// Application agrupa os objetos criados por CreateApplication
+type Application struct {
+	Deployment *appsv1.Deployment `json:"deployment"`
+	Service    *v1.Service        `json:"service"`
 }
 
 // CreateApplication cria um Deployment e um Service juntos
-func CreateApplication(namespace, name, image string, replicas int32, containerPort int32, serviceType string, servicePort int32, targetPort int, env map[string]string) error {
+func CreateApplication(namespace, name, image string, replicas int32, containerPort int32, serviceType string, servicePort int32, targetPort int, env map[string]string, opts MutateOptions) (*Application, error) {
 	// Primeiro cria o Deployment
-	err := CreateDeployment(namespace, name, image, replicas, containerPort, env)
+	deployment, err := CreateDeployment(namespace, name, image, replicas, containerPort, env, opts)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// Depois cria o Service
-	err = CreateService(namespace, name, serviceType, servicePort, targetPort)
+	service, err := CreateService(namespace, name, serviceType, servicePort, targetPort, opts)
 	if err != nil {
 		// Se o Service falhar, o Deployment já foi criado
 		// Em produção, você pode querer fazer rollback do Deployment aqui
-		return err
+		return nil, err
 	}
 
-	return nil
+	return &Application{Deployment: deployment, Service: service}, nil
 }
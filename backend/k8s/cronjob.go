@@ -0,0 +1,282 @@
+package k8s
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	batchv1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ErrInvalidSchedule indica uma expressão cron ou um fuso horário inválido.
+var ErrInvalidSchedule = errors.New("agendamento inválido")
+
+// Anotação que o kubectl (create job --from) grava nos Jobs disparados manualmente.
+const cronJobInstantiateAnnotation = "cronjob.kubernetes.io/instantiate"
+
+// CronJobInfo resume um CronJob. NextScheduleTime é calculado a partir da expressão
+// cron e do fuso do CronJob, e fica vazio enquanto ele estiver suspenso;
+// ScheduleError explica quando a expressão não pôde ser interpretada.
+type CronJobInfo struct {
+	Nome               string            `json:"nome"`
+	Namespace          string            `json:"namespace"`
+	Schedule           string            `json:"schedule"`
+	TimeZone           string            `json:"timeZone,omitempty"`
+	Suspend            bool              `json:"suspend"`
+	ConcurrencyPolicy  string            `json:"concurrencyPolicy"`
+	Image              string            `json:"image"`
+	Active             int               `json:"active"`
+	LastScheduleTime   *metav1.Time      `json:"lastScheduleTime,omitempty"`
+	LastSuccessfulTime *metav1.Time      `json:"lastSuccessfulTime,omitempty"`
+	NextScheduleTime   *metav1.Time      `json:"nextScheduleTime,omitempty"`
+	ScheduleError      string            `json:"scheduleError,omitempty"`
+	Labels             map[string]string `json:"labels,omitempty"`
+	CreationTimestamp  metav1.Time       `json:"creationTimestamp"`
+	Age                string            `json:"age"`
+}
+
+// CronJobDetail é o modelo completo de um CronJob, com os Jobs que ele criou
+// (do mais novo para o mais antigo) e eventos recentes.
+type CronJobDetail struct {
+	CronJobInfo
+	StartingDeadlineSeconds    *int64            `json:"startingDeadlineSeconds,omitempty"`
+	SuccessfulJobsHistoryLimit *int32            `json:"successfulJobsHistoryLimit,omitempty"`
+	FailedJobsHistoryLimit     *int32            `json:"failedJobsHistoryLimit,omitempty"`
+	Annotations                map[string]string `json:"annotations,omitempty"`
+	Jobs                       []JobInfo         `json:"jobs"`
+	Events                     []EventInfo       `json:"events"`
+}
+
+// CronJobSpec reúne os parâmetros de criação de um CronJob; Job é o modelo de cada execução.
+type CronJobSpec struct {
+	Schedule          string  `json:"schedule"`
+	TimeZone          string  `json:"timeZone,omitempty"`
+	ConcurrencyPolicy string  `json:"concurrencyPolicy,omitempty"`
+	Suspend           bool    `json:"suspend,omitempty"`
+	Job               JobSpec `json:"job"`
+}
+
+func (c CronJobInfo) GetNamespace() string { return c.Namespace }
+func (c CronJobInfo) GetName() string      { return c.Nome }
+
+// ListCronJobs retorna uma página de CronJobInfo do namespace informado (ou de todos, com AllNamespaces).
+func ListCronJobs(namespace string, opts ListOptions) (ListResult[CronJobInfo], error) {
+	return listNamespacedPage(namespace, opts, listCronJobs)
+}
+
+func listCronJobs(namespace string, listOpts metav1.ListOptions) ([]CronJobInfo, metav1.ListMeta, error) {
+	cronJobs, err := client.BatchV1().CronJobs(namespace).List(context.TODO(), listOpts)
+	if err != nil {
+		return nil, metav1.ListMeta{}, fmt.Errorf("erro ao listar cronjobs: %w", err)
+	}
+
+	now := time.Now()
+	infos := make([]CronJobInfo, 0, len(cronJobs.Items))
+	for _, cj := range cronJobs.Items {
+		infos = append(infos, newCronJobInfo(cj, now))
+	}
+	return infos, cronJobs.ListMeta, nil
+}
+
+// GetCronJob retorna os detalhes de um CronJob.
+func GetCronJob(namespace, name string) (CronJobDetail, error) {
+	cj, err := client.BatchV1().CronJobs(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+	if err != nil {
+		return CronJobDetail{}, fmt.Errorf("cronjob não encontrado: %w", err)
+	}
+
+	detail := CronJobDetail{
+		CronJobInfo:                newCronJobInfo(*cj, time.Now()),
+		StartingDeadlineSeconds:    cj.Spec.StartingDeadlineSeconds,
+		SuccessfulJobsHistoryLimit: cj.Spec.SuccessfulJobsHistoryLimit,
+		FailedJobsHistoryLimit:     cj.Spec.FailedJobsHistoryLimit,
+		Annotations:                withoutLastApplied(cj.Annotations),
+		Jobs:                       []JobInfo{},
+	}
+
+	jobs, err := client.BatchV1().Jobs(namespace).List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return detail, fmt.Errorf("erro ao listar jobs: %w", err)
+	}
+	for _, job := range jobs.Items {
+		if ref := metav1.GetControllerOfNoCopy(&job); ref != nil && ref.UID == cj.UID {
+			detail.Jobs = append(detail.Jobs, newJobInfo(job))
+		}
+	}
+	sort.SliceStable(detail.Jobs, func(i, j int) bool {
+		return detail.Jobs[j].CreationTimestamp.Before(&detail.Jobs[i].CreationTimestamp)
+	})
+
+	detail.Events = detailEvents(namespace, batchv1.SchemeGroupVersion.WithKind("CronJob"), cj.Name, cj.UID)
+	return detail, nil
+}
+
+// BuildCronJob monta um CronJob depois de validar a expressão cron e o fuso horário.
+func BuildCronJob(namespace, name string, spec CronJobSpec) (*batchv1.CronJob, error) {
+	if _, _, err := parseCronSchedule(spec.Schedule, spec.TimeZone); err != nil {
+		return nil, err
+	}
+
+	policy := batchv1.ConcurrencyPolicy(spec.ConcurrencyPolicy)
+	switch policy {
+	case "":
+		policy = batchv1.AllowConcurrent
+	case batchv1.AllowConcurrent, batchv1.ForbidConcurrent, batchv1.ReplaceConcurrent:
+	default:
+		return nil, fmt.Errorf("%w: concurrencyPolicy deve ser Allow, Forbid ou Replace", ErrInvalidSchedule)
+	}
+
+	job := BuildJob(namespace, name, spec.Job)
+	cj := &batchv1.CronJob{
+		TypeMeta: metav1.TypeMeta{APIVersion: "batch/v1", Kind: "CronJob"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: batchv1.CronJobSpec{
+			Schedule:          spec.Schedule,
+			ConcurrencyPolicy: policy,
+			Suspend:           &spec.Suspend,
+			JobTemplate: batchv1.JobTemplateSpec{
+				Spec: job.Spec,
+			},
+		},
+	}
+	if spec.TimeZone != "" {
+		cj.Spec.TimeZone = &spec.TimeZone
+	}
+	return cj, nil
+}
+
+// SetCronJobSuspend suspende ou retoma os agendamentos de um CronJob.
+// Jobs já em execução não são afetados.
+func SetCronJobSuspend(namespace, name string, suspend bool, opts MutateOptions) (*batchv1.CronJob, error) {
+	cronJobs := client.BatchV1().CronJobs(namespace)
+	cj, err := cronJobs.Get(context.TODO(), name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("cronjob não encontrado: %w", err)
+	}
+
+	cj.Spec.Suspend = &suspend
+	updated, err := cronJobs.Update(context.TODO(), cj, opts.updateOptions())
+	if err != nil {
+		return nil, fmt.Errorf("erro ao atualizar cronjob: %w", err)
+	}
+	return updated, nil
+}
+
+// TriggerCronJob cria um Job a partir do modelo do CronJob, como kubectl create job --from.
+// O Job pertence ao CronJob, aparece no seu histórico e segue os seus limites.
+func TriggerCronJob(namespace, name string, opts MutateOptions) (*batchv1.Job, error) {
+	cj, err := client.BatchV1().CronJobs(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("cronjob não encontrado: %w", err)
+	}
+
+	annotations := map[string]string{cronJobInstantiateAnnotation: "manual"}
+	for k, v := range cj.Spec.JobTemplate.Annotations {
+		annotations[k] = v
+	}
+
+	isController := true
+	job := &batchv1.Job{
+		TypeMeta: metav1.TypeMeta{APIVersion: "batch/v1", Kind: "Job"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        manualJobName(cj.Name, time.Now()),
+			Namespace:   namespace,
+			Labels:      cj.Spec.JobTemplate.Labels,
+			Annotations: annotations,
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: batchv1.SchemeGroupVersion.String(),
+				Kind:       "CronJob",
+				Name:       cj.Name,
+				UID:        cj.UID,
+				Controller: &isController,
+			}},
+		},
+		Spec: cj.Spec.JobTemplate.Spec,
+	}
+
+	created, err := client.BatchV1().Jobs(namespace).Create(context.TODO(), job, opts.createOptions())
+	if err != nil {
+		return nil, fmt.Errorf("erro ao criar job: %w", err)
+	}
+	return created, nil
+}
+
+// DeleteCronJob remove o CronJob e, em segundo plano, os Jobs e pods que ele criou.
+func DeleteCronJob(name string, namespace string, opts MutateOptions) (*batchv1.CronJob, error) {
+	cronJobs := client.BatchV1().CronJobs(namespace)
+	cj, err := cronJobs.Get(context.Background(), name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("cronjob não encontrado: %w", err)
+	}
+	if err := cronJobs.Delete(context.Background(), name, opts.cascadeDeleteOptions(cj.UID)); err != nil {
+		return nil, fmt.Errorf("erro ao deletar cronjob: %w", err)
+	}
+	return cj, nil
+}
+
+func newCronJobInfo(cj batchv1.CronJob, now time.Time) CronJobInfo {
+	info := CronJobInfo{
+		Nome:               cj.Name,
+		Namespace:          cj.Namespace,
+		Schedule:           cj.Spec.Schedule,
+		Suspend:            cj.Spec.Suspend != nil && *cj.Spec.Suspend,
+		ConcurrencyPolicy:  string(cj.Spec.ConcurrencyPolicy),
+		Active:             len(cj.Status.Active),
+		LastScheduleTime:   cj.Status.LastScheduleTime,
+		LastSuccessfulTime: cj.Status.LastSuccessfulTime,
+		Labels:             cj.Labels,
+		CreationTimestamp:  cj.CreationTimestamp,
+		Age:                age(cj.CreationTimestamp),
+	}
+	if cj.Spec.TimeZone != nil {
+		info.TimeZone = *cj.Spec.TimeZone
+	}
+	if containers := cj.Spec.JobTemplate.Spec.Template.Spec.Containers; len(containers) > 0 {
+		info.Image = containers[0].Image
+	}
+
+	sched, loc, err := parseCronSchedule(info.Schedule, info.TimeZone)
+	if err != nil {
+		info.ScheduleError = err.Error()
+		return info
+	}
+	if !info.Suspend {
+		next := metav1.NewTime(sched.Next(now.In(loc)))
+		info.NextScheduleTime = &next
+	}
+	return info
+}
+
+// parseCronSchedule interpreta a expressão no formato padrão de 5 campos (aceitando
+// macros como @hourly), no fuso informado ou em UTC, como o controlador de CronJobs.
+func parseCronSchedule(schedule, timeZone string) (cron.Schedule, *time.Location, error) {
+	loc := time.UTC
+	if timeZone != "" {
+		var err error
+		if loc, err = time.LoadLocation(timeZone); err != nil {
+			return nil, nil, fmt.Errorf("%w: fuso horário '%s' desconhecido", ErrInvalidSchedule, timeZone)
+		}
+	}
+	sched, err := cron.ParseStandard(schedule)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %v", ErrInvalidSchedule, err)
+	}
+	return sched, loc, nil
+}
+
+// manualJobName gera o nome do Job disparado manualmente, respeitando o limite de
+// 63 caracteres do label job-name.
+func manualJobName(cronJob string, now time.Time) string {
+	suffix := fmt.Sprintf("-manual-%d", now.Unix())
+	if max := 63 - len(suffix); len(cronJob) > max {
+		cronJob = cronJob[:max]
+	}
+	return cronJob + suffix
+}
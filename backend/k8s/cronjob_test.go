@@ -0,0 +1,99 @@
+package k8s
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+	// Garante os fusos horários mesmo em máquinas sem o zoneinfo do sistema
+	_ "time/tzdata"
+)
+
+func TestParseCronSchedule(t *testing.T) {
+	from := time.Date(2024, time.March, 10, 12, 2, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		schedule string
+		timeZone string
+		wantNext time.Time
+		wantErr  bool
+	}{
+		{
+			name:     "a cada cinco minutos",
+			schedule: "*/5 * * * *",
+			wantNext: time.Date(2024, time.March, 10, 12, 5, 0, 0, time.UTC),
+		},
+		{
+			name:     "macro",
+			schedule: "@hourly",
+			wantNext: time.Date(2024, time.March, 10, 13, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "com fuso horário",
+			schedule: "0 9 * * *",
+			timeZone: "America/Sao_Paulo",
+			wantNext: time.Date(2024, time.March, 11, 12, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "expressão inválida",
+			schedule: "todo dia",
+			wantErr:  true,
+		},
+		{
+			name:     "campo de segundos não é aceito",
+			schedule: "0 */5 * * * *",
+			wantErr:  true,
+		},
+		{
+			name:     "fuso horário desconhecido",
+			schedule: "0 9 * * *",
+			timeZone: "Marte/Olympus",
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sched, loc, err := parseCronSchedule(tt.schedule, tt.timeZone)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidSchedule) {
+					t.Fatalf("parseCronSchedule() erro = %v, esperado ErrInvalidSchedule", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseCronSchedule() erro inesperado: %v", err)
+			}
+			if next := sched.Next(from.In(loc)); !next.Equal(tt.wantNext) {
+				t.Errorf("próxima execução = %v, esperado %v", next.UTC(), tt.wantNext)
+			}
+		})
+	}
+}
+
+func TestManualJobName(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+
+	tests := []struct {
+		name    string
+		cronJob string
+		want    string
+	}{
+		{name: "nome curto", cronJob: "backup", want: "backup-manual-1700000000"},
+		{name: "nome no limite", cronJob: strings.Repeat("a", 45), want: strings.Repeat("a", 45) + "-manual-1700000000"},
+		{name: "nome truncado", cronJob: strings.Repeat("a", 60), want: strings.Repeat("a", 45) + "-manual-1700000000"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := manualJobName(tt.cronJob, now)
+			if got != tt.want {
+				t.Errorf("manualJobName() = %q, esperado %q", got, tt.want)
+			}
+			if len(got) > 63 {
+				t.Errorf("manualJobName() tem %d caracteres, o limite é 63", len(got))
+			}
+		})
+	}
+}
@@ -0,0 +1,437 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+)
+
+// DaemonSetInfo resume um DaemonSet e o progresso do seu rollout pelos nodes.
+// Status é calculado por daemonSetStatus: Ready, Progressing, Degraded ou NoNodes.
+type DaemonSetInfo struct {
+	Nome                   string              `json:"nome"`
+	Namespace              string              `json:"namespace"`
+	Status                 string              `json:"status"`
+	Image                  string              `json:"image"`
+	DesiredNumberScheduled int32               `json:"desiredNumberScheduled"`
+	CurrentNumberScheduled int32               `json:"currentNumberScheduled"`
+	NumberReady            int32               `json:"numberReady"`
+	UpdatedNumberScheduled int32               `json:"updatedNumberScheduled"`
+	NumberAvailable        int32               `json:"numberAvailable"`
+	NumberUnavailable      int32               `json:"numberUnavailable"`
+	NumberMisscheduled     int32               `json:"numberMisscheduled"`
+	UpdateStrategy         string              `json:"updateStrategy"`
+	MaxUnavailable         string              `json:"maxUnavailable,omitempty"`
+	MaxSurge               string              `json:"maxSurge,omitempty"`
+	NodeSelector           map[string]string   `json:"nodeSelector,omitempty"`
+	Generation             int64               `json:"generation"`
+	ObservedGeneration     int64               `json:"observedGeneration"`
+	Containers             []ContainerSpecInfo `json:"containers"`
+	Selector               map[string]string   `json:"selector"`
+	Labels                 map[string]string   `json:"labels,omitempty"`
+	CreationTimestamp      metav1.Time         `json:"creationTimestamp"`
+	Age                    string              `json:"age"`
+}
+
+// DaemonSetDetail é o modelo completo de um DaemonSet, com a situação de cada node
+// e eventos recentes. NodesWithoutReadyPod lista os nodes elegíveis sem um pod pronto.
+type DaemonSetDetail struct {
+	DaemonSetInfo
+	Annotations          map[string]string `json:"annotations,omitempty"`
+	Nodes                []DaemonSetNode   `json:"nodes"`
+	NodesWithoutReadyPod []string          `json:"nodesWithoutReadyPod"`
+	Events               []EventInfo       `json:"events"`
+}
+
+// DaemonSetNode descreve o pod do DaemonSet em um node. Eligible indica que o node deve
+// rodar o pod (nodeSelector, afinidade e taints); Misscheduled, que há pod em um node
+// não elegível. Updated indica que o pod está na revisão mais recente.
+type DaemonSetNode struct {
+	Node         string   `json:"node"`
+	Eligible     bool     `json:"eligible"`
+	Pod          *PodInfo `json:"pod,omitempty"`
+	Ready        bool     `json:"ready"`
+	Updated      bool     `json:"updated"`
+	Misscheduled bool     `json:"misscheduled"`
+}
+
+// DaemonSetSpec reúne os parâmetros de criação de um DaemonSet. NodeSelector restringe
+// os nodes; TolerateAllTaints inclui nodes com taints (ex.: control plane), comum em
+// agentes de node e coletores de log.
+type DaemonSetSpec struct {
+	Image             string
+	ContainerPort     int32
+	Env               map[string]string
+	NodeSelector      map[string]string
+	TolerateAllTaints bool
+}
+
+// daemonSetTolerations são adicionadas pelo controller a todo pod de DaemonSet, para
+// que ele continue nos nodes com problemas ou marcados como unschedulable.
+var daemonSetTolerations = []v1.Toleration{
+	{Key: v1.TaintNodeNotReady, Operator: v1.TolerationOpExists, Effect: v1.TaintEffectNoExecute},
+	{Key: v1.TaintNodeUnreachable, Operator: v1.TolerationOpExists, Effect: v1.TaintEffectNoExecute},
+	{Key: v1.TaintNodeDiskPressure, Operator: v1.TolerationOpExists, Effect: v1.TaintEffectNoSchedule},
+	{Key: v1.TaintNodeMemoryPressure, Operator: v1.TolerationOpExists, Effect: v1.TaintEffectNoSchedule},
+	{Key: v1.TaintNodePIDPressure, Operator: v1.TolerationOpExists, Effect: v1.TaintEffectNoSchedule},
+	{Key: v1.TaintNodeUnschedulable, Operator: v1.TolerationOpExists, Effect: v1.TaintEffectNoSchedule},
+}
+
+func (d DaemonSetInfo) GetNamespace() string { return d.Namespace }
+func (d DaemonSetInfo) GetName() string      { return d.Nome }
+
+// ListDaemonSets retorna uma página de DaemonSetInfo do namespace informado (ou de todos, com AllNamespaces).
+func ListDaemonSets(namespace string, opts ListOptions) (ListResult[DaemonSetInfo], error) {
+	return listNamespacedPage(namespace, opts, listDaemonSets)
+}
+
+func listDaemonSets(namespace string, listOpts metav1.ListOptions) ([]DaemonSetInfo, metav1.ListMeta, error) {
+	daemonSets, err := client.AppsV1().DaemonSets(namespace).List(context.TODO(), listOpts)
+	if err != nil {
+		return nil, metav1.ListMeta{}, fmt.Errorf("erro ao listar daemonsets: %w", err)
+	}
+
+	infos := make([]DaemonSetInfo, 0, len(daemonSets.Items))
+	for _, ds := range daemonSets.Items {
+		infos = append(infos, newDaemonSetInfo(ds))
+	}
+	return infos, daemonSets.ListMeta, nil
+}
+
+// GetDaemonSet retorna os detalhes de um DaemonSet, com o pod de cada node.
+func GetDaemonSet(namespace, name string) (DaemonSetDetail, error) {
+	ds, err := client.AppsV1().DaemonSets(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+	if err != nil {
+		return DaemonSetDetail{}, fmt.Errorf("daemonset não encontrado: %w", err)
+	}
+
+	detail := DaemonSetDetail{
+		DaemonSetInfo:        newDaemonSetInfo(*ds),
+		Annotations:          withoutLastApplied(ds.Annotations),
+		NodesWithoutReadyPod: []string{},
+	}
+	detail.Nodes, err = daemonSetNodes(ds)
+	if err != nil {
+		return detail, err
+	}
+	for _, node := range detail.Nodes {
+		if node.Eligible && !node.Ready {
+			detail.NodesWithoutReadyPod = append(detail.NodesWithoutReadyPod, node.Node)
+		}
+	}
+
+	detail.Events = detailEvents(namespace, appsv1.SchemeGroupVersion.WithKind("DaemonSet"), ds.Name, ds.UID)
+	return detail, nil
+}
+
+// BuildDaemonSet monta um DaemonSet com um container, rodando em todos os nodes que
+// atendam ao nodeSelector.
+func BuildDaemonSet(namespace, name string, spec DaemonSetSpec) *appsv1.DaemonSet {
+	// BuildDeployment já monta o container com porta e variáveis de ambiente
+	container := BuildDeployment(namespace, name, spec.Image, 1, spec.ContainerPort, spec.Env).Spec.Template.Spec.Containers[0]
+	ds := &appsv1.DaemonSet{
+		TypeMeta: metav1.TypeMeta{APIVersion: "apps/v1", Kind: "DaemonSet"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: appsv1.DaemonSetSpec{
+			Selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"app": name},
+			},
+			Template: v1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{"app": name},
+				},
+				Spec: v1.PodSpec{
+					Containers:   []v1.Container{container},
+					NodeSelector: spec.NodeSelector,
+				},
+			},
+		},
+	}
+	if spec.TolerateAllTaints {
+		ds.Spec.Template.Spec.Tolerations = []v1.Toleration{{Operator: v1.TolerationOpExists}}
+	}
+	return ds
+}
+
+// UpdateDaemonSetImage troca a imagem do container indicado (ou do primeiro); o
+// controller atualiza os pods node a node conforme a estratégia.
+func UpdateDaemonSetImage(namespace, name, container, image string, opts MutateOptions) (*appsv1.DaemonSet, error) {
+	return updateDaemonSet(namespace, name, opts, "erro ao atualizar imagem", func(ds *appsv1.DaemonSet) error {
+		return setContainerImage(&ds.Spec.Template.Spec, container, image)
+	})
+}
+
+// RestartDaemonSet recria os pods, node a node, conforme a estratégia de atualização.
+func RestartDaemonSet(namespace, name string, opts MutateOptions) (*appsv1.DaemonSet, error) {
+	return updateDaemonSet(namespace, name, opts, "erro ao reiniciar daemonset", func(ds *appsv1.DaemonSet) error {
+		if ds.Spec.Template.ObjectMeta.Annotations == nil {
+			ds.Spec.Template.ObjectMeta.Annotations = make(map[string]string)
+		}
+		ds.Spec.Template.ObjectMeta.Annotations[restartedAtAnnotation] = metav1.Now().Format(time.RFC3339)
+		return nil
+	})
+}
+
+// DeleteDaemonSet remove o DaemonSet; o garbage collector remove os pods em seguida.
+func DeleteDaemonSet(name string, namespace string, opts MutateOptions) (*appsv1.DaemonSet, error) {
+	daemonSets := client.AppsV1().DaemonSets(namespace)
+	ds, err := daemonSets.Get(context.Background(), name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("daemonset não encontrado: %w", err)
+	}
+	if err := daemonSets.Delete(context.Background(), name, opts.deleteOptions(ds.UID)); err != nil {
+		return nil, fmt.Errorf("erro ao deletar daemonset: %w", err)
+	}
+	return ds, nil
+}
+
+func newDaemonSetInfo(ds appsv1.DaemonSet) DaemonSetInfo {
+	st := ds.Status
+	info := DaemonSetInfo{
+		Nome:                   ds.Name,
+		Namespace:              ds.Namespace,
+		Status:                 daemonSetStatus(ds),
+		DesiredNumberScheduled: st.DesiredNumberScheduled,
+		CurrentNumberScheduled: st.CurrentNumberScheduled,
+		NumberReady:            st.NumberReady,
+		UpdatedNumberScheduled: st.UpdatedNumberScheduled,
+		NumberAvailable:        st.NumberAvailable,
+		NumberUnavailable:      st.NumberUnavailable,
+		NumberMisscheduled:     st.NumberMisscheduled,
+		UpdateStrategy:         string(ds.Spec.UpdateStrategy.Type),
+		NodeSelector:           ds.Spec.Template.Spec.NodeSelector,
+		Generation:             ds.Generation,
+		ObservedGeneration:     st.ObservedGeneration,
+		Containers:             containerSpecInfos(ds.Spec.Template.Spec.Containers),
+		Labels:                 ds.Labels,
+		CreationTimestamp:      ds.CreationTimestamp,
+		Age:                    age(ds.CreationTimestamp),
+	}
+	if ds.Spec.Selector != nil {
+		info.Selector = ds.Spec.Selector.MatchLabels
+	}
+	if ru := ds.Spec.UpdateStrategy.RollingUpdate; ru != nil {
+		if ru.MaxUnavailable != nil {
+			info.MaxUnavailable = ru.MaxUnavailable.String()
+		}
+		if ru.MaxSurge != nil {
+			info.MaxSurge = ru.MaxSurge.String()
+		}
+	}
+	if len(info.Containers) > 0 {
+		info.Image = info.Containers[0].Image
+	}
+	return info
+}
+
+// daemonSetStatus resume o estado do DaemonSet em um único valor:
+//   - NoNodes: nenhum node atende ao nodeSelector, à afinidade e aos taints
+//   - Progressing: o controller ainda não observou a última geração ou há nodes com pods
+//     fora da revisão mais recente
+//   - Degraded: há nodes sem pod pronto ou pods em nodes onde não deveriam estar
+//   - Ready: cada node elegível tem um pod atualizado e pronto
+func daemonSetStatus(ds appsv1.DaemonSet) string {
+	st := ds.Status
+	if st.ObservedGeneration < ds.Generation {
+		return "Progressing"
+	}
+	if st.DesiredNumberScheduled == 0 {
+		return "NoNodes"
+	}
+	if st.UpdatedNumberScheduled < st.DesiredNumberScheduled {
+		return "Progressing"
+	}
+	if st.NumberReady < st.DesiredNumberScheduled || st.NumberMisscheduled > 0 {
+		return "Degraded"
+	}
+	return "Ready"
+}
+
+// daemonSetNodes cruza os nodes do cluster com os pods do DaemonSet. Entram os nodes
+// elegíveis e os nodes que têm um pod do DaemonSet.
+func daemonSetNodes(ds *appsv1.DaemonSet) ([]DaemonSetNode, error) {
+	nodes, err := client.CoreV1().Nodes().List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("erro ao listar nodes: %w", err)
+	}
+	pods, err := daemonSetPods(ds)
+	if err != nil {
+		return nil, err
+	}
+	updateHash, err := daemonSetUpdateHash(ds)
+	if err != nil {
+		return nil, err
+	}
+
+	podsByNode := map[string]v1.Pod{}
+	for _, pod := range pods {
+		// Com maxSurge há dois pods no node durante a troca; prevalece o mais recente
+		if current, ok := podsByNode[pod.Spec.NodeName]; !ok || current.CreationTimestamp.Before(&pod.CreationTimestamp) {
+			podsByNode[pod.Spec.NodeName] = pod
+		}
+	}
+
+	result := []DaemonSetNode{}
+	for _, node := range nodes.Items {
+		item := DaemonSetNode{Node: node.Name, Eligible: nodeRunsDaemonSet(&ds.Spec.Template.Spec, &node)}
+		pod, hasPod := podsByNode[node.Name]
+		if !item.Eligible && !hasPod {
+			continue
+		}
+		if hasPod {
+			info := newPodInfo(pod)
+			item.Pod = &info
+			item.Ready = info.Ready
+			item.Updated = updateHash != "" && pod.Labels[appsv1.DefaultDaemonSetUniqueLabelKey] == updateHash
+			item.Misscheduled = !item.Eligible
+		}
+		result = append(result, item)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Node < result[j].Node })
+	return result, nil
+}
+
+// daemonSetPods retorna os pods controlados pelo DaemonSet.
+func daemonSetPods(ds *appsv1.DaemonSet) ([]v1.Pod, error) {
+	selector, err := metav1.LabelSelectorAsSelector(ds.Spec.Selector)
+	if err != nil {
+		return nil, fmt.Errorf("seletor inválido: %w", err)
+	}
+	list, err := client.CoreV1().Pods(ds.Namespace).List(context.TODO(), metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return nil, fmt.Errorf("erro ao listar pods: %w", err)
+	}
+
+	var owned []v1.Pod
+	for _, pod := range list.Items {
+		if ref := metav1.GetControllerOfNoCopy(&pod); ref != nil && ref.UID == ds.UID {
+			owned = append(owned, pod)
+		}
+	}
+	return owned, nil
+}
+
+// daemonSetUpdateHash devolve o hash da ControllerRevision mais recente do DaemonSet,
+// o mesmo valor do label controller-revision-hash dos pods atualizados.
+func daemonSetUpdateHash(ds *appsv1.DaemonSet) (string, error) {
+	selector, err := metav1.LabelSelectorAsSelector(ds.Spec.Selector)
+	if err != nil {
+		return "", fmt.Errorf("seletor inválido: %w", err)
+	}
+	list, err := client.AppsV1().ControllerRevisions(ds.Namespace).List(context.TODO(), metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return "", fmt.Errorf("erro ao listar controllerrevisions: %w", err)
+	}
+
+	var latest *appsv1.ControllerRevision
+	for i, revision := range list.Items {
+		if ref := metav1.GetControllerOfNoCopy(&revision); ref == nil || ref.UID != ds.UID {
+			continue
+		}
+		if latest == nil || revision.Revision > latest.Revision {
+			latest = &list.Items[i]
+		}
+	}
+	if latest == nil {
+		return "", nil
+	}
+	if hash := latest.Labels[appsv1.DefaultDaemonSetUniqueLabelKey]; hash != "" {
+		return hash, nil
+	}
+	return strings.TrimPrefix(latest.Name, ds.Name+"-"), nil
+}
+
+// nodeRunsDaemonSet aproxima a decisão do controller: o node precisa atender ao
+// nodeSelector e à afinidade obrigatória, e os taints NoSchedule/NoExecute precisam
+// ser tolerados (incluindo as tolerâncias adicionadas a todo pod de DaemonSet).
+func nodeRunsDaemonSet(spec *v1.PodSpec, node *v1.Node) bool {
+	if !labels.SelectorFromSet(spec.NodeSelector).Matches(labels.Set(node.Labels)) {
+		return false
+	}
+	if affinity := spec.Affinity; affinity != nil && affinity.NodeAffinity != nil {
+		if required := affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution; required != nil && !matchNodeSelectorTerms(required.NodeSelectorTerms, node) {
+			return false
+		}
+	}
+
+	tolerations := append(append([]v1.Toleration{}, spec.Tolerations...), daemonSetTolerations...)
+	for i := range node.Spec.Taints {
+		taint := &node.Spec.Taints[i]
+		if taint.Effect == v1.TaintEffectPreferNoSchedule {
+			continue
+		}
+		tolerated := false
+		for j := range tolerations {
+			if tolerations[j].ToleratesTaint(taint) {
+				tolerated = true
+				break
+			}
+		}
+		if !tolerated {
+			return false
+		}
+	}
+	return true
+}
+
+// matchNodeSelectorTerms avalia termos de afinidade de node: basta um termo ser atendido,
+// e cada termo exige todas as suas expressões.
+func matchNodeSelectorTerms(terms []v1.NodeSelectorTerm, node *v1.Node) bool {
+	for _, term := range terms {
+		if len(term.MatchExpressions) == 0 && len(term.MatchFields) == 0 {
+			continue
+		}
+		if matchNodeRequirements(term.MatchExpressions, labels.Set(node.Labels)) &&
+			matchNodeRequirements(term.MatchFields, labels.Set{"metadata.name": node.Name}) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchNodeRequirements(requirements []v1.NodeSelectorRequirement, set labels.Set) bool {
+	operators := map[v1.NodeSelectorOperator]selection.Operator{
+		v1.NodeSelectorOpIn:           selection.In,
+		v1.NodeSelectorOpNotIn:        selection.NotIn,
+		v1.NodeSelectorOpExists:       selection.Exists,
+		v1.NodeSelectorOpDoesNotExist: selection.DoesNotExist,
+		v1.NodeSelectorOpGt:           selection.GreaterThan,
+		v1.NodeSelectorOpLt:           selection.LessThan,
+	}
+	for _, req := range requirements {
+		requirement, err := labels.NewRequirement(req.Key, operators[req.Operator], req.Values)
+		if err != nil || !requirement.Matches(set) {
+			return false
+		}
+	}
+	return true
+}
+
+// updateDaemonSet lê o DaemonSet, aplica mutate e grava o resultado.
+func updateDaemonSet(namespace, name string, opts MutateOptions, errMsg string, mutate func(*appsv1.DaemonSet) error) (*appsv1.DaemonSet, error) {
+	ds, err := client.AppsV1().DaemonSets(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("daemonset não encontrado: %w", err)
+	}
+
+	if err := mutate(ds); err != nil {
+		return nil, err
+	}
+
+	updated, err := client.AppsV1().DaemonSets(namespace).Update(context.TODO(), ds, opts.updateOptions())
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", errMsg, err)
+	}
+	return updated, nil
+}
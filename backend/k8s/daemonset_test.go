@@ -0,0 +1,232 @@
+package k8s
+
+import (
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestDaemonSetStatus(t *testing.T) {
+	tests := []struct {
+		name string
+		ds   appsv1.DaemonSet
+		want string
+	}{
+		{
+			name: "geração ainda não observada",
+			ds: appsv1.DaemonSet{
+				ObjectMeta: metav1.ObjectMeta{Generation: 2},
+				Status:     appsv1.DaemonSetStatus{ObservedGeneration: 1, DesiredNumberScheduled: 3, UpdatedNumberScheduled: 3, NumberReady: 3},
+			},
+			want: "Progressing",
+		},
+		{
+			name: "nenhum node elegível",
+			ds:   appsv1.DaemonSet{},
+			want: "NoNodes",
+		},
+		{
+			name: "rollout em andamento",
+			ds: appsv1.DaemonSet{
+				Status: appsv1.DaemonSetStatus{DesiredNumberScheduled: 3, UpdatedNumberScheduled: 1, NumberReady: 3},
+			},
+			want: "Progressing",
+		},
+		{
+			name: "pods não prontos",
+			ds: appsv1.DaemonSet{
+				Status: appsv1.DaemonSetStatus{DesiredNumberScheduled: 3, UpdatedNumberScheduled: 3, NumberReady: 2},
+			},
+			want: "Degraded",
+		},
+		{
+			name: "pods fora do lugar",
+			ds: appsv1.DaemonSet{
+				Status: appsv1.DaemonSetStatus{DesiredNumberScheduled: 3, UpdatedNumberScheduled: 3, NumberReady: 3, NumberMisscheduled: 1},
+			},
+			want: "Degraded",
+		},
+		{
+			name: "pronto",
+			ds: appsv1.DaemonSet{
+				Status: appsv1.DaemonSetStatus{DesiredNumberScheduled: 3, UpdatedNumberScheduled: 3, NumberReady: 3},
+			},
+			want: "Ready",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := daemonSetStatus(tt.ds); got != tt.want {
+				t.Errorf("daemonSetStatus() = %q, esperado %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNodeRunsDaemonSet(t *testing.T) {
+	node := func(labels map[string]string, taints ...v1.Taint) *v1.Node {
+		return &v1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: "node-1", Labels: labels},
+			Spec:       v1.NodeSpec{Taints: taints},
+		}
+	}
+	linux := map[string]string{"kubernetes.io/os": "linux"}
+	gpuTaint := v1.Taint{Key: "gpu", Value: "true", Effect: v1.TaintEffectNoSchedule}
+
+	tests := []struct {
+		name string
+		spec v1.PodSpec
+		node *v1.Node
+		want bool
+	}{
+		{
+			name: "sem restrições",
+			node: node(nil),
+			want: true,
+		},
+		{
+			name: "nodeSelector atendido",
+			spec: v1.PodSpec{NodeSelector: linux},
+			node: node(linux),
+			want: true,
+		},
+		{
+			name: "nodeSelector não atendido",
+			spec: v1.PodSpec{NodeSelector: linux},
+			node: node(map[string]string{"kubernetes.io/os": "windows"}),
+			want: false,
+		},
+		{
+			name: "afinidade obrigatória não atendida",
+			spec: v1.PodSpec{Affinity: &v1.Affinity{NodeAffinity: &v1.NodeAffinity{
+				RequiredDuringSchedulingIgnoredDuringExecution: &v1.NodeSelector{NodeSelectorTerms: []v1.NodeSelectorTerm{{
+					MatchExpressions: []v1.NodeSelectorRequirement{{Key: "zone", Operator: v1.NodeSelectorOpIn, Values: []string{"a"}}},
+				}}},
+			}}},
+			node: node(map[string]string{"zone": "b"}),
+			want: false,
+		},
+		{
+			name: "taint sem toleration",
+			node: node(nil, gpuTaint),
+			want: false,
+		},
+		{
+			name: "taint com toleration",
+			spec: v1.PodSpec{Tolerations: []v1.Toleration{{Key: "gpu", Operator: v1.TolerationOpExists}}},
+			node: node(nil, gpuTaint),
+			want: true,
+		},
+		{
+			name: "taint PreferNoSchedule é ignorado",
+			node: node(nil, v1.Taint{Key: "gpu", Effect: v1.TaintEffectPreferNoSchedule}),
+			want: true,
+		},
+		{
+			name: "node unschedulable tolerado pelo controller",
+			node: node(nil, v1.Taint{Key: v1.TaintNodeUnschedulable, Effect: v1.TaintEffectNoSchedule}),
+			want: true,
+		},
+		{
+			name: "node not-ready tolerado pelo controller",
+			node: node(nil, v1.Taint{Key: v1.TaintNodeNotReady, Effect: v1.TaintEffectNoExecute}),
+			want: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := nodeRunsDaemonSet(&tt.spec, tt.node); got != tt.want {
+				t.Errorf("nodeRunsDaemonSet() = %v, esperado %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatchNodeSelectorTerms(t *testing.T) {
+	node := &v1.Node{ObjectMeta: metav1.ObjectMeta{
+		Name:   "node-1",
+		Labels: map[string]string{"zone": "a", "cpus": "8"},
+	}}
+	expr := func(key string, op v1.NodeSelectorOperator, values ...string) v1.NodeSelectorRequirement {
+		return v1.NodeSelectorRequirement{Key: key, Operator: op, Values: values}
+	}
+
+	tests := []struct {
+		name  string
+		terms []v1.NodeSelectorTerm
+		want  bool
+	}{
+		{
+			name: "sem termos",
+			want: false,
+		},
+		{
+			name:  "termo vazio não seleciona nada",
+			terms: []v1.NodeSelectorTerm{{}},
+			want:  false,
+		},
+		{
+			name:  "In atendido",
+			terms: []v1.NodeSelectorTerm{{MatchExpressions: []v1.NodeSelectorRequirement{expr("zone", v1.NodeSelectorOpIn, "a", "b")}}},
+			want:  true,
+		},
+		{
+			name:  "NotIn não atendido",
+			terms: []v1.NodeSelectorTerm{{MatchExpressions: []v1.NodeSelectorRequirement{expr("zone", v1.NodeSelectorOpNotIn, "a")}}},
+			want:  false,
+		},
+		{
+			name:  "Exists e DoesNotExist",
+			terms: []v1.NodeSelectorTerm{{MatchExpressions: []v1.NodeSelectorRequirement{expr("zone", v1.NodeSelectorOpExists), expr("gpu", v1.NodeSelectorOpDoesNotExist)}}},
+			want:  true,
+		},
+		{
+			name:  "Gt numérico",
+			terms: []v1.NodeSelectorTerm{{MatchExpressions: []v1.NodeSelectorRequirement{expr("cpus", v1.NodeSelectorOpGt, "4")}}},
+			want:  true,
+		},
+		{
+			name:  "Lt numérico não atendido",
+			terms: []v1.NodeSelectorTerm{{MatchExpressions: []v1.NodeSelectorRequirement{expr("cpus", v1.NodeSelectorOpLt, "4")}}},
+			want:  false,
+		},
+		{
+			name: "todas as expressões do termo são exigidas",
+			terms: []v1.NodeSelectorTerm{{MatchExpressions: []v1.NodeSelectorRequirement{
+				expr("zone", v1.NodeSelectorOpIn, "a"),
+				expr("cpus", v1.NodeSelectorOpIn, "16"),
+			}}},
+			want: false,
+		},
+		{
+			name: "basta um termo ser atendido",
+			terms: []v1.NodeSelectorTerm{
+				{MatchExpressions: []v1.NodeSelectorRequirement{expr("zone", v1.NodeSelectorOpIn, "b")}},
+				{MatchExpressions: []v1.NodeSelectorRequirement{expr("zone", v1.NodeSelectorOpIn, "a")}},
+			},
+			want: true,
+		},
+		{
+			name:  "matchFields pelo nome do node",
+			terms: []v1.NodeSelectorTerm{{MatchFields: []v1.NodeSelectorRequirement{expr("metadata.name", v1.NodeSelectorOpIn, "node-1")}}},
+			want:  true,
+		},
+		{
+			name:  "matchFields de outro node",
+			terms: []v1.NodeSelectorTerm{{MatchFields: []v1.NodeSelectorRequirement{expr("metadata.name", v1.NodeSelectorOpIn, "node-2")}}},
+			want:  false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := matchNodeSelectorTerms(tt.terms, node); got != tt.want {
+				t.Errorf("matchNodeSelectorTerms() = %v, esperado %v", got, tt.want)
+			}
+		})
+	}
+}
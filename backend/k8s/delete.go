@@ -2,36 +2,57 @@ package k8s
 
 import (
 	"context"
+	"fmt"
 
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
-func DeletePod(name string, namespace string) error {
+// As funções de exclusão devolvem o objeto removido (ou que seria removido, em dry-run).
 
-	deleteErr := client.CoreV1().Pods(namespace).Delete(context.Background(), name, metav1.DeleteOptions{})
-	if deleteErr != nil {
-		panic(deleteErr.Error())
+func DeletePod(name string, namespace string, opts MutateOptions) (*v1.Pod, error) {
+	pods := client.CoreV1().Pods(namespace)
+	pod, err := pods.Get(context.Background(), name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("pod não encontrado: %w", err)
 	}
-	return nil
+	if err := pods.Delete(context.Background(), name, opts.deleteOptions(pod.UID)); err != nil {
+		return nil, fmt.Errorf("erro ao deletar pod: %w", err)
+	}
+	return pod, nil
 }
-func DeleteDeployment(name string, namespace string) error {
-	deleteErr := client.AppsV1().Deployments(namespace).Delete(context.Background(), name, metav1.DeleteOptions{})
-	if deleteErr != nil {
-		panic(deleteErr.Error())
+func DeleteDeployment(name string, namespace string, opts MutateOptions) (*appsv1.Deployment, error) {
+	deployments := client.AppsV1().Deployments(namespace)
+	deployment, err := deployments.Get(context.Background(), name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("deployment não encontrado: %w", err)
+	}
+	if err := deployments.Delete(context.Background(), name, opts.deleteOptions(deployment.UID)); err != nil {
+		return nil, fmt.Errorf("erro ao deletar deployment: %w", err)
 	}
-	return nil
+	return deployment, nil
 }
-func DeleteService(name string, namespace string) error {
-	deleteErr := client.CoreV1().Services(namespace).Delete(context.Background(), name, metav1.DeleteOptions{})
-	if deleteErr != nil {
-		panic(deleteErr.Error())
+func DeleteService(name string, namespace string, opts MutateOptions) (*v1.Service, error) {
+	services := client.CoreV1().Services(namespace)
+	service, err := services.Get(context.Background(), name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("service não encontrado: %w", err)
 	}
-	return nil
+	if err := services.Delete(context.Background(), name, opts.deleteOptions(service.UID)); err != nil {
+		return nil, fmt.Errorf("erro ao deletar service: %w", err)
+	}
+	return service, nil
 }
-func DeleteSecret(name string, namespace string) error {
-	deleteErr := client.CoreV1().Secrets(namespace).Delete(context.Background(), name, metav1.DeleteOptions{})
-	if deleteErr != nil {
-		panic(deleteErr.Error())
+func DeleteSecret(name string, namespace string, opts MutateOptions) (*v1.Secret, error) {
+	secrets := client.CoreV1().Secrets(namespace)
+	secret, err := secrets.Get(context.Background(), name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("secret não encontrado: %w", err)
+	}
+	if err := secrets.Delete(context.Background(), name, opts.deleteOptions(secret.UID)); err != nil {
+		return nil, fmt.Errorf("erro ao deletar secret: %w", err)
 	}
-	return nil
+	// Os valores não saem do cluster na resposta
+	return withoutSecretValues(secret), nil
 }
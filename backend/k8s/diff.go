@@ -0,0 +1,312 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/yaml"
+)
+
+// Tipos de alteração de um campo no diff estruturado.
+const (
+	ChangeAdded   = "added"
+	ChangeRemoved = "removed"
+	ChangeChanged = "changed"
+)
+
+// serverManagedFields são removidos antes da comparação: o servidor os altera
+// a cada gravação e eles não fazem parte do que o usuário edita.
+var serverManagedFields = [][]string{
+	{"status"},
+	{"metadata", "managedFields"},
+	{"metadata", "resourceVersion"},
+	{"metadata", "generation"},
+	{"metadata", "uid"},
+	{"metadata", "creationTimestamp"},
+	{"metadata", "selfLink"},
+}
+
+// FieldChange é uma diferença em um campo, identificado pelo caminho (ex.: spec.replicas).
+type FieldChange struct {
+	Path string `json:"path"`
+	Type string `json:"type"`
+	Old  any    `json:"old,omitempty"`
+	New  any    `json:"new,omitempty"`
+}
+
+// DiffResult compara o objeto do cluster com o resultado da alteração. Exists é false
+// quando o objeto ainda não existe e seria criado.
+type DiffResult struct {
+	APIVersion string        `json:"apiVersion"`
+	Kind       string        `json:"kind"`
+	Namespace  string        `json:"namespace,omitempty"`
+	Name       string        `json:"name"`
+	Exists     bool          `json:"exists"`
+	Changes    []FieldChange `json:"changes"`
+	Diff       string        `json:"diff"`
+	Error      string        `json:"error,omitempty"`
+}
+
+// DiffManifests calcula, para cada objeto do manifesto, o que /apply mudaria. O resultado
+// vem de um server-side apply em dry-run, então inclui defaults e mutações dos webhooks.
+func DiffManifests(ctx context.Context, manifest io.Reader, opts ApplyOptions) ([]DiffResult, error) {
+	objects, err := decodeManifests(manifest)
+	if err != nil {
+		return nil, err
+	}
+	if len(objects) == 0 {
+		return nil, fmt.Errorf("%w: nenhum objeto encontrado", ErrInvalidManifest)
+	}
+	if opts.Namespace == "" {
+		opts.Namespace = metav1.NamespaceDefault
+	}
+	opts.DryRun = true
+
+	results := make([]DiffResult, 0, len(objects))
+	for _, obj := range objects {
+		result := DiffResult{APIVersion: obj.GetAPIVersion(), Kind: obj.GetKind(), Namespace: obj.GetNamespace(), Name: obj.GetName()}
+		live, merged, err := applyLive(ctx, obj, opts)
+		if err != nil {
+			result.Error = err.Error()
+		} else {
+			result = newDiffResult(live, merged)
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// DiffObject compara o objeto do cluster com obj, que o substituiria por inteiro
+// (ex.: um CreateResourceRequest editado). Se o objeto não existe, o diff mostra a criação.
+func DiffObject(ctx context.Context, obj runtime.Object) (DiffResult, error) {
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return DiffResult{}, fmt.Errorf("erro ao converter objeto: %w", err)
+	}
+	desired := &unstructured.Unstructured{Object: content}
+
+	resource, err := resourceFor(desired)
+	if err != nil {
+		return DiffResult{}, err
+	}
+	resourceClient := resource.client(desired.GetNamespace())
+	dryRun := MutateOptions{DryRun: true}
+
+	live, err := resourceClient.Get(ctx, desired.GetName(), metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		merged, err := resourceClient.Create(ctx, desired, dryRun.createOptions())
+		if err != nil {
+			return DiffResult{}, err
+		}
+		return newDiffResult(nil, merged), nil
+	}
+	if err != nil {
+		return DiffResult{}, err
+	}
+
+	desired.SetResourceVersion(live.GetResourceVersion())
+	merged, err := resourceClient.Update(ctx, desired, dryRun.updateOptions())
+	if err != nil {
+		return DiffResult{}, err
+	}
+	return newDiffResult(live, merged), nil
+}
+
+// DiffDeploymentUpdate mostra o que UpdateDeployment mudaria no deployment.
+func DiffDeploymentUpdate(ctx context.Context, namespace, name string, update DeploymentUpdate) (DiffResult, error) {
+	live, err := client.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return DiffResult{}, fmt.Errorf("deployment não encontrado: %w", err)
+	}
+	updated, err := UpdateDeployment(namespace, name, update, MutateOptions{DryRun: true})
+	if err != nil {
+		return DiffResult{}, err
+	}
+
+	// Os objetos tipados não trazem apiVersion e kind
+	live.APIVersion, live.Kind = "apps/v1", "Deployment"
+	updated.APIVersion, updated.Kind = "apps/v1", "Deployment"
+	liveContent, err := runtime.DefaultUnstructuredConverter.ToUnstructured(live)
+	if err != nil {
+		return DiffResult{}, err
+	}
+	updatedContent, err := runtime.DefaultUnstructuredConverter.ToUnstructured(updated)
+	if err != nil {
+		return DiffResult{}, err
+	}
+	return newDiffResult(&unstructured.Unstructured{Object: liveContent}, &unstructured.Unstructured{Object: updatedContent}), nil
+}
+
+func newDiffResult(live, merged *unstructured.Unstructured) DiffResult {
+	result := DiffResult{
+		APIVersion: merged.GetAPIVersion(),
+		Kind:       merged.GetKind(),
+		Namespace:  merged.GetNamespace(),
+		Name:       merged.GetName(),
+		Exists:     live != nil,
+	}
+
+	var liveClean *unstructured.Unstructured
+	if live != nil {
+		liveClean = withoutServerFields(live)
+	}
+	mergedClean := withoutServerFields(merged)
+	if isSecret(merged) {
+		redactSecretDiff(liveClean, mergedClean)
+	}
+
+	var before map[string]any
+	if liveClean != nil {
+		before = liveClean.Object
+	}
+	after := mergedClean.Object
+
+	result.Changes = diffValues("", before, after, nil)
+	if result.Changes == nil {
+		result.Changes = []FieldChange{}
+	}
+
+	beforeName, afterName := "/dev/null", "merged/"+objectPath(merged)
+	if live != nil {
+		beforeName = "live/" + objectPath(live)
+	}
+	diff, err := unifiedDiff(before, after, beforeName, afterName)
+	if err != nil {
+		result.Error = err.Error()
+	}
+	result.Diff = diff
+	return result
+}
+
+// withoutServerFields devolve uma cópia do objeto sem os campos gerenciados pelo servidor.
+func withoutServerFields(obj *unstructured.Unstructured) *unstructured.Unstructured {
+	clean := obj.DeepCopy()
+	for _, field := range serverManagedFields {
+		unstructured.RemoveNestedField(clean.Object, field...)
+	}
+	return clean
+}
+
+// secretChangedValue substitui, no lado novo do diff, o valor de uma chave alterada do Secret.
+const secretChangedValue = "(valor alterado)"
+
+// redactSecretDiff remove os valores do Secret dos dois lados do diff. As chaves com valor
+// alterado continuam aparecendo como alteradas, sem que os valores sejam expostos.
+func redactSecretDiff(live, merged *unstructured.Unstructured) {
+	var changed []string
+	if live != nil {
+		before, _, _ := unstructured.NestedMap(live.Object, "data")
+		after, _, _ := unstructured.NestedMap(merged.Object, "data")
+		for key, value := range after {
+			if old, ok := before[key]; ok && !reflect.DeepEqual(old, value) {
+				changed = append(changed, key)
+			}
+		}
+		redactSecret(live)
+	}
+	redactSecret(merged)
+
+	for _, key := range changed {
+		unstructured.SetNestedField(merged.Object, secretChangedValue, "data", key)
+	}
+}
+
+func objectPath(obj *unstructured.Unstructured) string {
+	parts := []string{strings.ToLower(obj.GetKind())}
+	if obj.GetNamespace() != "" {
+		parts = append(parts, obj.GetNamespace())
+	}
+	return strings.Join(append(parts, obj.GetName()), "/")
+}
+
+// diffValues compara recursivamente dois valores JSON. Mapas são comparados chave a chave
+// e listas de mesmo tamanho item a item; listas de tamanhos diferentes aparecem inteiras.
+func diffValues(path string, before, after any, changes []FieldChange) []FieldChange {
+	if reflect.DeepEqual(before, after) {
+		return changes
+	}
+	if before == nil {
+		return append(changes, FieldChange{Path: path, Type: ChangeAdded, New: after})
+	}
+	if after == nil {
+		return append(changes, FieldChange{Path: path, Type: ChangeRemoved, Old: before})
+	}
+
+	switch b := before.(type) {
+	case map[string]any:
+		a, ok := after.(map[string]any)
+		if !ok {
+			break
+		}
+		keys := make([]string, 0, len(a)+len(b))
+		for key := range b {
+			keys = append(keys, key)
+		}
+		for key := range a {
+			if _, ok := b[key]; !ok {
+				keys = append(keys, key)
+			}
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			changes = diffValues(joinPath(path, key), b[key], a[key], changes)
+		}
+		return changes
+	case []any:
+		a, ok := after.([]any)
+		if !ok || len(a) != len(b) {
+			break
+		}
+		for i := range b {
+			changes = diffValues(fmt.Sprintf("%s[%d]", path, i), b[i], a[i], changes)
+		}
+		return changes
+	}
+	return append(changes, FieldChange{Path: path, Type: ChangeChanged, Old: before, New: after})
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+// unifiedDiff gera o diff em texto, no formato do diff -u, entre as versões em YAML.
+func unifiedDiff(before, after map[string]any, beforeName, afterName string) (string, error) {
+	toLines := func(content map[string]any) ([]string, error) {
+		if content == nil {
+			return nil, nil
+		}
+		data, err := yaml.Marshal(content)
+		if err != nil {
+			return nil, err
+		}
+		return difflib.SplitLines(string(data)), nil
+	}
+
+	a, err := toLines(before)
+	if err != nil {
+		return "", err
+	}
+	b, err := toLines(after)
+	if err != nil {
+		return "", err
+	}
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        a,
+		B:        b,
+		FromFile: beforeName,
+		ToFile:   afterName,
+		Context:  3,
+	})
+}
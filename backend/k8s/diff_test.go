@@ -0,0 +1,167 @@
+package k8s
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestNewDiffResultSecret(t *testing.T) {
+	const (
+		oldPassword = "b2xkLXBhc3N3b3Jk" // old-password
+		newPassword = "bmV3LXBhc3N3b3Jk" // new-password
+		user        = "YWRtaW4="         // admin
+		newToken    = "dG9rZW4tc2VjcmV0" // token-secret
+		removed     = "cmVtb3ZpZG8="     // removido
+	)
+	secret := func(data map[string]any, annotations map[string]any) *unstructured.Unstructured {
+		meta := map[string]any{"name": "db", "namespace": "default", "resourceVersion": "7"}
+		if annotations != nil {
+			meta["annotations"] = annotations
+		}
+		return &unstructured.Unstructured{Object: map[string]any{
+			"apiVersion": "v1",
+			"kind":       "Secret",
+			"metadata":   meta,
+			"type":       "Opaque",
+			"data":       data,
+		}}
+	}
+
+	tests := []struct {
+		name        string
+		live        *unstructured.Unstructured
+		merged      *unstructured.Unstructured
+		wantChanges map[string]string
+		leaked      []string
+	}{
+		{
+			name:        "secret novo",
+			merged:      secret(map[string]any{"password": newPassword, "user": user}, nil),
+			wantChanges: map[string]string{"apiVersion": ChangeAdded, "kind": ChangeAdded, "metadata": ChangeAdded, "type": ChangeAdded, "data": ChangeAdded},
+			leaked:      []string{newPassword, user},
+		},
+		{
+			name:        "valor alterado aparece sem os valores",
+			live:        secret(map[string]any{"password": oldPassword, "user": user}, nil),
+			merged:      secret(map[string]any{"password": newPassword, "user": user}, nil),
+			wantChanges: map[string]string{"data.password": ChangeChanged},
+			leaked:      []string{oldPassword, newPassword, user},
+		},
+		{
+			name:        "chaves adicionadas e removidas",
+			live:        secret(map[string]any{"user": user, "old": removed}, nil),
+			merged:      secret(map[string]any{"user": user, "token": newToken}, nil),
+			wantChanges: map[string]string{"data.old": ChangeRemoved, "data.token": ChangeAdded},
+			leaked:      []string{user, removed, newToken},
+		},
+		{
+			name:        "sem alterações",
+			live:        secret(map[string]any{"password": oldPassword}, nil),
+			merged:      secret(map[string]any{"password": oldPassword}, nil),
+			wantChanges: map[string]string{},
+			leaked:      []string{oldPassword},
+		},
+		{
+			name: "anotação do kubectl apply com os valores",
+			live: secret(map[string]any{"password": oldPassword}, map[string]any{
+				v1.LastAppliedConfigAnnotation: `{"data":{"password":"` + oldPassword + `"}}`,
+			}),
+			merged: secret(map[string]any{"password": newPassword}, map[string]any{
+				v1.LastAppliedConfigAnnotation: `{"data":{"password":"` + newPassword + `"}}`,
+			}),
+			wantChanges: map[string]string{"data.password": ChangeChanged},
+			leaked:      []string{oldPassword, newPassword},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := newDiffResult(tt.live, tt.merged)
+
+			raw, err := json.Marshal(result)
+			if err != nil {
+				t.Fatalf("json.Marshal() erro inesperado: %v", err)
+			}
+			for _, value := range tt.leaked {
+				if strings.Contains(string(raw), value) {
+					t.Errorf("o diff expõe o valor %q:\n%s", value, raw)
+				}
+			}
+
+			got := map[string]string{}
+			for _, change := range result.Changes {
+				got[change.Path] = change.Type
+				if change.Type == ChangeChanged && strings.HasPrefix(change.Path, "data.") && change.New != secretChangedValue {
+					t.Errorf("%s: novo valor = %v, esperado %q", change.Path, change.New, secretChangedValue)
+				}
+			}
+			if len(got) != len(tt.wantChanges) {
+				t.Fatalf("changes = %v, esperado %v", got, tt.wantChanges)
+			}
+			for path, changeType := range tt.wantChanges {
+				if got[path] != changeType {
+					t.Errorf("changes[%q] = %q, esperado %q", path, got[path], changeType)
+				}
+			}
+		})
+	}
+}
+
+func TestNewDiffResultKeepsOtherValues(t *testing.T) {
+	configMap := func(value string) *unstructured.Unstructured {
+		return &unstructured.Unstructured{Object: map[string]any{
+			"apiVersion": "v1",
+			"kind":       "ConfigMap",
+			"metadata":   map[string]any{"name": "app", "namespace": "default"},
+			"data":       map[string]any{"LOG_LEVEL": value},
+		}}
+	}
+
+	result := newDiffResult(configMap("info"), configMap("debug"))
+	if len(result.Changes) != 1 {
+		t.Fatalf("changes = %+v, esperado uma alteração", result.Changes)
+	}
+	change := result.Changes[0]
+	if change.Path != "data.LOG_LEVEL" || change.Old != "info" || change.New != "debug" {
+		t.Errorf("change = %+v, esperado data.LOG_LEVEL de info para debug", change)
+	}
+	if !strings.Contains(result.Diff, "debug") {
+		t.Errorf("diff não mostra o novo valor:\n%s", result.Diff)
+	}
+}
+
+func TestRedactSecretDiff(t *testing.T) {
+	live := &unstructured.Unstructured{Object: map[string]any{
+		"metadata": map[string]any{"annotations": map[string]any{v1.LastAppliedConfigAnnotation: "{}", "team": "db"}},
+		"data":     map[string]any{"a": "MQ==", "b": "Mg==", "c": "Mw=="},
+	}}
+	merged := &unstructured.Unstructured{Object: map[string]any{
+		"metadata":   map[string]any{"annotations": map[string]any{v1.LastAppliedConfigAnnotation: "{}", "team": "db"}},
+		"data":       map[string]any{"a": "MQ==", "b": "MjI=", "d": "NA=="},
+		"stringData": map[string]any{"e": "5"},
+	}}
+	redactSecretDiff(live, merged)
+
+	wantLive := map[string]any{"a": "", "b": "", "c": ""}
+	wantMerged := map[string]any{"a": "", "b": secretChangedValue, "d": ""}
+	if data, _, _ := unstructured.NestedMap(live.Object, "data"); !reflect.DeepEqual(data, wantLive) {
+		t.Errorf("live.data = %v, esperado %v", data, wantLive)
+	}
+	if data, _, _ := unstructured.NestedMap(merged.Object, "data"); !reflect.DeepEqual(data, wantMerged) {
+		t.Errorf("merged.data = %v, esperado %v", data, wantMerged)
+	}
+	if _, found, _ := unstructured.NestedFieldNoCopy(merged.Object, "stringData"); found {
+		t.Errorf("stringData deveria ser removido")
+	}
+	for _, obj := range []*unstructured.Unstructured{live, merged} {
+		annotations := obj.GetAnnotations()
+		if _, ok := annotations[v1.LastAppliedConfigAnnotation]; ok || annotations["team"] != "db" {
+			t.Errorf("anotações = %v, esperado só a anotação team", annotations)
+		}
+	}
+}
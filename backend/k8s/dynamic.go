@@ -0,0 +1,70 @@
+package k8s
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/restmapper"
+)
+
+// ErrUnknownKind indica um tipo de recurso que o cluster não conhece.
+var ErrUnknownKind = errors.New("tipo de recurso desconhecido")
+
+// dynamicResource é um tipo da API resolvido pelo RESTMapper.
+type dynamicResource struct {
+	gvr        schema.GroupVersionResource
+	namespaced bool
+}
+
+// client devolve o cliente dinâmico do recurso, restrito ao namespace quando o recurso é namespaced.
+func (r dynamicResource) client(namespace string) dynamic.ResourceInterface {
+	if !r.namespaced {
+		return dynamicClient.Resource(r.gvr)
+	}
+	return dynamicClient.Resource(r.gvr).Namespace(namespace)
+}
+
+// resourceForName resolve um recurso pelo nome usado nas rotas: singular, plural ou
+// abreviação do kubectl (ex.: deployment, deployments, deploy).
+func resourceForName(name string) (dynamicResource, schema.GroupVersionKind, error) {
+	mapper := restmapper.NewShortcutExpander(restMapper, cachedDiscovery, nil)
+	gvk, err := mapper.KindFor(schema.GroupVersionResource{Resource: strings.ToLower(name)})
+	if meta.IsNoMatchError(err) {
+		restMapper.Reset()
+		gvk, err = mapper.KindFor(schema.GroupVersionResource{Resource: strings.ToLower(name)})
+	}
+	if err != nil {
+		return dynamicResource{}, schema.GroupVersionKind{}, fmt.Errorf("%w: tipo '%s' não encontrado no cluster", ErrUnknownKind, name)
+	}
+
+	resource, err := resourceForKind(gvk)
+	return resource, gvk, err
+}
+
+// resourceFor resolve o recurso da API de um objeto a partir do apiVersion e kind.
+func resourceFor(obj *unstructured.Unstructured) (dynamicResource, error) {
+	return resourceForKind(obj.GroupVersionKind())
+}
+
+// resourceForKind resolve o recurso de um GroupVersionKind. Se o tipo não for conhecido,
+// refaz a descoberta uma vez: ele pode ter sido criado agora (ex.: uma CRD no mesmo manifesto).
+func resourceForKind(gvk schema.GroupVersionKind) (dynamicResource, error) {
+	mapping, err := restMapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if meta.IsNoMatchError(err) {
+		restMapper.Reset()
+		mapping, err = restMapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	}
+	if err != nil {
+		return dynamicResource{}, fmt.Errorf("tipo %s não encontrado no cluster: %w", gvk, err)
+	}
+
+	return dynamicResource{
+		gvr:        mapping.Resource,
+		namespaced: mapping.Scope.Name() == meta.RESTScopeNameNamespace,
+	}, nil
+}
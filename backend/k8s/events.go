@@ -0,0 +1,254 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"slices"
+	"sort"
+	"strings"
+
+	eventsv1 "k8s.io/api/events/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/tools/events"
+)
+
+// recentEventsLimit é a quantidade de eventos incluída nos detalhes de um objeto.
+const recentEventsLimit = 20
+
+// EventInfo resume um evento do Kubernetes (events.k8s.io/v1).
+// LastTimestamp é o momento da última ocorrência, considerando eventos repetidos (Series).
+type EventInfo struct {
+	Nome                string      `json:"nome"`
+	Namespace           string      `json:"namespace"`
+	Type                string      `json:"type"`
+	Reason              string      `json:"reason"`
+	Note                string      `json:"note"`
+	Action              string      `json:"action,omitempty"`
+	Regarding           ObjectRef   `json:"regarding"`
+	ReportingController string      `json:"reportingController,omitempty"`
+	Count               int32       `json:"count"`
+	FirstTimestamp      metav1.Time `json:"firstTimestamp"`
+	LastTimestamp       metav1.Time `json:"lastTimestamp"`
+	Age                 string      `json:"age"`
+}
+
+// EventFilter restringe os eventos por tipo (Normal/Warning), motivo e objeto envolvido.
+// Campos vazios não filtram.
+type EventFilter struct {
+	Type   string
+	Reason string
+	Kind   string
+	Name   string
+}
+
+// eventKinds traduz os nomes de recurso usados nas rotas para o Kind dos eventos.
+var eventKinds = map[string]string{
+	"pod":         "Pod",
+	"deployment":  "Deployment",
+	"replicaset":  "ReplicaSet",
+	"service":     "Service",
+	"statefulset": "StatefulSet",
+	"daemonset":   "DaemonSet",
+	"job":         "Job",
+	"cronjob":     "CronJob",
+	"configmap":   "ConfigMap",
+	"secret":      "Secret",
+	"pvc":         "PersistentVolumeClaim",
+	"hpa":         "HorizontalPodAutoscaler",
+	"ingress":     "Ingress",
+	"node":        "Node",
+	"namespace":   "Namespace",
+}
+
+// fieldSelector monta o seletor de campos suportado por events.k8s.io/v1.
+func (f EventFilter) fieldSelector() string {
+	set := fields.Set{}
+	if f.Type != "" {
+		set["type"] = f.Type
+	}
+	if f.Reason != "" {
+		set["reason"] = f.Reason
+	}
+	if f.Kind != "" {
+		kind := f.Kind
+		if mapped, ok := eventKinds[strings.ToLower(kind)]; ok {
+			kind = mapped
+		}
+		set["regarding.kind"] = kind
+	}
+	if f.Name != "" {
+		set["regarding.name"] = f.Name
+	}
+	return set.AsSelector().String()
+}
+
+// Implementação de Namespaced usada na listagem de todos os namespaces.
+func (e EventInfo) GetNamespace() string { return e.Namespace }
+func (e EventInfo) GetName() string      { return e.Nome }
+
+// ListEvents retorna uma página de eventos do namespace informado (ou de todos, com AllNamespaces).
+func ListEvents(namespace string, opts ListOptions, filter EventFilter) (ListResult[EventInfo], error) {
+	return listNamespacedPage(namespace, opts, func(namespace string, listOpts metav1.ListOptions) ([]EventInfo, metav1.ListMeta, error) {
+		listOpts.FieldSelector = filter.fieldSelector()
+		list, err := client.EventsV1().Events(namespace).List(context.TODO(), listOpts)
+		if err != nil {
+			return nil, metav1.ListMeta{}, fmt.Errorf("erro ao listar eventos: %w", err)
+		}
+
+		infos := newEventInfos(list.Items)
+		return infos, list.ListMeta, nil
+	})
+}
+
+// ObjectEvents retorna a linha do tempo de eventos de um objeto, do mais recente
+// para o mais antigo. filter.Kind e filter.Name identificam o objeto.
+func ObjectEvents(namespace string, filter EventFilter) ([]EventInfo, error) {
+	list, err := client.EventsV1().Events(namespace).List(context.TODO(), metav1.ListOptions{FieldSelector: filter.fieldSelector()})
+	if err != nil {
+		return nil, fmt.Errorf("erro ao listar eventos: %w", err)
+	}
+
+	infos := newEventInfos(list.Items)
+	sortEventsByLastSeen(infos)
+	return infos, nil
+}
+
+// WatchEvents envia os eventos atuais e cada novo evento ou atualização que passe pelo
+// filtro, até ctx ser cancelado ou send falhar.
+func WatchEvents(ctx context.Context, namespace string, filter EventFilter, send func(WatchEvent[EventInfo]) error) error {
+	if namespace == AllNamespaces {
+		namespace = metav1.NamespaceAll
+	}
+	selector := filter.fieldSelector()
+
+	return watchList(ctx,
+		func(ctx context.Context) ([]EventInfo, string, error) {
+			list, err := client.EventsV1().Events(namespace).List(ctx, metav1.ListOptions{FieldSelector: selector})
+			if err != nil {
+				return nil, "", fmt.Errorf("erro ao listar eventos: %w", err)
+			}
+			infos := newEventInfos(list.Items)
+			sortEventsByLastSeen(infos)
+			// O estado inicial vai do mais antigo para o mais recente, como uma linha do tempo
+			slices.Reverse(infos)
+			return infos, list.ResourceVersion, nil
+		},
+		func(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error) {
+			opts.FieldSelector = selector
+			return client.EventsV1().Events(namespace).Watch(ctx, opts)
+		},
+		func(obj runtime.Object) (EventInfo, bool) {
+			event, ok := obj.(*eventsv1.Event)
+			if !ok {
+				return EventInfo{}, false
+			}
+			return newEventInfo(*event), true
+		},
+		send,
+	)
+}
+
+// ObjectRef identifica o objeto ao qual um evento se refere.
+type ObjectRef struct {
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+}
+
+// newEventInfo converte um evento da API no modelo exposto pelo backend.
+// Eventos criados pela API antiga (core/v1) só preenchem os campos Deprecated*.
+func newEventInfo(event eventsv1.Event) EventInfo {
+	info := EventInfo{
+		Nome:                event.Name,
+		Namespace:           event.Namespace,
+		Type:                event.Type,
+		Reason:              event.Reason,
+		Note:                event.Note,
+		Action:              event.Action,
+		ReportingController: event.ReportingController,
+		Regarding: ObjectRef{
+			Kind:      event.Regarding.Kind,
+			Namespace: event.Regarding.Namespace,
+			Name:      event.Regarding.Name,
+		},
+		Count:          1,
+		FirstTimestamp: metav1.Time{Time: event.EventTime.Time},
+	}
+	if info.ReportingController == "" {
+		info.ReportingController = event.DeprecatedSource.Component
+	}
+
+	if info.FirstTimestamp.IsZero() {
+		info.FirstTimestamp = event.DeprecatedFirstTimestamp
+	}
+	if info.FirstTimestamp.IsZero() {
+		info.FirstTimestamp = event.CreationTimestamp
+	}
+	info.LastTimestamp = info.FirstTimestamp
+
+	switch {
+	case event.Series != nil:
+		info.Count = event.Series.Count
+		info.LastTimestamp = metav1.Time{Time: event.Series.LastObservedTime.Time}
+	case event.DeprecatedCount > 0:
+		info.Count = event.DeprecatedCount
+		if !event.DeprecatedLastTimestamp.IsZero() {
+			info.LastTimestamp = event.DeprecatedLastTimestamp
+		}
+	}
+	info.Age = age(info.LastTimestamp)
+	return info
+}
+
+func newEventInfos(events []eventsv1.Event) []EventInfo {
+	infos := make([]EventInfo, 0, len(events))
+	for _, event := range events {
+		infos = append(infos, newEventInfo(event))
+	}
+	return infos
+}
+
+// sortEventsByLastSeen ordena eventos do mais recente para o mais antigo.
+func sortEventsByLastSeen(infos []EventInfo) {
+	sort.SliceStable(infos, func(i, j int) bool {
+		return infos[i].LastTimestamp.After(infos[j].LastTimestamp.Time)
+	})
+}
+
+// detailEvents busca os eventos recentes para o detalhe de um objeto. Sem
+// permissão para ler eventos (ou em qualquer outra falha), o detalhe segue
+// sem eles em vez de falhar a requisição inteira.
+func detailEvents(namespace string, gvk schema.GroupVersionKind, name string, uid types.UID) []EventInfo {
+	infos, err := objectEvents(namespace, gvk, name, uid, recentEventsLimit)
+	if err != nil {
+		log.Printf("⚠️ Não foi possível buscar os eventos de %s %s/%s: %v", gvk.Kind, namespace, name, err)
+		return []EventInfo{}
+	}
+	return infos
+}
+
+// objectEvents busca os eventos mais recentes de um objeto, identificado por kind, nome e UID.
+func objectEvents(namespace string, gvk schema.GroupVersionKind, name string, uid types.UID, limit int) ([]EventInfo, error) {
+	selector, err := events.GetFieldSelector(eventsv1.SchemeGroupVersion, gvk, name, uid)
+	if err != nil {
+		return nil, err
+	}
+
+	list, err := client.EventsV1().Events(namespace).List(context.TODO(), metav1.ListOptions{FieldSelector: selector.String()})
+	if err != nil {
+		return nil, fmt.Errorf("erro ao listar eventos: %w", err)
+	}
+
+	infos := newEventInfos(list.Items)
+	sortEventsByLastSeen(infos)
+	if limit > 0 && len(infos) > limit {
+		infos = infos[:limit]
+	}
+	return infos, nil
+}
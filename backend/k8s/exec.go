@@ -0,0 +1,108 @@
+package k8s
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/httpstream"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/remotecommand"
+	utilexec "k8s.io/client-go/util/exec"
+)
+
+// defaultShell é o comando usado quando nenhum é informado.
+var defaultShell = []string{"/bin/sh"}
+
+// TerminalSize é o tamanho do terminal enviado ao container em sessões com TTY.
+type TerminalSize = remotecommand.TerminalSize
+
+// ExecOptions define o container e o comando de uma sessão de exec.
+type ExecOptions struct {
+	Container string
+	Command   []string
+	TTY       bool
+}
+
+// ExecStreams são os streams ligados à sessão. Resize pode ser nil quando não há TTY.
+type ExecStreams struct {
+	Stdin  io.Reader
+	Stdout io.Writer
+	Stderr io.Writer
+	Resize remotecommand.TerminalSizeQueue
+}
+
+// ExitCode extrai o código de saída de um erro retornado por ExecInPod.
+func ExitCode(err error) (int, bool) {
+	var exitErr utilexec.ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.ExitStatus(), true
+	}
+	return 0, false
+}
+
+// ExecInPod abre uma sessão pods/exec e liga os streams até o comando terminar
+// ou ctx ser cancelado. Tenta WebSocket primeiro e cai para SPDY em API servers
+// antigos, como o kubectl.
+func ExecInPod(ctx context.Context, namespace, name string, opts ExecOptions, streams ExecStreams) error {
+	pod, err := client.CoreV1().Pods(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("pod não encontrado: %w", err)
+	}
+	if pod.Status.Phase == v1.PodSucceeded || pod.Status.Phase == v1.PodFailed {
+		return fmt.Errorf("não é possível executar comandos em um pod finalizado (%s)", pod.Status.Phase)
+	}
+
+	container := opts.Container
+	if container == "" {
+		container = defaultContainer(pod)
+	}
+	command := opts.Command
+	if len(command) == 0 {
+		command = defaultShell
+	}
+
+	req := client.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Namespace(namespace).
+		Name(name).
+		SubResource("exec").
+		VersionedParams(&v1.PodExecOptions{
+			Container: container,
+			Command:   command,
+			Stdin:     streams.Stdin != nil,
+			Stdout:    streams.Stdout != nil,
+			// Com TTY, stderr é mesclado em stdout pelo container runtime
+			Stderr: streams.Stderr != nil && !opts.TTY,
+			TTY:    opts.TTY,
+		}, scheme.ParameterCodec)
+
+	spdyExec, err := remotecommand.NewSPDYExecutor(restConfig, "POST", req.URL())
+	if err != nil {
+		return fmt.Errorf("erro ao preparar exec: %w", err)
+	}
+	wsExec, err := remotecommand.NewWebSocketExecutor(restConfig, "GET", req.URL().String())
+	if err != nil {
+		return fmt.Errorf("erro ao preparar exec: %w", err)
+	}
+	executor, err := remotecommand.NewFallbackExecutor(wsExec, spdyExec, func(err error) bool {
+		return httpstream.IsUpgradeFailure(err) || httpstream.IsHTTPSProxyError(err)
+	})
+	if err != nil {
+		return fmt.Errorf("erro ao preparar exec: %w", err)
+	}
+
+	streamOpts := remotecommand.StreamOptions{
+		Stdin:             streams.Stdin,
+		Stdout:            streams.Stdout,
+		Tty:               opts.TTY,
+		TerminalSizeQueue: streams.Resize,
+	}
+	if !opts.TTY {
+		streamOpts.Stderr = streams.Stderr
+	}
+	return executor.StreamWithContext(ctx, streamOpts)
+}
@@ -0,0 +1,327 @@
+package k8s
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+)
+
+// Como tratar os Secrets na exportação.
+const (
+	SecretsRedact = "redact"
+	SecretsOmit   = "omit"
+)
+
+// ErrInvalidExport indica opções de exportação inválidas.
+var ErrInvalidExport = errors.New("opção de exportação inválida")
+
+// ExportOptions controla a exportação. Secrets é SecretsRedact (padrão: mantém as chaves
+// com valores vazios) ou SecretsOmit. Kinds restringe a exportação de namespace.
+type ExportOptions struct {
+	Secrets string
+	Kinds   []string
+}
+
+// exportKinds são os tipos incluídos na exportação de um namespace, na ordem em que
+// devem ser aplicados (configuração antes das cargas de trabalho que a usam).
+var exportKinds = []string{
+	"serviceaccounts",
+	"configmaps",
+	"secrets",
+	"persistentvolumeclaims",
+	"roles",
+	"rolebindings",
+	"services",
+	"deployments",
+	"statefulsets",
+	"daemonsets",
+	"cronjobs",
+	"jobs",
+	"horizontalpodautoscalers",
+	"ingresses",
+	"networkpolicies",
+	"poddisruptionbudgets",
+}
+
+// exportAnnotations são anotações adicionadas por ferramentas e controllers.
+var exportAnnotations = []string{
+	"kubectl.kubernetes.io/last-applied-configuration",
+	"deployment.kubernetes.io/revision",
+	"pv.kubernetes.io/bind-completed",
+	"pv.kubernetes.io/bound-by-controller",
+	"volume.beta.kubernetes.io/storage-provisioner",
+	"volume.kubernetes.io/storage-provisioner",
+}
+
+// podSpecDefaults são valores que o API server preenche em todo PodSpec.
+var podSpecDefaults = map[string]any{
+	"dnsPolicy":                     "ClusterFirst",
+	"restartPolicy":                 "Always",
+	"schedulerName":                 "default-scheduler",
+	"securityContext":               map[string]any{},
+	"terminationGracePeriodSeconds": int64(30),
+}
+
+// containerDefaults são valores que o API server preenche em todo container.
+var containerDefaults = map[string]any{
+	"terminationMessagePath":   "/dev/termination-log",
+	"terminationMessagePolicy": "File",
+	"resources":                map[string]any{},
+}
+
+// kindDefaults são valores padrão do spec de cada tipo.
+var kindDefaults = map[string]map[string]any{
+	"Deployment": {
+		"progressDeadlineSeconds": int64(600),
+		"revisionHistoryLimit":    int64(10),
+	},
+	"StatefulSet": {
+		"podManagementPolicy":  "OrderedReady",
+		"revisionHistoryLimit": int64(10),
+	},
+	"DaemonSet": {
+		"revisionHistoryLimit": int64(10),
+	},
+	"Service": {
+		"sessionAffinity":       "None",
+		"internalTrafficPolicy": "Cluster",
+	},
+}
+
+// ExportResource devolve um objeto em YAML, sem os campos gerados pelo cluster,
+// pronto para ser aplicado novamente. kind aceita singular, plural ou abreviação.
+func ExportResource(ctx context.Context, namespace, kind, name string, opts ExportOptions) ([]byte, error) {
+	if err := opts.validate(); err != nil {
+		return nil, err
+	}
+	resource, gvk, err := resourceForName(kind)
+	if err != nil {
+		return nil, err
+	}
+
+	obj, err := resource.client(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("%s não encontrado: %w", strings.ToLower(gvk.Kind), err)
+	}
+	if obj.GetKind() == "Secret" && opts.Secrets == SecretsOmit {
+		return nil, fmt.Errorf("%w: secrets omitidos (secrets=%s)", ErrInvalidExport, SecretsOmit)
+	}
+	return yaml.Marshal(cleanForExport(obj, opts).Object)
+}
+
+// ExportNamespace devolve em um único YAML (documentos separados por ---) os recursos do
+// namespace criados por usuários. Objetos gerenciados por um controller (ex.: ReplicaSets
+// de um Deployment) e os criados automaticamente pelo cluster ficam de fora.
+func ExportNamespace(ctx context.Context, namespace string, opts ExportOptions) ([]byte, error) {
+	if err := opts.validate(); err != nil {
+		return nil, err
+	}
+	kinds := exportKinds
+	if len(opts.Kinds) > 0 {
+		kinds = opts.Kinds
+	}
+
+	var out bytes.Buffer
+	for _, kind := range kinds {
+		resource, _, err := resourceForName(kind)
+		if err != nil {
+			if len(opts.Kinds) > 0 {
+				return nil, err
+			}
+			// Tipos opcionais (ex.: PodDisruptionBudget em clusters antigos) são ignorados
+			continue
+		}
+		if !resource.namespaced {
+			return nil, fmt.Errorf("%w: '%s' não pertence a um namespace", ErrInvalidExport, kind)
+		}
+
+		list, err := resource.client(namespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("erro ao listar %s: %w", kind, err)
+		}
+		items := list.Items
+		sort.Slice(items, func(i, j int) bool { return items[i].GetName() < items[j].GetName() })
+
+		for i := range items {
+			obj := &items[i]
+			if skipExport(obj, opts) {
+				continue
+			}
+			data, err := yaml.Marshal(cleanForExport(obj, opts).Object)
+			if err != nil {
+				return nil, err
+			}
+			out.WriteString("---\n")
+			out.Write(data)
+		}
+	}
+	return out.Bytes(), nil
+}
+
+func (o ExportOptions) validate() error {
+	switch o.Secrets {
+	case "", SecretsRedact, SecretsOmit:
+		return nil
+	default:
+		return fmt.Errorf("%w: 'secrets' deve ser %s ou %s", ErrInvalidExport, SecretsRedact, SecretsOmit)
+	}
+}
+
+// skipExport descarta objetos que o cluster recria sozinho.
+func skipExport(obj *unstructured.Unstructured, opts ExportOptions) bool {
+	if metav1.GetControllerOf(obj) != nil {
+		return true
+	}
+	switch obj.GetKind() {
+	case "Secret":
+		secretType, _, _ := unstructured.NestedString(obj.Object, "type")
+		return opts.Secrets == SecretsOmit || secretType == "kubernetes.io/service-account-token"
+	case "ConfigMap":
+		// Certificado da CA publicado em todo namespace
+		return obj.GetName() == "kube-root-ca.crt"
+	case "ServiceAccount":
+		return obj.GetName() == "default"
+	}
+	return false
+}
+
+// cleanForExport remove status, metadados gerados pelo servidor, anotações de ferramentas
+// e valores preenchidos por padrão, e mascara os valores dos Secrets.
+func cleanForExport(obj *unstructured.Unstructured, opts ExportOptions) *unstructured.Unstructured {
+	clean := withoutServerFields(obj)
+	unstructured.RemoveNestedField(clean.Object, "metadata", "deletionTimestamp")
+	unstructured.RemoveNestedField(clean.Object, "metadata", "deletionGracePeriodSeconds")
+
+	annotations := clean.GetAnnotations()
+	for _, annotation := range exportAnnotations {
+		delete(annotations, annotation)
+	}
+	if len(annotations) == 0 {
+		annotations = nil
+	}
+	clean.SetAnnotations(annotations)
+
+	if spec, found, _ := unstructured.NestedMap(clean.Object, "spec"); found {
+		removeDefaults(spec, kindDefaults[clean.GetKind()])
+		unstructured.SetNestedMap(clean.Object, spec, "spec")
+	}
+
+	switch clean.GetKind() {
+	case "Pod":
+		unstructured.RemoveNestedField(clean.Object, "spec", "nodeName")
+		cleanPodSpec(clean.Object, "spec")
+	case "Deployment", "StatefulSet", "DaemonSet", "ReplicaSet", "Job":
+		cleanPodSpec(clean.Object, "spec", "template", "spec")
+		unstructured.RemoveNestedField(clean.Object, "spec", "template", "metadata", "creationTimestamp")
+		if clean.GetKind() == "Job" {
+			// Gerados a partir do UID do Job; impedem que ele seja recriado
+			cleanGeneratedJobSelector(clean)
+		}
+	case "CronJob":
+		cleanPodSpec(clean.Object, "spec", "jobTemplate", "spec", "template", "spec")
+		unstructured.RemoveNestedField(clean.Object, "spec", "jobTemplate", "spec", "template", "metadata", "creationTimestamp")
+	case "Service":
+		if clusterIP, _, _ := unstructured.NestedString(clean.Object, "spec", "clusterIP"); clusterIP != "None" {
+			// Endereços alocados pelo cluster; um novo é escolhido ao recriar
+			unstructured.RemoveNestedField(clean.Object, "spec", "clusterIP")
+			unstructured.RemoveNestedField(clean.Object, "spec", "clusterIPs")
+		}
+	case "PersistentVolumeClaim":
+		// O volume é vinculado pelo cluster ao recriar a claim
+		unstructured.RemoveNestedField(clean.Object, "spec", "volumeName")
+	case "Secret":
+		redactSecret(clean)
+	}
+	return clean
+}
+
+// cleanPodSpec remove os valores padrão do PodSpec e dos containers no caminho informado.
+func cleanPodSpec(obj map[string]any, path ...string) {
+	spec, found, _ := unstructured.NestedMap(obj, path...)
+	if !found {
+		return
+	}
+	removeDefaults(spec, podSpecDefaults)
+	for _, field := range []string{"containers", "initContainers"} {
+		containers, _, _ := unstructured.NestedSlice(spec, field)
+		for _, c := range containers {
+			if container, ok := c.(map[string]any); ok {
+				removeDefaults(container, containerDefaults)
+			}
+		}
+		if containers != nil {
+			spec[field] = containers
+		}
+	}
+	unstructured.SetNestedMap(obj, spec, path...)
+}
+
+func cleanGeneratedJobSelector(job *unstructured.Unstructured) {
+	if manual, _, _ := unstructured.NestedBool(job.Object, "spec", "manualSelector"); manual {
+		return
+	}
+	unstructured.RemoveNestedField(job.Object, "spec", "selector")
+	for _, label := range []string{"controller-uid", "batch.kubernetes.io/controller-uid"} {
+		unstructured.RemoveNestedField(job.Object, "spec", "template", "metadata", "labels", label)
+	}
+}
+
+// redactSecret mantém as chaves do Secret, mas com valores vazios. A anotação
+// last-applied-configuration também sai, já que guarda os valores em texto puro.
+func redactSecret(secret *unstructured.Unstructured) {
+	data, _, _ := unstructured.NestedMap(secret.Object, "data")
+	for key := range data {
+		data[key] = ""
+	}
+	if data != nil {
+		unstructured.SetNestedMap(secret.Object, data, "data")
+	}
+	unstructured.RemoveNestedField(secret.Object, "stringData")
+	unstructured.RemoveNestedField(secret.Object, "metadata", "annotations", v1.LastAppliedConfigAnnotation)
+}
+
+// isSecret indica se o objeto é um Secret do core/v1.
+func isSecret(obj *unstructured.Unstructured) bool {
+	return obj.GetAPIVersion() == "v1" && obj.GetKind() == "Secret"
+}
+
+// removeDefaults apaga de obj os campos cujo valor é igual ao padrão.
+func removeDefaults(obj map[string]any, defaults map[string]any) {
+	for field, value := range defaults {
+		if current, ok := obj[field]; ok && sameValue(current, value) {
+			delete(obj, field)
+		}
+	}
+}
+
+// sameValue compara valores JSON; números são comparados pelo valor, já que podem
+// chegar como int64 (API) ou float64 (YAML decodificado).
+func sameValue(a, b any) bool {
+	x, aIsNumber := toFloat(a)
+	y, bIsNumber := toFloat(b)
+	if aIsNumber && bIsNumber {
+		return x == y
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+func toFloat(v any) (float64, bool) {
+	switch n := v.(type) {
+	case int64:
+		return float64(n), true
+	case float64:
+		return n, true
+	case int:
+		return float64(n), true
+	}
+	return 0, false
+}
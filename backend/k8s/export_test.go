@@ -0,0 +1,260 @@
+package k8s
+
+import (
+	"reflect"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestCleanForExport(t *testing.T) {
+	podSpec := func() map[string]any {
+		return map[string]any{
+			"dnsPolicy":                     "ClusterFirst",
+			"restartPolicy":                 "Always",
+			"schedulerName":                 "default-scheduler",
+			"securityContext":               map[string]any{},
+			"terminationGracePeriodSeconds": int64(30),
+			"containers": []any{map[string]any{
+				"name":                     "app",
+				"image":                    "nginx:1.27",
+				"resources":                map[string]any{},
+				"terminationMessagePath":   "/dev/termination-log",
+				"terminationMessagePolicy": "File",
+			}},
+		}
+	}
+	cleanPodSpec := map[string]any{
+		"containers": []any{map[string]any{"name": "app", "image": "nginx:1.27"}},
+	}
+	metadata := func(extra map[string]any) map[string]any {
+		meta := map[string]any{
+			"name":              "app",
+			"namespace":         "default",
+			"uid":               "1234",
+			"resourceVersion":   "42",
+			"generation":        int64(3),
+			"creationTimestamp": "2024-01-01T00:00:00Z",
+			"managedFields":     []any{map[string]any{"manager": "kubectl"}},
+			"annotations": map[string]any{
+				"kubectl.kubernetes.io/last-applied-configuration": "{}",
+				"deployment.kubernetes.io/revision":                "3",
+			},
+		}
+		for k, v := range extra {
+			meta[k] = v
+		}
+		return meta
+	}
+	cleanMetadata := map[string]any{"name": "app", "namespace": "default"}
+
+	tests := []struct {
+		name string
+		obj  map[string]any
+		want map[string]any
+	}{
+		{
+			name: "deployment sem status, metadados do servidor e padrões",
+			obj: map[string]any{
+				"apiVersion": "apps/v1",
+				"kind":       "Deployment",
+				"metadata":   metadata(map[string]any{"annotations": map[string]any{"team": "web", "deployment.kubernetes.io/revision": "3"}}),
+				"spec": map[string]any{
+					"replicas":                int64(2),
+					"progressDeadlineSeconds": int64(600),
+					"revisionHistoryLimit":    int64(5),
+					"template": map[string]any{
+						"metadata": map[string]any{"creationTimestamp": nil, "labels": map[string]any{"app": "web"}},
+						"spec":     podSpec(),
+					},
+				},
+				"status": map[string]any{"replicas": int64(2)},
+			},
+			want: map[string]any{
+				"apiVersion": "apps/v1",
+				"kind":       "Deployment",
+				"metadata":   map[string]any{"name": "app", "namespace": "default", "annotations": map[string]any{"team": "web"}},
+				"spec": map[string]any{
+					"replicas":             int64(2),
+					"revisionHistoryLimit": int64(5),
+					"template": map[string]any{
+						"metadata": map[string]any{"labels": map[string]any{"app": "web"}},
+						"spec":     cleanPodSpec,
+					},
+				},
+			},
+		},
+		{
+			name: "pod sem o node escolhido pelo scheduler",
+			obj: map[string]any{
+				"apiVersion": "v1",
+				"kind":       "Pod",
+				"metadata":   metadata(nil),
+				"spec": func() map[string]any {
+					spec := podSpec()
+					spec["nodeName"] = "node-1"
+					return spec
+				}(),
+			},
+			want: map[string]any{
+				"apiVersion": "v1",
+				"kind":       "Pod",
+				"metadata":   cleanMetadata,
+				"spec":       cleanPodSpec,
+			},
+		},
+		{
+			name: "service sem o clusterIP alocado",
+			obj: map[string]any{
+				"apiVersion": "v1",
+				"kind":       "Service",
+				"metadata":   metadata(nil),
+				"spec": map[string]any{
+					"clusterIP":       "10.96.0.10",
+					"clusterIPs":      []any{"10.96.0.10"},
+					"sessionAffinity": "None",
+					"ports":           []any{map[string]any{"port": int64(80)}},
+				},
+			},
+			want: map[string]any{
+				"apiVersion": "v1",
+				"kind":       "Service",
+				"metadata":   cleanMetadata,
+				"spec":       map[string]any{"ports": []any{map[string]any{"port": int64(80)}}},
+			},
+		},
+		{
+			name: "service headless mantém clusterIP None",
+			obj: map[string]any{
+				"apiVersion": "v1",
+				"kind":       "Service",
+				"metadata":   metadata(nil),
+				"spec":       map[string]any{"clusterIP": "None"},
+			},
+			want: map[string]any{
+				"apiVersion": "v1",
+				"kind":       "Service",
+				"metadata":   cleanMetadata,
+				"spec":       map[string]any{"clusterIP": "None"},
+			},
+		},
+		{
+			name: "job sem o seletor gerado",
+			obj: map[string]any{
+				"apiVersion": "batch/v1",
+				"kind":       "Job",
+				"metadata":   metadata(nil),
+				"spec": map[string]any{
+					"selector": map[string]any{"matchLabels": map[string]any{"controller-uid": "1234"}},
+					"template": map[string]any{
+						"metadata": map[string]any{"labels": map[string]any{"controller-uid": "1234", "batch.kubernetes.io/controller-uid": "1234", "app": "migrate"}},
+						"spec":     podSpec(),
+					},
+				},
+			},
+			want: map[string]any{
+				"apiVersion": "batch/v1",
+				"kind":       "Job",
+				"metadata":   cleanMetadata,
+				"spec": map[string]any{
+					"template": map[string]any{
+						"metadata": map[string]any{"labels": map[string]any{"app": "migrate"}},
+						"spec":     cleanPodSpec,
+					},
+				},
+			},
+		},
+		{
+			name: "secret com as chaves e sem os valores",
+			obj: map[string]any{
+				"apiVersion": "v1",
+				"kind":       "Secret",
+				"metadata":   metadata(nil),
+				"type":       "Opaque",
+				"data":       map[string]any{"password": "c2VncmVkbw==", "user": "YWRtaW4="},
+				"stringData": map[string]any{"token": "abc"},
+			},
+			want: map[string]any{
+				"apiVersion": "v1",
+				"kind":       "Secret",
+				"metadata":   cleanMetadata,
+				"type":       "Opaque",
+				"data":       map[string]any{"password": "", "user": ""},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			obj := &unstructured.Unstructured{Object: tt.obj}
+			original := obj.DeepCopy()
+
+			got := cleanForExport(obj, ExportOptions{Secrets: SecretsRedact})
+			if !reflect.DeepEqual(got.Object, tt.want) {
+				t.Errorf("cleanForExport() =\n%#v\nesperado\n%#v", got.Object, tt.want)
+			}
+			if !reflect.DeepEqual(obj.Object, original.Object) {
+				t.Errorf("cleanForExport() alterou o objeto original")
+			}
+		})
+	}
+}
+
+func TestSkipExport(t *testing.T) {
+	object := func(kind, name string, extra map[string]any) *unstructured.Unstructured {
+		obj := &unstructured.Unstructured{Object: map[string]any{"apiVersion": "v1", "kind": kind}}
+		obj.SetName(name)
+		for k, v := range extra {
+			obj.Object[k] = v
+		}
+		return obj
+	}
+	controlled := object("Pod", "web-abc", nil)
+	controller := true
+	controlled.SetOwnerReferences([]metav1.OwnerReference{{Kind: "ReplicaSet", Name: "web", Controller: &controller}})
+
+	tests := []struct {
+		name string
+		obj  *unstructured.Unstructured
+		opts ExportOptions
+		want bool
+	}{
+		{name: "objeto criado por um controller", obj: controlled, want: true},
+		{name: "configmap comum", obj: object("ConfigMap", "app-config", nil), want: false},
+		{name: "CA do cluster", obj: object("ConfigMap", "kube-root-ca.crt", nil), want: true},
+		{name: "service account padrão", obj: object("ServiceAccount", "default", nil), want: true},
+		{name: "secret com redact", obj: object("Secret", "db", nil), opts: ExportOptions{Secrets: SecretsRedact}, want: false},
+		{name: "secret com omit", obj: object("Secret", "db", nil), opts: ExportOptions{Secrets: SecretsOmit}, want: true},
+		{name: "token de service account", obj: object("Secret", "sa-token", map[string]any{"type": "kubernetes.io/service-account-token"}), want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := skipExport(tt.obj, tt.opts); got != tt.want {
+				t.Errorf("skipExport() = %v, esperado %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRedactSecret(t *testing.T) {
+	secret := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "v1",
+		"kind":       "Secret",
+		"metadata":   map[string]any{"name": "db"},
+		"data":       map[string]any{"password": "c2VncmVkbw=="},
+		"stringData": map[string]any{"user": "admin"},
+	}}
+	redactSecret(secret)
+
+	want := map[string]any{
+		"apiVersion": "v1",
+		"kind":       "Secret",
+		"metadata":   map[string]any{"name": "db"},
+		"data":       map[string]any{"password": ""},
+	}
+	if !reflect.DeepEqual(secret.Object, want) {
+		t.Errorf("redactSecret() = %#v, esperado %#v", secret.Object, want)
+	}
+}
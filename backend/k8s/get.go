@@ -0,0 +1,317 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// maxOwnerDepth limita quantos níveis de controladores são seguidos a partir de um objeto.
+const maxOwnerDepth = 5
+
+// PodDetail é o modelo completo de um pod, com a cadeia de controladores e eventos recentes.
+type PodDetail struct {
+	PodInfo
+	HostIP         string            `json:"hostIP,omitempty"`
+	PodIPs         []string          `json:"podIPs,omitempty"`
+	ServiceAccount string            `json:"serviceAccount,omitempty"`
+	RestartPolicy  string            `json:"restartPolicy"`
+	StartTime      *metav1.Time      `json:"startTime,omitempty"`
+	Annotations    map[string]string `json:"annotations,omitempty"`
+	OwnerChain     []OwnerInfo       `json:"ownerChain"`
+	Events         []EventInfo       `json:"events"`
+}
+
+// DeploymentDetail é o modelo completo de um deployment, com seus ReplicaSets,
+// pods e eventos recentes.
+type DeploymentDetail struct {
+	DeploymentInfo
+	Paused               bool              `json:"paused"`
+	RevisionHistoryLimit *int32            `json:"revisionHistoryLimit,omitempty"`
+	Annotations          map[string]string `json:"annotations,omitempty"`
+	ReplicaSets          []ReplicaSetInfo  `json:"replicaSets"`
+	Autoscaler           *HPAInfo          `json:"autoscaler,omitempty"`
+	Pods                 []PodInfo         `json:"pods"`
+	Events               []EventInfo       `json:"events"`
+}
+
+// ReplicaSetInfo resume um ReplicaSet de um deployment. Revision vem da anotação
+// deployment.kubernetes.io/revision.
+type ReplicaSetInfo struct {
+	Nome              string      `json:"nome"`
+	Revision          int64       `json:"revision"`
+	Replicas          int32       `json:"replicas"`
+	ReadyReplicas     int32       `json:"readyReplicas"`
+	AvailableReplicas int32       `json:"availableReplicas"`
+	Images            []string    `json:"images"`
+	CreationTimestamp metav1.Time `json:"creationTimestamp"`
+	Age               string      `json:"age"`
+}
+
+// ServiceDetail é o modelo completo de um service, com cada endpoint das suas
+// EndpointSlices e eventos recentes.
+type ServiceDetail struct {
+	ServiceInfo
+	SessionAffinity string            `json:"sessionAffinity,omitempty"`
+	Annotations     map[string]string `json:"annotations,omitempty"`
+	Backends        []EndpointInfo    `json:"backends"`
+	Events          []EventInfo       `json:"events"`
+}
+
+// EndpointInfo descreve um endpoint de uma EndpointSlice.
+type EndpointInfo struct {
+	Addresses   []string           `json:"addresses"`
+	Ready       bool               `json:"ready"`
+	Serving     bool               `json:"serving"`
+	Terminating bool               `json:"terminating"`
+	Target      *ObjectRef         `json:"target,omitempty"`
+	NodeName    string             `json:"nodeName,omitempty"`
+	Zone        string             `json:"zone,omitempty"`
+	Ports       []EndpointPortInfo `json:"ports,omitempty"`
+}
+
+type EndpointPortInfo struct {
+	Name     string `json:"name,omitempty"`
+	Port     int32  `json:"port"`
+	Protocol string `json:"protocol"`
+}
+
+// GetPod retorna os detalhes de um pod.
+func GetPod(namespace, name string) (PodDetail, error) {
+	pod, err := client.CoreV1().Pods(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+	if err != nil {
+		return PodDetail{}, fmt.Errorf("pod não encontrado: %w", err)
+	}
+
+	detail := PodDetail{
+		PodInfo:        newPodInfo(*pod),
+		HostIP:         pod.Status.HostIP,
+		ServiceAccount: pod.Spec.ServiceAccountName,
+		RestartPolicy:  string(pod.Spec.RestartPolicy),
+		StartTime:      pod.Status.StartTime,
+		Annotations:    withoutLastApplied(pod.Annotations),
+	}
+	for _, ip := range pod.Status.PodIPs {
+		detail.PodIPs = append(detail.PodIPs, ip.IP)
+	}
+
+	// Sem permissão para ler algum dono, a cadeia para no último encontrado
+	detail.OwnerChain, err = ownerChain(namespace, pod.ObjectMeta)
+	if err != nil {
+		log.Printf("⚠️ Não foi possível montar a cadeia de donos do pod %s/%s: %v", namespace, name, err)
+	}
+	detail.Events = detailEvents(namespace, v1.SchemeGroupVersion.WithKind("Pod"), pod.Name, pod.UID)
+	return detail, nil
+}
+
+// GetDeployment retorna os detalhes de um deployment.
+func GetDeployment(namespace, name string) (DeploymentDetail, error) {
+	deployment, err := client.AppsV1().Deployments(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+	if err != nil {
+		return DeploymentDetail{}, fmt.Errorf("deployment não encontrado: %w", err)
+	}
+
+	detail := DeploymentDetail{
+		DeploymentInfo:       newDeploymentInfo(*deployment),
+		Paused:               deployment.Spec.Paused,
+		RevisionHistoryLimit: deployment.Spec.RevisionHistoryLimit,
+		Annotations:          withoutLastApplied(deployment.Annotations),
+		ReplicaSets:          []ReplicaSetInfo{},
+		Pods:                 []PodInfo{},
+	}
+
+	// Sem permissão para ler ReplicaSets, o detalhe segue sem eles (e sem os pods)
+	replicaSets, err := deploymentReplicaSets(deployment)
+	if err != nil {
+		log.Printf("⚠️ Não foi possível buscar os replicasets do deployment %s/%s: %v", namespace, name, err)
+	}
+	owned := map[string]bool{}
+	for _, rs := range replicaSets {
+		owned[rs.Name] = true
+		detail.ReplicaSets = append(detail.ReplicaSets, newReplicaSetInfo(rs))
+	}
+
+	// Sem permissão para ler HPAs, o detalhe segue sem o autoscaler
+	if hpa, err := deploymentAutoscaler(namespace, name); err != nil {
+		log.Printf("⚠️ Não foi possível buscar o HPA do deployment %s/%s: %v", namespace, name, err)
+	} else if hpa != nil {
+		info := newHPAInfo(*hpa)
+		detail.Autoscaler = &info
+	}
+
+	pods, err := deploymentPods(deployment)
+	if err != nil {
+		log.Printf("⚠️ Não foi possível buscar os pods do deployment %s/%s: %v", namespace, name, err)
+	}
+	for _, pod := range pods {
+		// Só entram pods criados pelos ReplicaSets deste deployment
+		if ref := metav1.GetControllerOfNoCopy(&pod); ref != nil && ref.Kind == "ReplicaSet" && owned[ref.Name] {
+			detail.Pods = append(detail.Pods, newPodInfo(pod))
+		}
+	}
+
+	detail.Events = detailEvents(namespace, appsv1.SchemeGroupVersion.WithKind("Deployment"), deployment.Name, deployment.UID)
+	return detail, nil
+}
+
+// GetService retorna os detalhes de um service.
+func GetService(namespace, name string) (ServiceDetail, error) {
+	service, err := client.CoreV1().Services(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+	if err != nil {
+		return ServiceDetail{}, fmt.Errorf("service não encontrado: %w", err)
+	}
+
+	// Sem permissão para ler EndpointSlices, o detalhe segue sem as contagens e os backends
+	var endpoints map[string]*EndpointCounts
+	slices, err := client.DiscoveryV1().EndpointSlices(namespace).List(context.TODO(), metav1.ListOptions{
+		LabelSelector: discoveryv1.LabelServiceName + "=" + service.Name,
+	})
+	if err != nil {
+		log.Printf("⚠️ Não foi possível buscar as endpointslices do service %s/%s: %v", namespace, name, err)
+		slices = &discoveryv1.EndpointSliceList{}
+	} else {
+		endpoints = countEndpointSlices(slices.Items)
+	}
+
+	detail := ServiceDetail{
+		ServiceInfo:     newServiceInfo(*service, endpoints),
+		SessionAffinity: string(service.Spec.SessionAffinity),
+		Annotations:     withoutLastApplied(service.Annotations),
+		Backends:        []EndpointInfo{},
+	}
+	for _, slice := range slices.Items {
+		for _, ep := range slice.Endpoints {
+			detail.Backends = append(detail.Backends, newEndpointInfo(slice, ep))
+		}
+	}
+
+	detail.Events = detailEvents(namespace, v1.SchemeGroupVersion.WithKind("Service"), service.Name, service.UID)
+	return detail, nil
+}
+
+// ownerChain segue as referências de controlador a partir de um objeto
+// (ex.: Pod -> ReplicaSet -> Deployment) usando apenas metadados.
+func ownerChain(namespace string, objMeta metav1.ObjectMeta) ([]OwnerInfo, error) {
+	chain := []OwnerInfo{}
+	ref := metav1.GetControllerOfNoCopy(&objMeta)
+	for depth := 0; ref != nil && depth < maxOwnerDepth; depth++ {
+		chain = append(chain, OwnerInfo{Kind: ref.Kind, Name: ref.Name})
+
+		gvr, _ := meta.UnsafeGuessKindToResource(schema.FromAPIVersionAndKind(ref.APIVersion, ref.Kind))
+		owner, err := metadataClient.Resource(gvr).Namespace(namespace).Get(context.TODO(), ref.Name, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			// O dono já foi removido; a cadeia termina nele
+			break
+		}
+		if err != nil {
+			return chain, fmt.Errorf("erro ao buscar %s %s: %w", ref.Kind, ref.Name, err)
+		}
+		ref = metav1.GetControllerOfNoCopy(owner)
+	}
+	return chain, nil
+}
+
+// deploymentReplicaSets retorna os ReplicaSets controlados pelo deployment, do mais novo para o mais antigo.
+func deploymentReplicaSets(deployment *appsv1.Deployment) ([]appsv1.ReplicaSet, error) {
+	selector, err := metav1.LabelSelectorAsSelector(deployment.Spec.Selector)
+	if err != nil {
+		return nil, fmt.Errorf("seletor inválido: %w", err)
+	}
+
+	list, err := client.AppsV1().ReplicaSets(deployment.Namespace).List(context.TODO(), metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return nil, fmt.Errorf("erro ao listar replicasets: %w", err)
+	}
+
+	var owned []appsv1.ReplicaSet
+	for _, rs := range list.Items {
+		if ref := metav1.GetControllerOfNoCopy(&rs); ref != nil && ref.UID == deployment.UID {
+			owned = append(owned, rs)
+		}
+	}
+	sort.SliceStable(owned, func(i, j int) bool {
+		return replicaSetRevision(owned[i]) > replicaSetRevision(owned[j])
+	})
+	return owned, nil
+}
+
+// deploymentPods retorna os pods selecionados pelo seletor do deployment.
+func deploymentPods(deployment *appsv1.Deployment) ([]v1.Pod, error) {
+	selector, err := metav1.LabelSelectorAsSelector(deployment.Spec.Selector)
+	if err != nil {
+		return nil, fmt.Errorf("seletor inválido: %w", err)
+	}
+
+	list, err := client.CoreV1().Pods(deployment.Namespace).List(context.TODO(), metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return nil, fmt.Errorf("erro ao listar pods: %w", err)
+	}
+	return list.Items, nil
+}
+
+func replicaSetRevision(rs appsv1.ReplicaSet) int64 {
+	revision, _ := strconv.ParseInt(rs.Annotations["deployment.kubernetes.io/revision"], 10, 64)
+	return revision
+}
+
+func newReplicaSetInfo(rs appsv1.ReplicaSet) ReplicaSetInfo {
+	info := ReplicaSetInfo{
+		Nome:              rs.Name,
+		Revision:          replicaSetRevision(rs),
+		ReadyReplicas:     rs.Status.ReadyReplicas,
+		AvailableReplicas: rs.Status.AvailableReplicas,
+		Images:            []string{},
+		CreationTimestamp: rs.CreationTimestamp,
+		Age:               age(rs.CreationTimestamp),
+	}
+	if rs.Spec.Replicas != nil {
+		info.Replicas = *rs.Spec.Replicas
+	}
+	for _, c := range rs.Spec.Template.Spec.Containers {
+		info.Images = append(info.Images, c.Image)
+	}
+	return info
+}
+
+func newEndpointInfo(slice discoveryv1.EndpointSlice, ep discoveryv1.Endpoint) EndpointInfo {
+	info := EndpointInfo{
+		Addresses: ep.Addresses,
+		// Condições nil devem ser interpretadas como verdadeiras (ready/serving) ou falsas (terminating)
+		Ready:       ep.Conditions.Ready == nil || *ep.Conditions.Ready,
+		Serving:     ep.Conditions.Serving == nil || *ep.Conditions.Serving,
+		Terminating: ep.Conditions.Terminating != nil && *ep.Conditions.Terminating,
+	}
+	if ep.NodeName != nil {
+		info.NodeName = *ep.NodeName
+	}
+	if ep.Zone != nil {
+		info.Zone = *ep.Zone
+	}
+	if ep.TargetRef != nil {
+		info.Target = &ObjectRef{Kind: ep.TargetRef.Kind, Namespace: ep.TargetRef.Namespace, Name: ep.TargetRef.Name}
+	}
+	for _, port := range slice.Ports {
+		portInfo := EndpointPortInfo{}
+		if port.Name != nil {
+			portInfo.Name = *port.Name
+		}
+		if port.Port != nil {
+			portInfo.Port = *port.Port
+		}
+		if port.Protocol != nil {
+			portInfo.Protocol = string(*port.Protocol)
+		}
+		info.Ports = append(info.Ports, portInfo)
+	}
+	return info
+}
@@ -0,0 +1,364 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// HPAInfo resume um HorizontalPodAutoscaler (autoscaling/v2). Deployment é o alvo do
+// escalonamento; ScalingActive e ScalingLimited vêm das condições do HPA e indicam se
+// ele consegue calcular as réplicas e se está preso em minReplicas/maxReplicas.
+type HPAInfo struct {
+	Nome              string            `json:"nome"`
+	Namespace         string            `json:"namespace"`
+	Deployment        string            `json:"deployment"`
+	MinReplicas       int32             `json:"minReplicas"`
+	MaxReplicas       int32             `json:"maxReplicas"`
+	CurrentReplicas   int32             `json:"currentReplicas"`
+	DesiredReplicas   int32             `json:"desiredReplicas"`
+	Metrics           []HPAMetric       `json:"metrics"`
+	ScalingActive     bool              `json:"scalingActive"`
+	ScalingLimited    bool              `json:"scalingLimited"`
+	LastScaleTime     *metav1.Time      `json:"lastScaleTime,omitempty"`
+	Conditions        []HPACondition    `json:"conditions,omitempty"`
+	Labels            map[string]string `json:"labels,omitempty"`
+	CreationTimestamp metav1.Time       `json:"creationTimestamp"`
+	Age               string            `json:"age"`
+}
+
+// HPADetail é o modelo completo de um HPA, com eventos recentes (ex.: SuccessfulRescale).
+type HPADetail struct {
+	HPAInfo
+	Annotations map[string]string `json:"annotations,omitempty"`
+	Events      []EventInfo       `json:"events"`
+}
+
+// HPAMetric mostra a utilização alvo e a atual (em % dos requests) de um recurso.
+// Current fica vazio enquanto o metrics-server não informar o valor.
+type HPAMetric struct {
+	Resource string `json:"resource"`
+	Target   int32  `json:"target"`
+	Current  *int32 `json:"current,omitempty"`
+}
+
+type HPACondition struct {
+	Type    string `json:"type"`
+	Status  string `json:"status"`
+	Reason  string `json:"reason,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+// HPASpec reúne os parâmetros de um HPA sobre um deployment. As metas de CPU e memória
+// são utilizações médias em % dos requests dos containers; ao menos uma é obrigatória.
+// Sem minReplicas, o Kubernetes usa 1.
+type HPASpec struct {
+	Deployment        string `json:"deployment"`
+	MinReplicas       *int32 `json:"minReplicas,omitempty"`
+	MaxReplicas       int32  `json:"maxReplicas"`
+	CPUUtilization    *int32 `json:"cpuUtilization,omitempty"`
+	MemoryUtilization *int32 `json:"memoryUtilization,omitempty"`
+}
+
+func (h HPAInfo) GetNamespace() string { return h.Namespace }
+func (h HPAInfo) GetName() string      { return h.Nome }
+
+// ListHPAs retorna uma página de HPAInfo do namespace informado (ou de todos, com AllNamespaces).
+func ListHPAs(namespace string, opts ListOptions) (ListResult[HPAInfo], error) {
+	return listNamespacedPage(namespace, opts, listHPAs)
+}
+
+func listHPAs(namespace string, listOpts metav1.ListOptions) ([]HPAInfo, metav1.ListMeta, error) {
+	hpas, err := client.AutoscalingV2().HorizontalPodAutoscalers(namespace).List(context.TODO(), listOpts)
+	if err != nil {
+		return nil, metav1.ListMeta{}, fmt.Errorf("erro ao listar hpas: %w", err)
+	}
+
+	infos := make([]HPAInfo, 0, len(hpas.Items))
+	for _, hpa := range hpas.Items {
+		infos = append(infos, newHPAInfo(hpa))
+	}
+	return infos, hpas.ListMeta, nil
+}
+
+// GetHPA retorna os detalhes de um HPA, com o estado atual das métricas e condições.
+func GetHPA(namespace, name string) (HPADetail, error) {
+	hpa, err := client.AutoscalingV2().HorizontalPodAutoscalers(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+	if err != nil {
+		return HPADetail{}, fmt.Errorf("hpa não encontrado: %w", err)
+	}
+
+	detail := HPADetail{
+		HPAInfo:     newHPAInfo(*hpa),
+		Annotations: withoutLastApplied(hpa.Annotations),
+	}
+	detail.Events = detailEvents(namespace, autoscalingv2.SchemeGroupVersion.WithKind("HorizontalPodAutoscaler"), hpa.Name, hpa.UID)
+	return detail, nil
+}
+
+// BuildHPA monta um HPA sobre o deployment depois de validar réplicas e metas.
+func BuildHPA(namespace, name string, spec HPASpec) (*autoscalingv2.HorizontalPodAutoscaler, error) {
+	if spec.Deployment == "" {
+		return nil, fmt.Errorf("%w: 'deployment' é obrigatório", ErrInvalidUpdate)
+	}
+	hpa := &autoscalingv2.HorizontalPodAutoscaler{
+		TypeMeta: metav1.TypeMeta{APIVersion: "autoscaling/v2", Kind: "HorizontalPodAutoscaler"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: autoscalingv2.HorizontalPodAutoscalerSpec{
+			ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{
+				APIVersion: appsv1.SchemeGroupVersion.String(),
+				Kind:       "Deployment",
+				Name:       spec.Deployment,
+			},
+		},
+	}
+	if err := setHPASpec(hpa, spec); err != nil {
+		return nil, err
+	}
+	return hpa, nil
+}
+
+// CreateHPA cria o HPA. O deployment precisa existir, não pode ter outro HPA e todos os
+// containers precisam de requests dos recursos usados nas metas de utilização.
+func CreateHPA(namespace, name string, spec HPASpec, opts MutateOptions) (*autoscalingv2.HorizontalPodAutoscaler, error) {
+	hpa, err := BuildHPA(namespace, name, spec)
+	if err != nil {
+		return nil, err
+	}
+
+	deployment, err := client.AppsV1().Deployments(namespace).Get(context.TODO(), spec.Deployment, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("deployment não encontrado: %w", err)
+	}
+	if err := checkHPARequests(deployment, hpa); err != nil {
+		return nil, err
+	}
+	existing, err := deploymentAutoscaler(namespace, spec.Deployment)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, apierrors.NewConflict(autoscalingv2.Resource("horizontalpodautoscalers"), name,
+			fmt.Errorf("o deployment '%s' já é controlado pelo HPA '%s'", spec.Deployment, existing.Name))
+	}
+
+	created, err := client.AutoscalingV2().HorizontalPodAutoscalers(namespace).Create(context.TODO(), hpa, opts.createOptions())
+	if err != nil {
+		return nil, fmt.Errorf("erro ao criar hpa: %w", err)
+	}
+	return created, nil
+}
+
+// UpdateHPA substitui as réplicas mínima e máxima e as metas do HPA. O deployment alvo
+// e o comportamento (behavior) são mantidos; spec.Deployment é ignorado.
+func UpdateHPA(namespace, name string, spec HPASpec, opts MutateOptions) (*autoscalingv2.HorizontalPodAutoscaler, error) {
+	hpas := client.AutoscalingV2().HorizontalPodAutoscalers(namespace)
+	hpa, err := hpas.Get(context.TODO(), name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("hpa não encontrado: %w", err)
+	}
+	if err := setHPASpec(hpa, spec); err != nil {
+		return nil, err
+	}
+
+	if ref := hpa.Spec.ScaleTargetRef; ref.Kind == "Deployment" {
+		deployment, err := client.AppsV1().Deployments(namespace).Get(context.TODO(), ref.Name, metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("deployment não encontrado: %w", err)
+		}
+		if err := checkHPARequests(deployment, hpa); err != nil {
+			return nil, err
+		}
+	}
+
+	updated, err := hpas.Update(context.TODO(), hpa, opts.updateOptions())
+	if err != nil {
+		return nil, fmt.Errorf("erro ao atualizar hpa: %w", err)
+	}
+	return updated, nil
+}
+
+// DeleteHPA remove o HPA; o deployment mantém o número de réplicas atual.
+func DeleteHPA(name string, namespace string, opts MutateOptions) (*autoscalingv2.HorizontalPodAutoscaler, error) {
+	hpas := client.AutoscalingV2().HorizontalPodAutoscalers(namespace)
+	hpa, err := hpas.Get(context.Background(), name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("hpa não encontrado: %w", err)
+	}
+	if err := hpas.Delete(context.Background(), name, opts.deleteOptions(hpa.UID)); err != nil {
+		return nil, fmt.Errorf("erro ao deletar hpa: %w", err)
+	}
+	return hpa, nil
+}
+
+// checkManualScale recusa alterar as réplicas de um deployment controlado por um HPA,
+// já que o HPA desfaria a alteração na próxima avaliação. Um HPA sem métricas
+// (ScalingActive=False) não escala, e nesse caso a alteração manual é aceita.
+func checkManualScale(namespace, name string) error {
+	hpa, err := deploymentAutoscaler(namespace, name)
+	if err != nil {
+		// Sem permissão para ler HPAs, a escala segue como antes
+		log.Printf("⚠️ Não foi possível verificar HPAs do deployment %s/%s: %v", namespace, name, err)
+		return nil
+	}
+	if hpa == nil {
+		return nil
+	}
+	info := newHPAInfo(*hpa)
+	if !info.ScalingActive && hpaConditionKnown(*hpa, autoscalingv2.ScalingActive) {
+		return nil
+	}
+	return apierrors.NewConflict(appsv1.Resource("deployments"), name,
+		fmt.Errorf("as réplicas são controladas pelo HPA '%s' (min %d, max %d); altere o HPA ou remova-o",
+			hpa.Name, info.MinReplicas, info.MaxReplicas))
+}
+
+// deploymentAutoscaler devolve o HPA que escala o deployment, ou nil.
+func deploymentAutoscaler(namespace, name string) (*autoscalingv2.HorizontalPodAutoscaler, error) {
+	hpas, err := client.AutoscalingV2().HorizontalPodAutoscalers(namespace).List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("erro ao listar hpas: %w", err)
+	}
+	for i, hpa := range hpas.Items {
+		ref := hpa.Spec.ScaleTargetRef
+		if ref.Kind == "Deployment" && ref.Name == name && strings.HasPrefix(ref.APIVersion, appsv1.GroupName+"/") {
+			return &hpas.Items[i], nil
+		}
+	}
+	return nil, nil
+}
+
+func setHPASpec(hpa *autoscalingv2.HorizontalPodAutoscaler, spec HPASpec) error {
+	if spec.MaxReplicas < 1 {
+		return fmt.Errorf("%w: 'maxReplicas' deve ser maior que 0", ErrInvalidUpdate)
+	}
+	if spec.MinReplicas != nil && (*spec.MinReplicas < 1 || *spec.MinReplicas > spec.MaxReplicas) {
+		return fmt.Errorf("%w: 'minReplicas' deve estar entre 1 e maxReplicas", ErrInvalidUpdate)
+	}
+	if spec.CPUUtilization == nil && spec.MemoryUtilization == nil {
+		return fmt.Errorf("%w: informe 'cpuUtilization' e/ou 'memoryUtilization'", ErrInvalidUpdate)
+	}
+
+	var metrics []autoscalingv2.MetricSpec
+	for _, target := range []struct {
+		resource    v1.ResourceName
+		utilization *int32
+	}{{v1.ResourceCPU, spec.CPUUtilization}, {v1.ResourceMemory, spec.MemoryUtilization}} {
+		if target.utilization == nil {
+			continue
+		}
+		if *target.utilization < 1 {
+			return fmt.Errorf("%w: a utilização de %s deve ser maior que 0%%", ErrInvalidUpdate, target.resource)
+		}
+		metrics = append(metrics, autoscalingv2.MetricSpec{
+			Type: autoscalingv2.ResourceMetricSourceType,
+			Resource: &autoscalingv2.ResourceMetricSource{
+				Name: target.resource,
+				Target: autoscalingv2.MetricTarget{
+					Type:               autoscalingv2.UtilizationMetricType,
+					AverageUtilization: target.utilization,
+				},
+			},
+		})
+	}
+
+	hpa.Spec.MinReplicas = spec.MinReplicas
+	hpa.Spec.MaxReplicas = spec.MaxReplicas
+	hpa.Spec.Metrics = metrics
+	return nil
+}
+
+// checkHPARequests exige requests do recurso em todos os containers do deployment:
+// a utilização é calculada como uso / request, e sem request o HPA não consegue escalar.
+func checkHPARequests(deployment *appsv1.Deployment, hpa *autoscalingv2.HorizontalPodAutoscaler) error {
+	var missing []string
+	for _, metric := range hpa.Spec.Metrics {
+		if metric.Resource == nil || metric.Resource.Target.Type != autoscalingv2.UtilizationMetricType {
+			continue
+		}
+		for _, container := range deployment.Spec.Template.Spec.Containers {
+			if _, ok := container.Resources.Requests[metric.Resource.Name]; !ok {
+				missing = append(missing, fmt.Sprintf("%s (%s)", container.Name, metric.Resource.Name))
+			}
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("%w: metas de utilização exigem requests nos containers; faltam em %s",
+			ErrInvalidUpdate, strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+func newHPAInfo(hpa autoscalingv2.HorizontalPodAutoscaler) HPAInfo {
+	info := HPAInfo{
+		Nome:              hpa.Name,
+		Namespace:         hpa.Namespace,
+		Deployment:        hpa.Spec.ScaleTargetRef.Name,
+		MinReplicas:       1,
+		MaxReplicas:       hpa.Spec.MaxReplicas,
+		CurrentReplicas:   hpa.Status.CurrentReplicas,
+		DesiredReplicas:   hpa.Status.DesiredReplicas,
+		Metrics:           []HPAMetric{},
+		LastScaleTime:     hpa.Status.LastScaleTime,
+		Labels:            hpa.Labels,
+		CreationTimestamp: hpa.CreationTimestamp,
+		Age:               age(hpa.CreationTimestamp),
+	}
+	if hpa.Spec.MinReplicas != nil {
+		info.MinReplicas = *hpa.Spec.MinReplicas
+	}
+
+	current := make(map[v1.ResourceName]*int32)
+	for _, metric := range hpa.Status.CurrentMetrics {
+		if metric.Resource != nil {
+			current[metric.Resource.Name] = metric.Resource.Current.AverageUtilization
+		}
+	}
+	for _, metric := range hpa.Spec.Metrics {
+		if metric.Resource == nil || metric.Resource.Target.AverageUtilization == nil {
+			continue
+		}
+		info.Metrics = append(info.Metrics, HPAMetric{
+			Resource: string(metric.Resource.Name),
+			Target:   *metric.Resource.Target.AverageUtilization,
+			Current:  current[metric.Resource.Name],
+		})
+	}
+
+	for _, cond := range hpa.Status.Conditions {
+		info.Conditions = append(info.Conditions, HPACondition{
+			Type:    string(cond.Type),
+			Status:  string(cond.Status),
+			Reason:  cond.Reason,
+			Message: cond.Message,
+		})
+		switch cond.Type {
+		case autoscalingv2.ScalingActive:
+			info.ScalingActive = cond.Status == v1.ConditionTrue
+		case autoscalingv2.ScalingLimited:
+			info.ScalingLimited = cond.Status == v1.ConditionTrue
+		}
+	}
+	return info
+}
+
+// hpaConditionKnown indica se o controlador já avaliou a condição; um HPA recém-criado
+// ainda não tem condições e deve ser tratado como ativo.
+func hpaConditionKnown(hpa autoscalingv2.HorizontalPodAutoscaler, condType autoscalingv2.HorizontalPodAutoscalerConditionType) bool {
+	for _, cond := range hpa.Status.Conditions {
+		if cond.Type == condType {
+			return cond.Status != v1.ConditionUnknown
+		}
+	}
+	return false
+}
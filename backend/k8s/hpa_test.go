@@ -0,0 +1,195 @@
+package k8s
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func int32Ptr(v int32) *int32 { return &v }
+
+func TestSetHPASpec(t *testing.T) {
+	tests := []struct {
+		name        string
+		spec        HPASpec
+		wantMin     *int32
+		wantMetrics map[v1.ResourceName]int32
+		wantErr     bool
+	}{
+		{
+			name:        "cpu sem mínimo",
+			spec:        HPASpec{MaxReplicas: 5, CPUUtilization: int32Ptr(70)},
+			wantMetrics: map[v1.ResourceName]int32{v1.ResourceCPU: 70},
+		},
+		{
+			name:        "cpu e memória com mínimo",
+			spec:        HPASpec{MinReplicas: int32Ptr(2), MaxReplicas: 10, CPUUtilization: int32Ptr(60), MemoryUtilization: int32Ptr(80)},
+			wantMin:     int32Ptr(2),
+			wantMetrics: map[v1.ResourceName]int32{v1.ResourceCPU: 60, v1.ResourceMemory: 80},
+		},
+		{
+			name:        "mínimo igual ao máximo",
+			spec:        HPASpec{MinReplicas: int32Ptr(3), MaxReplicas: 3, MemoryUtilization: int32Ptr(75)},
+			wantMin:     int32Ptr(3),
+			wantMetrics: map[v1.ResourceName]int32{v1.ResourceMemory: 75},
+		},
+		{name: "máximo zero", spec: HPASpec{CPUUtilization: int32Ptr(70)}, wantErr: true},
+		{name: "mínimo zero", spec: HPASpec{MinReplicas: int32Ptr(0), MaxReplicas: 5, CPUUtilization: int32Ptr(70)}, wantErr: true},
+		{name: "mínimo acima do máximo", spec: HPASpec{MinReplicas: int32Ptr(6), MaxReplicas: 5, CPUUtilization: int32Ptr(70)}, wantErr: true},
+		{name: "sem métricas", spec: HPASpec{MaxReplicas: 5}, wantErr: true},
+		{name: "utilização zero", spec: HPASpec{MaxReplicas: 5, CPUUtilization: int32Ptr(0)}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Métricas anteriores são substituídas pelas do spec
+			hpa := &autoscalingv2.HorizontalPodAutoscaler{Spec: autoscalingv2.HorizontalPodAutoscalerSpec{
+				MinReplicas: int32Ptr(9),
+				Metrics:     []autoscalingv2.MetricSpec{{Type: autoscalingv2.PodsMetricSourceType}},
+			}}
+			err := setHPASpec(hpa, tt.spec)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidUpdate) {
+					t.Fatalf("setHPASpec() erro = %v, esperado ErrInvalidUpdate", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("setHPASpec() erro inesperado: %v", err)
+			}
+
+			if hpa.Spec.MaxReplicas != tt.spec.MaxReplicas {
+				t.Errorf("maxReplicas = %d, esperado %d", hpa.Spec.MaxReplicas, tt.spec.MaxReplicas)
+			}
+			if (hpa.Spec.MinReplicas == nil) != (tt.wantMin == nil) || (tt.wantMin != nil && *hpa.Spec.MinReplicas != *tt.wantMin) {
+				t.Errorf("minReplicas = %v, esperado %v", hpa.Spec.MinReplicas, tt.wantMin)
+			}
+			if len(hpa.Spec.Metrics) != len(tt.wantMetrics) {
+				t.Fatalf("metrics = %+v, esperado %v", hpa.Spec.Metrics, tt.wantMetrics)
+			}
+			for _, metric := range hpa.Spec.Metrics {
+				target := metric.Resource.Target
+				if metric.Type != autoscalingv2.ResourceMetricSourceType || target.Type != autoscalingv2.UtilizationMetricType ||
+					target.AverageUtilization == nil || *target.AverageUtilization != tt.wantMetrics[metric.Resource.Name] {
+					t.Errorf("métrica %s = %+v, esperado utilização de %d%%", metric.Resource.Name, target, tt.wantMetrics[metric.Resource.Name])
+				}
+			}
+		})
+	}
+}
+
+func TestCheckHPARequests(t *testing.T) {
+	container := func(name string, requests ...v1.ResourceName) v1.Container {
+		c := v1.Container{Name: name, Resources: v1.ResourceRequirements{Requests: v1.ResourceList{}}}
+		for _, r := range requests {
+			c.Resources.Requests[r] = resource.MustParse("100m")
+		}
+		return c
+	}
+	deployment := func(containers ...v1.Container) *appsv1.Deployment {
+		d := &appsv1.Deployment{}
+		d.Spec.Template.Spec.Containers = containers
+		return d
+	}
+	hpa := func(spec HPASpec) *autoscalingv2.HorizontalPodAutoscaler {
+		h := &autoscalingv2.HorizontalPodAutoscaler{}
+		if err := setHPASpec(h, spec); err != nil {
+			t.Fatalf("setHPASpec() erro inesperado: %v", err)
+		}
+		return h
+	}
+	cpu := HPASpec{MaxReplicas: 3, CPUUtilization: int32Ptr(70)}
+	cpuAndMemory := HPASpec{MaxReplicas: 3, CPUUtilization: int32Ptr(70), MemoryUtilization: int32Ptr(80)}
+
+	tests := []struct {
+		name        string
+		deployment  *appsv1.Deployment
+		hpa         *autoscalingv2.HorizontalPodAutoscaler
+		wantMissing []string
+	}{
+		{
+			name:       "todos os containers com request de cpu",
+			deployment: deployment(container("app", v1.ResourceCPU), container("sidecar", v1.ResourceCPU)),
+			hpa:        hpa(cpu),
+		},
+		{
+			name:        "sidecar sem request de cpu",
+			deployment:  deployment(container("app", v1.ResourceCPU), container("sidecar")),
+			hpa:         hpa(cpu),
+			wantMissing: []string{"sidecar (cpu)"},
+		},
+		{
+			name:        "faltando memória",
+			deployment:  deployment(container("app", v1.ResourceCPU)),
+			hpa:         hpa(cpuAndMemory),
+			wantMissing: []string{"app (memory)"},
+		},
+		{
+			name:       "métricas sem utilização não exigem requests",
+			deployment: deployment(container("app")),
+			hpa: &autoscalingv2.HorizontalPodAutoscaler{Spec: autoscalingv2.HorizontalPodAutoscalerSpec{
+				Metrics: []autoscalingv2.MetricSpec{{
+					Type: autoscalingv2.ResourceMetricSourceType,
+					Resource: &autoscalingv2.ResourceMetricSource{
+						Name:   v1.ResourceCPU,
+						Target: autoscalingv2.MetricTarget{Type: autoscalingv2.AverageValueMetricType, AverageValue: resource.NewMilliQuantity(500, resource.DecimalSI)},
+					},
+				}},
+			}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkHPARequests(tt.deployment, tt.hpa)
+			if len(tt.wantMissing) == 0 {
+				if err != nil {
+					t.Fatalf("checkHPARequests() erro inesperado: %v", err)
+				}
+				return
+			}
+			if !errors.Is(err, ErrInvalidUpdate) {
+				t.Fatalf("checkHPARequests() erro = %v, esperado ErrInvalidUpdate", err)
+			}
+			for _, missing := range tt.wantMissing {
+				if !strings.Contains(err.Error(), missing) {
+					t.Errorf("checkHPARequests() erro = %q, esperado mencionar %s", err, missing)
+				}
+			}
+		})
+	}
+}
+
+func TestHPAConditionKnown(t *testing.T) {
+	hpa := func(conditions ...autoscalingv2.HorizontalPodAutoscalerCondition) autoscalingv2.HorizontalPodAutoscaler {
+		return autoscalingv2.HorizontalPodAutoscaler{Status: autoscalingv2.HorizontalPodAutoscalerStatus{Conditions: conditions}}
+	}
+	condition := func(condType autoscalingv2.HorizontalPodAutoscalerConditionType, status v1.ConditionStatus) autoscalingv2.HorizontalPodAutoscalerCondition {
+		return autoscalingv2.HorizontalPodAutoscalerCondition{Type: condType, Status: status}
+	}
+
+	tests := []struct {
+		name string
+		hpa  autoscalingv2.HorizontalPodAutoscaler
+		want bool
+	}{
+		{name: "hpa recém-criado sem condições", hpa: hpa(), want: false},
+		{name: "condição verdadeira", hpa: hpa(condition(autoscalingv2.ScalingActive, v1.ConditionTrue)), want: true},
+		{name: "condição falsa", hpa: hpa(condition(autoscalingv2.ScalingActive, v1.ConditionFalse)), want: true},
+		{name: "condição desconhecida", hpa: hpa(condition(autoscalingv2.ScalingActive, v1.ConditionUnknown)), want: false},
+		{name: "só outras condições", hpa: hpa(condition(autoscalingv2.AbleToScale, v1.ConditionTrue)), want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := hpaConditionKnown(tt.hpa, autoscalingv2.ScalingActive); got != tt.want {
+				t.Errorf("hpaConditionKnown() = %v, esperado %v", got, tt.want)
+			}
+		})
+	}
+}
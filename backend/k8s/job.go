@@ -0,0 +1,288 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// JobInfo resume um Job e o resultado dos seus pods. Duration vai do início até a
+// conclusão ou, com o Job ainda ativo, até agora.
+// Status é calculado por jobStatus: Complete, Failed, Suspended, Running ou Pending.
+type JobInfo struct {
+	Nome              string            `json:"nome"`
+	Namespace         string            `json:"namespace"`
+	Status            string            `json:"status"`
+	Image             string            `json:"image"`
+	Completions       *int32            `json:"completions,omitempty"`
+	Parallelism       *int32            `json:"parallelism,omitempty"`
+	Active            int32             `json:"active"`
+	Ready             int32             `json:"ready"`
+	Succeeded         int32             `json:"succeeded"`
+	Failed            int32             `json:"failed"`
+	StartTime         *metav1.Time      `json:"startTime,omitempty"`
+	CompletionTime    *metav1.Time      `json:"completionTime,omitempty"`
+	Duration          string            `json:"duration,omitempty"`
+	Owner             *OwnerInfo        `json:"owner,omitempty"`
+	Labels            map[string]string `json:"labels,omitempty"`
+	CreationTimestamp metav1.Time       `json:"creationTimestamp"`
+	Age               string            `json:"age"`
+}
+
+// JobDetail é o modelo completo de um Job, com as condições, os pods e eventos recentes.
+type JobDetail struct {
+	JobInfo
+	BackoffLimit            *int32             `json:"backoffLimit,omitempty"`
+	TTLSecondsAfterFinished *int32             `json:"ttlSecondsAfterFinished,omitempty"`
+	ActiveDeadlineSeconds   *int64             `json:"activeDeadlineSeconds,omitempty"`
+	Command                 []string           `json:"command,omitempty"`
+	Args                    []string           `json:"args,omitempty"`
+	Annotations             map[string]string  `json:"annotations,omitempty"`
+	Conditions              []JobConditionInfo `json:"conditions,omitempty"`
+	Pods                    []PodInfo          `json:"pods"`
+	Events                  []EventInfo        `json:"events"`
+}
+
+type JobConditionInfo struct {
+	Type               string      `json:"type"`
+	Status             string      `json:"status"`
+	Reason             string      `json:"reason,omitempty"`
+	Message            string      `json:"message,omitempty"`
+	LastTransitionTime metav1.Time `json:"lastTransitionTime"`
+}
+
+// JobSpec reúne os parâmetros de criação de um Job. Campos nil usam os padrões do
+// Kubernetes (backoffLimit 6, completions e parallelism 1, sem TTL).
+type JobSpec struct {
+	Image                   string            `json:"image"`
+	Command                 []string          `json:"command,omitempty"`
+	Args                    []string          `json:"args,omitempty"`
+	Env                     map[string]string `json:"env,omitempty"`
+	BackoffLimit            *int32            `json:"backoffLimit,omitempty"`
+	Completions             *int32            `json:"completions,omitempty"`
+	Parallelism             *int32            `json:"parallelism,omitempty"`
+	TTLSecondsAfterFinished *int32            `json:"ttlSecondsAfterFinished,omitempty"`
+}
+
+func (j JobInfo) GetNamespace() string { return j.Namespace }
+func (j JobInfo) GetName() string      { return j.Nome }
+
+// ListJobs retorna uma página de JobInfo do namespace informado (ou de todos, com AllNamespaces).
+func ListJobs(namespace string, opts ListOptions) (ListResult[JobInfo], error) {
+	return listNamespacedPage(namespace, opts, listJobs)
+}
+
+func listJobs(namespace string, listOpts metav1.ListOptions) ([]JobInfo, metav1.ListMeta, error) {
+	jobs, err := client.BatchV1().Jobs(namespace).List(context.TODO(), listOpts)
+	if err != nil {
+		return nil, metav1.ListMeta{}, fmt.Errorf("erro ao listar jobs: %w", err)
+	}
+
+	infos := make([]JobInfo, 0, len(jobs.Items))
+	for _, job := range jobs.Items {
+		infos = append(infos, newJobInfo(job))
+	}
+	return infos, jobs.ListMeta, nil
+}
+
+// GetJob retorna os detalhes de um Job.
+func GetJob(namespace, name string) (JobDetail, error) {
+	job, err := client.BatchV1().Jobs(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+	if err != nil {
+		return JobDetail{}, fmt.Errorf("job não encontrado: %w", err)
+	}
+
+	detail := JobDetail{
+		JobInfo:                 newJobInfo(*job),
+		BackoffLimit:            job.Spec.BackoffLimit,
+		TTLSecondsAfterFinished: job.Spec.TTLSecondsAfterFinished,
+		ActiveDeadlineSeconds:   job.Spec.ActiveDeadlineSeconds,
+		Annotations:             withoutLastApplied(job.Annotations),
+		Pods:                    []PodInfo{},
+	}
+	if containers := job.Spec.Template.Spec.Containers; len(containers) > 0 {
+		detail.Command = containers[0].Command
+		detail.Args = containers[0].Args
+	}
+	for _, cond := range job.Status.Conditions {
+		detail.Conditions = append(detail.Conditions, JobConditionInfo{
+			Type:               string(cond.Type),
+			Status:             string(cond.Status),
+			Reason:             cond.Reason,
+			Message:            cond.Message,
+			LastTransitionTime: cond.LastTransitionTime,
+		})
+	}
+
+	pods, err := jobPods(job)
+	if err != nil {
+		return detail, err
+	}
+	for _, pod := range pods {
+		detail.Pods = append(detail.Pods, newPodInfo(pod))
+	}
+
+	detail.Events = detailEvents(namespace, batchv1.SchemeGroupVersion.WithKind("Job"), job.Name, job.UID)
+	return detail, nil
+}
+
+// BuildJob monta um Job com um container. Os pods não são reiniciados no lugar
+// (restartPolicy Never); novas tentativas criam novos pods, até backoffLimit.
+func BuildJob(namespace, name string, spec JobSpec) *batchv1.Job {
+	return &batchv1.Job{
+		TypeMeta: metav1.TypeMeta{APIVersion: "batch/v1", Kind: "Job"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit:            spec.BackoffLimit,
+			Completions:             spec.Completions,
+			Parallelism:             spec.Parallelism,
+			TTLSecondsAfterFinished: spec.TTLSecondsAfterFinished,
+			Template:                jobPodTemplate(name, spec),
+		},
+	}
+}
+
+func jobPodTemplate(name string, spec JobSpec) v1.PodTemplateSpec {
+	// BuildDeployment já monta o container com as variáveis de ambiente
+	container := BuildDeployment("", name, spec.Image, 1, 0, spec.Env).Spec.Template.Spec.Containers[0]
+	container.Command = spec.Command
+	container.Args = spec.Args
+	return v1.PodTemplateSpec{
+		Spec: v1.PodSpec{
+			Containers:    []v1.Container{container},
+			RestartPolicy: v1.RestartPolicyNever,
+		},
+	}
+}
+
+// DeleteJob remove o Job e, em segundo plano, os seus pods.
+func DeleteJob(name string, namespace string, opts MutateOptions) (*batchv1.Job, error) {
+	jobs := client.BatchV1().Jobs(namespace)
+	job, err := jobs.Get(context.Background(), name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("job não encontrado: %w", err)
+	}
+	if err := jobs.Delete(context.Background(), name, opts.cascadeDeleteOptions(job.UID)); err != nil {
+		return nil, fmt.Errorf("erro ao deletar job: %w", err)
+	}
+	return job, nil
+}
+
+// StreamJobLogs junta os logs dos pods do Job, inclusive dos que já terminaram.
+func StreamJobLogs(ctx context.Context, namespace, name string, opts LogOptions, send func(LogLine) error) error {
+	job, err := client.BatchV1().Jobs(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("job não encontrado: %w", err)
+	}
+	pods, err := jobPods(job)
+	if err != nil {
+		return err
+	}
+	if len(pods) == 0 {
+		return fmt.Errorf("%w: job '%s' não possui pods (ainda não iniciou ou os pods já foram removidos)", ErrNoPods, name)
+	}
+	return streamPodsLogs(ctx, namespace, pods, opts, send)
+}
+
+func newJobInfo(job batchv1.Job) JobInfo {
+	info := JobInfo{
+		Nome:              job.Name,
+		Namespace:         job.Namespace,
+		Status:            jobStatus(job),
+		Completions:       job.Spec.Completions,
+		Parallelism:       job.Spec.Parallelism,
+		Active:            job.Status.Active,
+		Succeeded:         job.Status.Succeeded,
+		Failed:            job.Status.Failed,
+		StartTime:         job.Status.StartTime,
+		CompletionTime:    job.Status.CompletionTime,
+		Owner:             controllerOwner(job.ObjectMeta),
+		Labels:            job.Labels,
+		CreationTimestamp: job.CreationTimestamp,
+		Age:               age(job.CreationTimestamp),
+	}
+	if job.Status.Ready != nil {
+		info.Ready = *job.Status.Ready
+	}
+	if containers := job.Spec.Template.Spec.Containers; len(containers) > 0 {
+		info.Image = containers[0].Image
+	}
+
+	if job.Status.StartTime != nil {
+		end := time.Now()
+		if job.Status.CompletionTime != nil {
+			end = job.Status.CompletionTime.Time
+		} else if finished := jobFinishedAt(job); !finished.IsZero() {
+			end = finished
+		}
+		info.Duration = end.Sub(job.Status.StartTime.Time).Round(time.Second).String()
+	}
+	return info
+}
+
+// jobStatus resume o estado do Job em um único valor:
+//   - Complete: todas as conclusões foram atingidas
+//   - Failed: o Job desistiu (backoffLimit, activeDeadlineSeconds...)
+//   - Suspended: o Job está suspenso
+//   - Running: há pods ativos
+//   - Pending: ainda não há pods ativos
+func jobStatus(job batchv1.Job) string {
+	for _, cond := range job.Status.Conditions {
+		if cond.Status != v1.ConditionTrue {
+			continue
+		}
+		switch cond.Type {
+		case batchv1.JobComplete:
+			return "Complete"
+		case batchv1.JobFailed:
+			return "Failed"
+		case batchv1.JobSuspended:
+			return "Suspended"
+		}
+	}
+	if job.Status.Active > 0 {
+		return "Running"
+	}
+	return "Pending"
+}
+
+// jobFinishedAt devolve o momento em que o Job falhou; Jobs com falha não têm completionTime.
+func jobFinishedAt(job batchv1.Job) time.Time {
+	for _, cond := range job.Status.Conditions {
+		if cond.Type == batchv1.JobFailed && cond.Status == v1.ConditionTrue {
+			return cond.LastTransitionTime.Time
+		}
+	}
+	return time.Time{}
+}
+
+// jobPods retorna os pods criados pelo Job, do mais antigo para o mais novo.
+func jobPods(job *batchv1.Job) ([]v1.Pod, error) {
+	selector, err := metav1.LabelSelectorAsSelector(job.Spec.Selector)
+	if err != nil {
+		return nil, fmt.Errorf("seletor inválido: %w", err)
+	}
+	list, err := client.CoreV1().Pods(job.Namespace).List(context.TODO(), metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return nil, fmt.Errorf("erro ao listar pods: %w", err)
+	}
+
+	var owned []v1.Pod
+	for _, pod := range list.Items {
+		if ref := metav1.GetControllerOfNoCopy(&pod); ref != nil && ref.UID == job.UID {
+			owned = append(owned, pod)
+		}
+	}
+	sort.SliceStable(owned, func(i, j int) bool {
+		return owned[i].CreationTimestamp.Before(&owned[j].CreationTimestamp)
+	})
+	return owned, nil
+}
@@ -5,46 +5,188 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"time"
 
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/duration"
 	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 type PodInfo struct {
-	Nome      string `json:"nome"`
-	Namespace string `json:"namespace"`
-	Status    string `json:"status"`
-	IP        string `json:"ip"`
-	Node      string `json:"node"`
-	Image     string `json:"image"`
+	Nome              string             `json:"nome"`
+	Namespace         string             `json:"namespace"`
+	Status            string             `json:"status"`
+	Reason            string             `json:"reason,omitempty"`
+	Ready             bool               `json:"ready"`
+	ReadyContainers   int                `json:"readyContainers"`
+	TotalContainers   int                `json:"totalContainers"`
+	IP                string             `json:"ip"`
+	Node              string             `json:"node"`
+	Image             string             `json:"image"`
+	Restarts          int32              `json:"restarts"`
+	QOSClass          string             `json:"qosClass"`
+	Owner             *OwnerInfo         `json:"owner,omitempty"`
+	Labels            map[string]string  `json:"labels,omitempty"`
+	Containers        []ContainerInfo    `json:"containers"`
+	InitContainers    []ContainerInfo    `json:"initContainers,omitempty"`
+	Conditions        []PodConditionInfo `json:"conditions,omitempty"`
+	CreationTimestamp metav1.Time        `json:"creationTimestamp"`
+	Age               string             `json:"age"`
 }
+
+// ContainerInfo descreve um container (ou init container) de um pod e seu status atual.
+// State é Running, Waiting, Terminated ou Unknown; Reason traz o motivo de Waiting/Terminated
+// (ex.: CrashLoopBackOff, OOMKilled).
+type ContainerInfo struct {
+	Name            string           `json:"name"`
+	Image           string           `json:"image"`
+	Ready           bool             `json:"ready"`
+	RestartCount    int32            `json:"restartCount"`
+	State           string           `json:"state"`
+	Reason          string           `json:"reason,omitempty"`
+	Message         string           `json:"message,omitempty"`
+	ExitCode        *int32           `json:"exitCode,omitempty"`
+	StartedAt       *metav1.Time     `json:"startedAt,omitempty"`
+	LastTermination *TerminationInfo `json:"lastTermination,omitempty"`
+}
+
+// TerminationInfo resume a última finalização de um container.
+type TerminationInfo struct {
+	Reason     string      `json:"reason,omitempty"`
+	Message    string      `json:"message,omitempty"`
+	ExitCode   int32       `json:"exitCode"`
+	StartedAt  metav1.Time `json:"startedAt"`
+	FinishedAt metav1.Time `json:"finishedAt"`
+}
+
+type PodConditionInfo struct {
+	Type               string      `json:"type"`
+	Status             string      `json:"status"`
+	Reason             string      `json:"reason,omitempty"`
+	Message            string      `json:"message,omitempty"`
+	LastTransitionTime metav1.Time `json:"lastTransitionTime"`
+}
+
+// OwnerInfo identifica o controlador dono de um objeto (ex.: ReplicaSet de um pod).
+type OwnerInfo struct {
+	Kind string `json:"kind"`
+	Name string `json:"name"`
+}
+
+// DeploymentInfo resume um deployment e o progresso do seu rollout.
+// Status é calculado por deploymentStatus: Ready, Progressing, Degraded, Paused ou ScaledToZero.
 type DeploymentInfo struct {
-	Nome          string            `json:"nome"`
-	Namespace     string            `json:"namespace"`
-	Status        string            `json:"status"`
-	Image         string            `json:"image"`
-	Replicas      int32             `json:"replicas"`
-	ContainerPort int32             `json:"containerPort"`
-	Selector      map[string]string `json:"selector"`
+	Nome                string                    `json:"nome"`
+	Namespace           string                    `json:"namespace"`
+	Status              string                    `json:"status"`
+	Image               string                    `json:"image"`
+	Replicas            int32                     `json:"replicas"`
+	ReadyReplicas       int32                     `json:"readyReplicas"`
+	UpdatedReplicas     int32                     `json:"updatedReplicas"`
+	AvailableReplicas   int32                     `json:"availableReplicas"`
+	UnavailableReplicas int32                     `json:"unavailableReplicas"`
+	Generation          int64                     `json:"generation"`
+	ObservedGeneration  int64                     `json:"observedGeneration"`
+	Strategy            string                    `json:"strategy"`
+	MaxSurge            string                    `json:"maxSurge,omitempty"`
+	MaxUnavailable      string                    `json:"maxUnavailable,omitempty"`
+	ContainerPort       int32                     `json:"containerPort"`
+	Containers          []ContainerSpecInfo       `json:"containers"`
+	Conditions          []DeploymentConditionInfo `json:"conditions,omitempty"`
+	Selector            map[string]string         `json:"selector"`
+	Labels              map[string]string         `json:"labels,omitempty"`
+	CreationTimestamp   metav1.Time               `json:"creationTimestamp"`
+	Age                 string                    `json:"age"`
 }
+
+// ContainerSpecInfo descreve um container do template de um workload.
+type ContainerSpecInfo struct {
+	Name  string              `json:"name"`
+	Image string              `json:"image"`
+	Ports []ContainerPortInfo `json:"ports,omitempty"`
+}
+
+type ContainerPortInfo struct {
+	Name          string `json:"name,omitempty"`
+	ContainerPort int32  `json:"containerPort"`
+	Protocol      string `json:"protocol"`
+}
+
+type DeploymentConditionInfo struct {
+	Type               string      `json:"type"`
+	Status             string      `json:"status"`
+	Reason             string      `json:"reason,omitempty"`
+	Message            string      `json:"message,omitempty"`
+	LastUpdateTime     metav1.Time `json:"lastUpdateTime"`
+	LastTransitionTime metav1.Time `json:"lastTransitionTime"`
+}
+
+// ServiceInfo resume um service. Port, TargetPort, ExternalIP e LoadBalancerIP
+// refletem apenas a primeira porta/endereço; a lista completa está em Ports,
+// ExternalIPs e LoadBalancer.
 type ServiceInfo struct {
-	Nome           string            `json:"nome"`
-	Port           int32             `json:"port"`
-	TargetPort     int32             `json:"targetPort"`
-	Selector       map[string]string `json:"selector"`
-	Type           string            `json:"type"`
-	Namespace      string            `json:"namespace"`
-	ClusterIP      string            `json:"clusterIP"`
-	ExternalIP     string            `json:"externalIP"`
-	LoadBalancerIP string            `json:"loadBalancerIP"`
-}
-
-// ListarPods agora retorna um slice de PodInfo e um erro.
-func ListPods(namespace string) ([]PodInfo, error) {
-	pods, err := client.CoreV1().Pods(namespace).List(context.TODO(), metav1.ListOptions{})
+	Nome              string                `json:"nome"`
+	Port              int32                 `json:"port"`
+	TargetPort        int32                 `json:"targetPort"`
+	Ports             []ServicePortInfo     `json:"ports"`
+	Selector          map[string]string     `json:"selector"`
+	Type              string                `json:"type"`
+	Namespace         string                `json:"namespace"`
+	ClusterIP         string                `json:"clusterIP"`
+	ExternalIP        string                `json:"externalIP"`
+	ExternalIPs       []string              `json:"externalIPs,omitempty"`
+	ExternalName      string                `json:"externalName,omitempty"`
+	LoadBalancerIP    string                `json:"loadBalancerIP"`
+	LoadBalancer      []LoadBalancerAddress `json:"loadBalancer,omitempty"`
+	Endpoints         *EndpointCounts       `json:"endpoints,omitempty"`
+	Labels            map[string]string     `json:"labels,omitempty"`
+	CreationTimestamp metav1.Time           `json:"creationTimestamp"`
+	Age               string                `json:"age"`
+}
+
+// ServicePortInfo descreve uma porta do service. TargetPort é serializado como
+// número ou como o nome da porta do container, conforme definido no spec.
+type ServicePortInfo struct {
+	Name       string             `json:"name,omitempty"`
+	Protocol   string             `json:"protocol"`
+	Port       int32              `json:"port"`
+	TargetPort intstr.IntOrString `json:"targetPort"`
+	NodePort   int32              `json:"nodePort,omitempty"`
+}
+
+type LoadBalancerAddress struct {
+	IP       string `json:"ip,omitempty"`
+	Hostname string `json:"hostname,omitempty"`
+}
+
+// EndpointCounts conta os endpoints prontos e não prontos das EndpointSlices de um service.
+type EndpointCounts struct {
+	Ready    int `json:"ready"`
+	NotReady int `json:"notReady"`
+}
+
+// Implementações de Namespaced usadas na listagem de todos os namespaces.
+func (p PodInfo) GetNamespace() string        { return p.Namespace }
+func (p PodInfo) GetName() string             { return p.Nome }
+func (d DeploymentInfo) GetNamespace() string { return d.Namespace }
+func (d DeploymentInfo) GetName() string      { return d.Nome }
+func (s ServiceInfo) GetNamespace() string    { return s.Namespace }
+func (s ServiceInfo) GetName() string         { return s.Nome }
+
+// ListPods retorna uma página de PodInfo do namespace informado (ou de todos, com AllNamespaces).
+func ListPods(namespace string, opts ListOptions) (ListResult[PodInfo], error) {
+	return listNamespacedPage(namespace, opts, listPods)
+}
+
+func listPods(namespace string, listOpts metav1.ListOptions) ([]PodInfo, metav1.ListMeta, error) {
+	pods, err := client.CoreV1().Pods(namespace).List(context.TODO(), listOpts)
 	if err != nil {
 		// Retorna um slice vazio e o erro
-		return nil, fmt.Errorf("erro ao listar pods: %w", err)
+		return nil, metav1.ListMeta{}, fmt.Errorf("erro ao listar pods: %w", err)
 	}
 
 	// Cria um slice de PodInfo para armazenar os resultados
@@ -52,131 +194,532 @@ func ListPods(namespace string) ([]PodInfo, error) {
 
 	// Itera sobre a lista de pods retornada pela API
 	for _, pod := range pods.Items {
-		info := PodInfo{
-			Nome:      pod.Name,
-			Namespace: pod.Namespace,
-			Status:    string(pod.Status.Phase), // pod.Status.Phase é do tipo v1.PodPhase
-			IP:        pod.Status.PodIP,
-			Node:      pod.Spec.NodeName,
-			Image:     pod.Spec.Containers[0].Image,
-		}
-		podsInfo = append(podsInfo, info)
+		podsInfo = append(podsInfo, newPodInfo(pod))
 	}
 
 	// Retorna o slice preenchido e nenhum erro
-	return podsInfo, nil
+	return podsInfo, pods.ListMeta, nil
+}
+
+// newPodInfo converte um pod da API no modelo exposto pelo backend.
+func newPodInfo(pod v1.Pod) PodInfo {
+	info := PodInfo{
+		Nome:              pod.Name,
+		Namespace:         pod.Namespace,
+		Status:            string(pod.Status.Phase), // pod.Status.Phase é do tipo v1.PodPhase
+		Reason:            pod.Status.Reason,
+		IP:                pod.Status.PodIP,
+		Node:              pod.Spec.NodeName,
+		QOSClass:          string(pod.Status.QOSClass),
+		Owner:             controllerOwner(pod.ObjectMeta),
+		Labels:            pod.Labels,
+		Containers:        containerInfos(pod.Spec.Containers, pod.Status.ContainerStatuses),
+		InitContainers:    containerInfos(pod.Spec.InitContainers, pod.Status.InitContainerStatuses),
+		TotalContainers:   len(pod.Spec.Containers),
+		CreationTimestamp: pod.CreationTimestamp,
+		Age:               age(pod.CreationTimestamp),
+	}
+	if len(pod.Spec.Containers) > 0 {
+		info.Image = pod.Spec.Containers[0].Image
+	}
+	if pod.DeletionTimestamp != nil {
+		info.Reason = "Terminating"
+	}
+
+	for _, c := range info.Containers {
+		info.Restarts += c.RestartCount
+		if c.Ready {
+			info.ReadyContainers++
+		}
+		// Como o kubectl, mostra o motivo do primeiro container com problema (ex.: CrashLoopBackOff)
+		if info.Reason == "" && c.Reason != "" && c.Reason != "Completed" {
+			info.Reason = c.Reason
+		}
+	}
+
+	for _, cond := range pod.Status.Conditions {
+		if cond.Type == v1.PodReady {
+			info.Ready = cond.Status == v1.ConditionTrue
+		}
+		info.Conditions = append(info.Conditions, PodConditionInfo{
+			Type:               string(cond.Type),
+			Status:             string(cond.Status),
+			Reason:             cond.Reason,
+			Message:            cond.Message,
+			LastTransitionTime: cond.LastTransitionTime,
+		})
+	}
+	return info
+}
+
+// containerInfos combina a especificação dos containers com seus status, na ordem do spec.
+func containerInfos(containers []v1.Container, statuses []v1.ContainerStatus) []ContainerInfo {
+	byName := make(map[string]v1.ContainerStatus, len(statuses))
+	for _, cs := range statuses {
+		byName[cs.Name] = cs
+	}
+
+	infos := make([]ContainerInfo, 0, len(containers))
+	for _, c := range containers {
+		info := ContainerInfo{Name: c.Name, Image: c.Image, State: "Unknown"}
+		if cs, ok := byName[c.Name]; ok {
+			info.Ready = cs.Ready
+			info.RestartCount = cs.RestartCount
+			switch {
+			case cs.State.Running != nil:
+				info.State = "Running"
+				info.StartedAt = &cs.State.Running.StartedAt
+			case cs.State.Waiting != nil:
+				info.State = "Waiting"
+				info.Reason = cs.State.Waiting.Reason
+				info.Message = cs.State.Waiting.Message
+			case cs.State.Terminated != nil:
+				info.State = "Terminated"
+				info.Reason = cs.State.Terminated.Reason
+				info.Message = cs.State.Terminated.Message
+				info.ExitCode = &cs.State.Terminated.ExitCode
+				info.StartedAt = &cs.State.Terminated.StartedAt
+			}
+			if last := cs.LastTerminationState.Terminated; last != nil {
+				info.LastTermination = &TerminationInfo{
+					Reason:     last.Reason,
+					Message:    last.Message,
+					ExitCode:   last.ExitCode,
+					StartedAt:  last.StartedAt,
+					FinishedAt: last.FinishedAt,
+				}
+			}
+		}
+		infos = append(infos, info)
+	}
+	return infos
+}
+
+// controllerOwner devolve a referência do controlador do objeto, se houver.
+func controllerOwner(meta metav1.ObjectMeta) *OwnerInfo {
+	if ref := metav1.GetControllerOfNoCopy(&meta); ref != nil {
+		return &OwnerInfo{Kind: ref.Kind, Name: ref.Name}
+	}
+	return nil
+}
+
+// age formata há quanto tempo o objeto existe, no mesmo formato do kubectl (ex.: 5d3h).
+func age(created metav1.Time) string {
+	if created.IsZero() {
+		return ""
+	}
+	return duration.HumanDuration(time.Since(created.Time))
 }
 
-func ListDeployments(namespace string) ([]DeploymentInfo, error) {
-	deployments, err := client.AppsV1().Deployments(namespace).List(context.TODO(), metav1.ListOptions{})
+// ListDeployments retorna uma página de DeploymentInfo do namespace informado (ou de todos, com AllNamespaces).
+func ListDeployments(namespace string, opts ListOptions) (ListResult[DeploymentInfo], error) {
+	return listNamespacedPage(namespace, opts, listDeployments)
+}
+
+func listDeployments(namespace string, listOpts metav1.ListOptions) ([]DeploymentInfo, metav1.ListMeta, error) {
+	deployments, err := client.AppsV1().Deployments(namespace).List(context.TODO(), listOpts)
 	if err != nil {
-		return nil, fmt.Errorf("erro ao listar deployments: %w", err)
+		return nil, metav1.ListMeta{}, fmt.Errorf("erro ao listar deployments: %w", err)
 	}
 
 	var deploymentsInfo []DeploymentInfo
 
 	for _, deployment := range deployments.Items {
-		var containerPort int32 = 0
-		if len(deployment.Spec.Template.Spec.Containers) > 0 {
-			if len(deployment.Spec.Template.Spec.Containers[0].Ports) > 0 {
-				containerPort = deployment.Spec.Template.Spec.Containers[0].Ports[0].ContainerPort
-			}
+		deploymentsInfo = append(deploymentsInfo, newDeploymentInfo(deployment))
+	}
+	return deploymentsInfo, deployments.ListMeta, nil
+}
+
+// newDeploymentInfo converte um deployment da API no modelo exposto pelo backend.
+func newDeploymentInfo(deployment appsv1.Deployment) DeploymentInfo {
+	var replicas int32 = 0
+	if deployment.Spec.Replicas != nil {
+		replicas = *deployment.Spec.Replicas
+	}
+
+	info := DeploymentInfo{
+		Nome:                deployment.Name,
+		Namespace:           deployment.Namespace,
+		Status:              deploymentStatus(deployment, replicas),
+		Replicas:            replicas,
+		ReadyReplicas:       deployment.Status.ReadyReplicas,
+		UpdatedReplicas:     deployment.Status.UpdatedReplicas,
+		AvailableReplicas:   deployment.Status.AvailableReplicas,
+		UnavailableReplicas: deployment.Status.UnavailableReplicas,
+		Generation:          deployment.Generation,
+		ObservedGeneration:  deployment.Status.ObservedGeneration,
+		Strategy:            string(deployment.Spec.Strategy.Type),
+		Containers:          containerSpecInfos(deployment.Spec.Template.Spec.Containers),
+		Labels:              deployment.Labels,
+		CreationTimestamp:   deployment.CreationTimestamp,
+		Age:                 age(deployment.CreationTimestamp),
+	}
+	if deployment.Spec.Selector != nil {
+		info.Selector = deployment.Spec.Selector.MatchLabels
+	}
+	if ru := deployment.Spec.Strategy.RollingUpdate; ru != nil {
+		if ru.MaxSurge != nil {
+			info.MaxSurge = ru.MaxSurge.String()
+		}
+		if ru.MaxUnavailable != nil {
+			info.MaxUnavailable = ru.MaxUnavailable.String()
 		}
+	}
 
-		var image string
-		if len(deployment.Spec.Template.Spec.Containers) > 0 {
-			image = deployment.Spec.Template.Spec.Containers[0].Image
+	// Image e ContainerPort continuam refletindo o primeiro container
+	if len(info.Containers) > 0 {
+		info.Image = info.Containers[0].Image
+		if len(info.Containers[0].Ports) > 0 {
+			info.ContainerPort = info.Containers[0].Ports[0].ContainerPort
 		}
+	}
 
-		var replicas int32 = 0
-		if deployment.Spec.Replicas != nil {
-			replicas = *deployment.Spec.Replicas
+	for _, cond := range deployment.Status.Conditions {
+		info.Conditions = append(info.Conditions, DeploymentConditionInfo{
+			Type:               string(cond.Type),
+			Status:             string(cond.Status),
+			Reason:             cond.Reason,
+			Message:            cond.Message,
+			LastUpdateTime:     cond.LastUpdateTime,
+			LastTransitionTime: cond.LastTransitionTime,
+		})
+	}
+	return info
+}
+
+// deploymentStatus resume o estado do rollout em um único valor:
+//   - ScaledToZero: o deployment foi escalado para 0 réplicas
+//   - Degraded: o rollout estourou o progressDeadlineSeconds, há falha ao criar réplicas
+//     ou faltam réplicas disponíveis sem rollout em andamento
+//   - Paused: o rollout está pausado
+//   - Progressing: o controller ainda não observou a última geração ou há réplicas antigas/não atualizadas
+//   - Ready: todas as réplicas estão atualizadas e disponíveis
+func deploymentStatus(deployment appsv1.Deployment, replicas int32) string {
+	st := deployment.Status
+	for _, cond := range st.Conditions {
+		switch {
+		case cond.Type == appsv1.DeploymentProgressing && cond.Status == v1.ConditionFalse && cond.Reason == "ProgressDeadlineExceeded":
+			return "Degraded"
+		case cond.Type == appsv1.DeploymentReplicaFailure && cond.Status == v1.ConditionTrue:
+			return "Degraded"
 		}
+	}
 
-		status := "Unknown"
-		if deployment.Status.ReadyReplicas == replicas && replicas > 0 {
-			status = "Ready"
-		} else if deployment.Status.ReadyReplicas < replicas {
-			status = "NotReady"
+	if replicas == 0 {
+		if st.Replicas > 0 {
+			// Pods antigos ainda estão sendo removidos
+			return "Progressing"
 		}
+		return "ScaledToZero"
+	}
+	if deployment.Spec.Paused {
+		return "Paused"
+	}
+	if st.ObservedGeneration < deployment.Generation ||
+		st.UpdatedReplicas < replicas ||
+		st.Replicas > st.UpdatedReplicas {
+		return "Progressing"
+	}
+	if st.AvailableReplicas < replicas {
+		return "Degraded"
+	}
+	return "Ready"
+}
 
-		info := DeploymentInfo{
-			Nome:          deployment.Name,
-			Namespace:     deployment.Namespace,
-			Status:        status,
-			Image:         image,
-			Replicas:      replicas,
-			ContainerPort: containerPort,
-			Selector:      deployment.Spec.Selector.MatchLabels,
+// containerSpecInfos lista nome, imagem e portas de todos os containers de um template.
+func containerSpecInfos(containers []v1.Container) []ContainerSpecInfo {
+	infos := make([]ContainerSpecInfo, 0, len(containers))
+	for _, c := range containers {
+		info := ContainerSpecInfo{Name: c.Name, Image: c.Image}
+		for _, port := range c.Ports {
+			info.Ports = append(info.Ports, ContainerPortInfo{
+				Name:          port.Name,
+				ContainerPort: port.ContainerPort,
+				Protocol:      string(port.Protocol),
+			})
 		}
-		deploymentsInfo = append(deploymentsInfo, info)
+		infos = append(infos, info)
 	}
-	return deploymentsInfo, nil
+	return infos
 }
 
-func ListServices(namespace string) ([]ServiceInfo, error) {
-	services, err := client.CoreV1().Services(namespace).List(context.TODO(), metav1.ListOptions{})
+// ListServices retorna uma página de ServiceInfo do namespace informado (ou de todos, com AllNamespaces).
+func ListServices(namespace string, opts ListOptions) (ListResult[ServiceInfo], error) {
+	return listNamespacedPage(namespace, opts, listServices)
+}
+
+func listServices(namespace string, listOpts metav1.ListOptions) ([]ServiceInfo, metav1.ListMeta, error) {
+	services, err := client.CoreV1().Services(namespace).List(context.TODO(), listOpts)
+	if err != nil {
+		return nil, metav1.ListMeta{}, fmt.Errorf("erro ao listar services: %w", err)
+	}
+
+	// As EndpointSlices são buscadas uma vez por página, e não uma vez por service
+	endpoints, err := countEndpoints(namespace)
 	if err != nil {
-		return nil, fmt.Errorf("erro ao listar services: %w", err)
+		// Sem permissão para EndpointSlices, a listagem continua sem as contagens
+		log.Printf("⚠️ Não foi possível contar endpoints: %v", err)
 	}
+
 	var servicesInfo []ServiceInfo
 	for _, service := range services.Items {
-		var port int32 = 0
-		var targetPort int32 = 0
-		if len(service.Spec.Ports) > 0 {
-			port = service.Spec.Ports[0].Port
-			// Verifica se TargetPort é IntVal ou StringVal
-			if service.Spec.Ports[0].TargetPort.Type == intstr.Int {
-				targetPort = service.Spec.Ports[0].TargetPort.IntVal
-			}
+		servicesInfo = append(servicesInfo, newServiceInfo(service, endpoints))
+	}
+	return servicesInfo, services.ListMeta, nil
+}
+
+// newServiceInfo converte um service da API no modelo exposto pelo backend.
+// endpoints é indexado por "namespace/nome" e pode ser nil.
+func newServiceInfo(service v1.Service, endpoints map[string]*EndpointCounts) ServiceInfo {
+	info := ServiceInfo{
+		Nome:              service.Name,
+		Ports:             []ServicePortInfo{},
+		Selector:          service.Spec.Selector,
+		Type:              string(service.Spec.Type),
+		Namespace:         service.Namespace,
+		ClusterIP:         service.Spec.ClusterIP,
+		ExternalIPs:       service.Spec.ExternalIPs,
+		ExternalName:      service.Spec.ExternalName,
+		Labels:            service.Labels,
+		CreationTimestamp: service.CreationTimestamp,
+		Age:               age(service.CreationTimestamp),
+	}
+
+	for _, port := range service.Spec.Ports {
+		info.Ports = append(info.Ports, ServicePortInfo{
+			Name:       port.Name,
+			Protocol:   string(port.Protocol),
+			Port:       port.Port,
+			TargetPort: port.TargetPort,
+			NodePort:   port.NodePort,
+		})
+	}
+	if len(service.Spec.Ports) > 0 {
+		info.Port = service.Spec.Ports[0].Port
+		// TargetPort numérico mantém compatibilidade; portas nomeadas aparecem em Ports
+		if service.Spec.Ports[0].TargetPort.Type == intstr.Int {
+			info.TargetPort = service.Spec.Ports[0].TargetPort.IntVal
+		}
+	}
+
+	if len(service.Spec.ExternalIPs) > 0 {
+		info.ExternalIP = service.Spec.ExternalIPs[0]
+	}
+
+	for _, ingress := range service.Status.LoadBalancer.Ingress {
+		info.LoadBalancer = append(info.LoadBalancer, LoadBalancerAddress{IP: ingress.IP, Hostname: ingress.Hostname})
+		if info.LoadBalancerIP == "" {
+			info.LoadBalancerIP = ingress.IP
 		}
+	}
+	// Se não tiver IP no Ingress, tenta pegar do spec (deprecated mas ainda pode existir)
+	if info.LoadBalancerIP == "" {
+		info.LoadBalancerIP = service.Spec.LoadBalancerIP
+	}
 
-		var externalIP string
-		if len(service.Spec.ExternalIPs) > 0 {
-			externalIP = service.Spec.ExternalIPs[0]
+	// Services ExternalName não têm endpoints; sem o índice, as contagens ficam de fora
+	if endpoints != nil && service.Spec.Type != v1.ServiceTypeExternalName {
+		info.Endpoints = &EndpointCounts{}
+		if counts, ok := endpoints[service.Namespace+"/"+service.Name]; ok {
+			info.Endpoints = counts
 		}
+	}
+	return info
+}
+
+// countEndpoints soma os endpoints prontos e não prontos de cada service do namespace
+// a partir das EndpointSlices. Endpoints repetidos entre slices de famílias de endereço
+// diferentes (dual-stack) são contados uma única vez.
+func countEndpoints(namespace string) (map[string]*EndpointCounts, error) {
+	slices, err := client.DiscoveryV1().EndpointSlices(namespace).List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("erro ao listar endpointslices: %w", err)
+	}
+	return countEndpointSlices(slices.Items), nil
+}
 
-		var loadBalancerIP string
-		if len(service.Status.LoadBalancer.Ingress) > 0 {
-			loadBalancerIP = service.Status.LoadBalancer.Ingress[0].IP
+// countEndpointSlices agrupa as contagens de endpoints por "namespace/service".
+func countEndpointSlices(slices []discoveryv1.EndpointSlice) map[string]*EndpointCounts {
+	counts := map[string]*EndpointCounts{}
+	seen := map[string]bool{}
+	for _, slice := range slices {
+		serviceName := slice.Labels[discoveryv1.LabelServiceName]
+		if serviceName == "" {
+			continue
 		}
-		// Se não tiver IP no Ingress, tenta pegar do spec (deprecated mas ainda pode existir)
-		if loadBalancerIP == "" {
-			loadBalancerIP = service.Spec.LoadBalancerIP
+		key := slice.Namespace + "/" + serviceName
+		if counts[key] == nil {
+			counts[key] = &EndpointCounts{}
 		}
 
-		info := ServiceInfo{
-			Nome:           service.Name,
-			Port:           port,
-			TargetPort:     targetPort,
-			Selector:       service.Spec.Selector,
-			Type:           string(service.Spec.Type),
-			Namespace:      service.Namespace,
-			ClusterIP:      service.Spec.ClusterIP,
-			ExternalIP:     externalIP,
-			LoadBalancerIP: loadBalancerIP,
+		for _, ep := range slice.Endpoints {
+			id := endpointID(ep)
+			if id != "" {
+				if seen[key+"/"+id] {
+					continue
+				}
+				seen[key+"/"+id] = true
+			}
+			// Ready nil deve ser interpretado como pronto
+			if ep.Conditions.Ready == nil || *ep.Conditions.Ready {
+				counts[key].Ready++
+			} else {
+				counts[key].NotReady++
+			}
 		}
-		servicesInfo = append(servicesInfo, info)
 	}
-	return servicesInfo, nil
+	return counts
+}
+
+func endpointID(ep discoveryv1.Endpoint) string {
+	if ep.TargetRef != nil && ep.TargetRef.Name != "" {
+		return ep.TargetRef.Kind + "/" + ep.TargetRef.Name
+	}
+	if len(ep.Addresses) > 0 {
+		return ep.Addresses[0]
+	}
+	return ""
 }
 
-func ListNamespaces() ([]string, error) {
-	log.Printf("🔍 ListNamespaces: Iniciando busca por namespaces")
+// NamespaceInfo resume um namespace. Counts e Quotas só são preenchidos quando
+// a listagem é feita com contagem de recursos.
+type NamespaceInfo struct {
+	Nome              string            `json:"nome"`
+	Status            string            `json:"status"`
+	Labels            map[string]string `json:"labels,omitempty"`
+	Annotations       map[string]string `json:"annotations,omitempty"`
+	CreationTimestamp metav1.Time       `json:"creationTimestamp"`
+	Age               string            `json:"age"`
+	Counts            *NamespaceCounts  `json:"counts,omitempty"`
+	Quotas            []QuotaInfo       `json:"quotas,omitempty"`
+}
+
+type NamespaceCounts struct {
+	Pods        int `json:"pods"`
+	Deployments int `json:"deployments"`
+	Services    int `json:"services"`
+}
+
+// QuotaInfo mostra os limites (hard) e o uso atual de uma ResourceQuota.
+type QuotaInfo struct {
+	Nome string            `json:"nome"`
+	Hard map[string]string `json:"hard"`
+	Used map[string]string `json:"used"`
+}
 
-	namespace, err := client.CoreV1().Namespaces().List(context.TODO(), metav1.ListOptions{})
+// ListNamespaces retorna uma página de NamespaceInfo. Com withCounts, cada namespace
+// traz a quantidade de pods, deployments e services e o uso das ResourceQuotas.
+func ListNamespaces(opts ListOptions, withCounts bool) (ListResult[NamespaceInfo], error) {
+	return listPage(opts, func(listOpts metav1.ListOptions) ([]NamespaceInfo, metav1.ListMeta, error) {
+		return listNamespaces(listOpts, withCounts)
+	})
+}
+
+func listNamespaces(listOpts metav1.ListOptions, withCounts bool) ([]NamespaceInfo, metav1.ListMeta, error) {
+	namespace, err := client.CoreV1().Namespaces().List(context.TODO(), listOpts)
 	if err != nil {
 		log.Printf("❌ Erro ao listar namespaces: %v", err)
-		return nil, fmt.Errorf("erro ao listar namespaces: %w", err)
+		return nil, metav1.ListMeta{}, fmt.Errorf("erro ao listar namespaces: %w", err)
+	}
+
+	var counts map[string]*NamespaceCounts
+	var quotas map[string][]QuotaInfo
+	if withCounts {
+		if counts, err = countNamespaceResources(); err != nil {
+			log.Printf("⚠️ Não foi possível contar recursos dos namespaces: %v", err)
+		}
+		if quotas, err = namespaceQuotas(); err != nil {
+			log.Printf("⚠️ Não foi possível listar resource quotas: %v", err)
+		}
 	}
-	log.Printf("📋 Total de namespaces encontrados: %d", len(namespace.Items))
 
-	var namespaces []string
+	var namespaces []NamespaceInfo
 	for _, ns := range namespace.Items {
-		namespaces = append(namespaces, ns.Name)
-		log.Printf("📝 Namespace: %s", ns.Name)
+		info := NamespaceInfo{
+			Nome:              ns.Name,
+			Status:            string(ns.Status.Phase),
+			Labels:            ns.Labels,
+			Annotations:       withoutLastApplied(ns.Annotations),
+			CreationTimestamp: ns.CreationTimestamp,
+			Age:               age(ns.CreationTimestamp),
+			Quotas:            quotas[ns.Name],
+		}
+		if counts != nil {
+			info.Counts = &NamespaceCounts{}
+			if c, ok := counts[ns.Name]; ok {
+				info.Counts = c
+			}
+		}
+		namespaces = append(namespaces, info)
+	}
+
+	return namespaces, namespace.ListMeta, nil
+}
+
+// countNamespaceResources conta pods, deployments e services de todos os namespaces
+// com uma listagem de metadados por tipo, servida pelo cache do API server.
+func countNamespaceResources() (map[string]*NamespaceCounts, error) {
+	counts := map[string]*NamespaceCounts{}
+	resources := []struct {
+		gvr schema.GroupVersionResource
+		inc func(*NamespaceCounts)
+	}{
+		{v1.SchemeGroupVersion.WithResource("pods"), func(c *NamespaceCounts) { c.Pods++ }},
+		{appsv1.SchemeGroupVersion.WithResource("deployments"), func(c *NamespaceCounts) { c.Deployments++ }},
+		{v1.SchemeGroupVersion.WithResource("services"), func(c *NamespaceCounts) { c.Services++ }},
 	}
 
-	log.Printf("✅ ListNamespaces: Retornando %d namespaces", len(namespaces))
-	return namespaces, nil
+	for _, res := range resources {
+		list, err := metadataClient.Resource(res.gvr).List(context.TODO(), metav1.ListOptions{ResourceVersion: "0"})
+		if err != nil {
+			return nil, fmt.Errorf("erro ao contar %s: %w", res.gvr.Resource, err)
+		}
+		for _, item := range list.Items {
+			if counts[item.Namespace] == nil {
+				counts[item.Namespace] = &NamespaceCounts{}
+			}
+			res.inc(counts[item.Namespace])
+		}
+	}
+	return counts, nil
+}
+
+// namespaceQuotas agrupa as ResourceQuotas do cluster por namespace.
+func namespaceQuotas() (map[string][]QuotaInfo, error) {
+	list, err := client.CoreV1().ResourceQuotas(metav1.NamespaceAll).List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("erro ao listar resource quotas: %w", err)
+	}
+
+	quotas := map[string][]QuotaInfo{}
+	for _, quota := range list.Items {
+		quotas[quota.Namespace] = append(quotas[quota.Namespace], QuotaInfo{
+			Nome: quota.Name,
+			Hard: quantities(quota.Status.Hard),
+			Used: quantities(quota.Status.Used),
+		})
+	}
+	return quotas, nil
+}
+
+func quantities(list v1.ResourceList) map[string]string {
+	values := make(map[string]string, len(list))
+	for name, quantity := range list {
+		values[string(name)] = quantity.String()
+	}
+	return values
+}
+
+// withoutLastApplied remove a anotação do kubectl apply, que repete o objeto inteiro.
+func withoutLastApplied(annotations map[string]string) map[string]string {
+	if _, ok := annotations[v1.LastAppliedConfigAnnotation]; !ok {
+		return annotations
+	}
+	filtered := make(map[string]string, len(annotations))
+	for k, v := range annotations {
+		if k != v1.LastAppliedConfigAnnotation {
+			filtered[k] = v
+		}
+	}
+	return filtered
 }
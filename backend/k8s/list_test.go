@@ -0,0 +1,98 @@
+package k8s
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestCountEndpointSlices(t *testing.T) {
+	ready, notReady := true, false
+	slice := func(service string, family discoveryv1.AddressType, endpoints ...discoveryv1.Endpoint) discoveryv1.EndpointSlice {
+		labels := map[string]string{}
+		if service != "" {
+			labels[discoveryv1.LabelServiceName] = service
+		}
+		return discoveryv1.EndpointSlice{
+			ObjectMeta:  metav1.ObjectMeta{Namespace: "default", Labels: labels},
+			AddressType: family,
+			Endpoints:   endpoints,
+		}
+	}
+	pod := func(name, address string, isReady *bool) discoveryv1.Endpoint {
+		return discoveryv1.Endpoint{
+			Addresses:  []string{address},
+			Conditions: discoveryv1.EndpointConditions{Ready: isReady},
+			TargetRef:  &v1.ObjectReference{Kind: "Pod", Name: name},
+		}
+	}
+
+	tests := []struct {
+		name   string
+		slices []discoveryv1.EndpointSlice
+		want   map[string]EndpointCounts
+	}{
+		{
+			name:   "prontos e não prontos",
+			slices: []discoveryv1.EndpointSlice{slice("web", discoveryv1.AddressTypeIPv4, pod("web-1", "10.0.0.1", &ready), pod("web-2", "10.0.0.2", &notReady))},
+			want:   map[string]EndpointCounts{"default/web": {Ready: 1, NotReady: 1}},
+		},
+		{
+			name:   "ready nulo conta como pronto",
+			slices: []discoveryv1.EndpointSlice{slice("web", discoveryv1.AddressTypeIPv4, pod("web-1", "10.0.0.1", nil))},
+			want:   map[string]EndpointCounts{"default/web": {Ready: 1}},
+		},
+		{
+			name: "dual-stack conta cada pod uma vez",
+			slices: []discoveryv1.EndpointSlice{
+				slice("web", discoveryv1.AddressTypeIPv4, pod("web-1", "10.0.0.1", &ready), pod("web-2", "10.0.0.2", &notReady)),
+				slice("web", discoveryv1.AddressTypeIPv6, pod("web-1", "fd00::1", &ready), pod("web-2", "fd00::2", &notReady)),
+			},
+			want: map[string]EndpointCounts{"default/web": {Ready: 1, NotReady: 1}},
+		},
+		{
+			name: "endpoints sem targetRef são identificados pelo endereço",
+			slices: []discoveryv1.EndpointSlice{
+				slice("externo", discoveryv1.AddressTypeIPv4,
+					discoveryv1.Endpoint{Addresses: []string{"192.168.0.10"}},
+					discoveryv1.Endpoint{Addresses: []string{"192.168.0.11"}}),
+				slice("externo", discoveryv1.AddressTypeIPv4, discoveryv1.Endpoint{Addresses: []string{"192.168.0.10"}}),
+			},
+			want: map[string]EndpointCounts{"default/externo": {Ready: 2}},
+		},
+		{
+			name: "mesmo pod em services diferentes",
+			slices: []discoveryv1.EndpointSlice{
+				slice("web", discoveryv1.AddressTypeIPv4, pod("web-1", "10.0.0.1", &ready)),
+				slice("web-headless", discoveryv1.AddressTypeIPv4, pod("web-1", "10.0.0.1", &ready)),
+			},
+			want: map[string]EndpointCounts{"default/web": {Ready: 1}, "default/web-headless": {Ready: 1}},
+		},
+		{
+			name:   "service sem endpoints",
+			slices: []discoveryv1.EndpointSlice{slice("vazio", discoveryv1.AddressTypeIPv4)},
+			want:   map[string]EndpointCounts{"default/vazio": {}},
+		},
+		{
+			name:   "slices sem o label do service são ignoradas",
+			slices: []discoveryv1.EndpointSlice{slice("", discoveryv1.AddressTypeIPv4, pod("web-1", "10.0.0.1", &ready))},
+			want:   map[string]EndpointCounts{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := countEndpointSlices(tt.slices)
+			if len(got) != len(tt.want) {
+				t.Fatalf("countEndpointSlices() tem %d services, esperado %d", len(got), len(tt.want))
+			}
+			for key, want := range tt.want {
+				if got[key] == nil || *got[key] != want {
+					t.Errorf("countEndpointSlices()[%q] = %+v, esperado %+v", key, got[key], want)
+				}
+			}
+		})
+	}
+}
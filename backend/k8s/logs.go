@@ -0,0 +1,163 @@
+package k8s
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// defaultContainerAnnotation indica o container padrão de um pod, como no kubectl.
+const defaultContainerAnnotation = "kubectl.kubernetes.io/default-container"
+
+// maxLogLineSize é o tamanho máximo de uma linha de log lida do stream.
+const maxLogLineSize = 1024 * 1024
+
+// LogOptions espelha os campos de PodLogOptions expostos pela API.
+type LogOptions struct {
+	Container    string
+	Follow       bool
+	TailLines    *int64
+	SinceSeconds *int64
+	SinceTime    *metav1.Time
+	Timestamps   bool
+	Previous     bool
+}
+
+// LogLine é uma linha de log com o pod e o container de origem.
+type LogLine struct {
+	Pod       string `json:"pod"`
+	Container string `json:"container"`
+	Line      string `json:"line"`
+}
+
+func (o LogOptions) podLogOptions(container string) *v1.PodLogOptions {
+	return &v1.PodLogOptions{
+		Container:    container,
+		Follow:       o.Follow,
+		TailLines:    o.TailLines,
+		SinceSeconds: o.SinceSeconds,
+		SinceTime:    o.SinceTime,
+		Timestamps:   o.Timestamps,
+		Previous:     o.Previous,
+	}
+}
+
+// StreamPodLogs envia, linha a linha, os logs de um container do pod. Sem container
+// informado, usa o container padrão do pod (anotação do kubectl ou o primeiro).
+func StreamPodLogs(ctx context.Context, namespace, name string, opts LogOptions, send func(LogLine) error) error {
+	pod, err := client.CoreV1().Pods(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("pod não encontrado: %w", err)
+	}
+
+	container := opts.Container
+	if container == "" {
+		container = defaultContainer(pod)
+	}
+	return streamContainerLogs(ctx, pod.Namespace, pod.Name, container, opts, send)
+}
+
+// ErrNoPods indica que o workload existe, mas não tem pods (ex.: escalado para zero).
+var ErrNoPods = errors.New("nenhum pod encontrado")
+
+// StreamDeploymentLogs junta os logs de todos os containers de todos os pods do deployment.
+// As linhas chegam na ordem em que são lidas de cada stream; opts.Container restringe
+// a um único container de cada pod.
+func StreamDeploymentLogs(ctx context.Context, namespace, name string, opts LogOptions, send func(LogLine) error) error {
+	deployment, err := client.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("deployment não encontrado: %w", err)
+	}
+	pods, err := deploymentPods(deployment)
+	if err != nil {
+		return err
+	}
+	if len(pods) == 0 {
+		return fmt.Errorf("%w: deployment '%s' não possui pods", ErrNoPods, name)
+	}
+
+	return streamPodsLogs(ctx, namespace, pods, opts, send)
+}
+
+// streamPodsLogs junta os logs dos containers dos pods; opts.Container restringe
+// a um único container de cada pod.
+func streamPodsLogs(ctx context.Context, namespace string, pods []v1.Pod, opts LogOptions, send func(LogLine) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	lines := make(chan LogLine)
+	var wg sync.WaitGroup
+	for _, pod := range pods {
+		for _, c := range pod.Spec.Containers {
+			if opts.Container != "" && c.Name != opts.Container {
+				continue
+			}
+			wg.Add(1)
+			go func(pod, container string) {
+				defer wg.Done()
+				err := streamContainerLogs(ctx, namespace, pod, container, opts, func(line LogLine) error {
+					select {
+					case lines <- line:
+						return nil
+					case <-ctx.Done():
+						return ctx.Err()
+					}
+				})
+				if err != nil && ctx.Err() == nil {
+					// Um container sem logs (ex.: ainda criando) não interrompe os demais
+					select {
+					case lines <- LogLine{Pod: pod, Container: container, Line: fmt.Sprintf("<erro ao ler logs: %v>", err)}:
+					case <-ctx.Done():
+					}
+				}
+			}(pod.Name, c.Name)
+		}
+	}
+	go func() {
+		wg.Wait()
+		close(lines)
+	}()
+
+	// Cancelar o contexto libera as goroutines bloqueadas no envio
+	for line := range lines {
+		if err := send(line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func streamContainerLogs(ctx context.Context, namespace, pod, container string, opts LogOptions, send func(LogLine) error) error {
+	stream, err := client.CoreV1().Pods(namespace).GetLogs(pod, opts.podLogOptions(container)).Stream(ctx)
+	if err != nil {
+		return fmt.Errorf("erro ao abrir logs de %s/%s: %w", pod, container, err)
+	}
+	defer stream.Close()
+
+	scanner := bufio.NewScanner(stream)
+	scanner.Buffer(make([]byte, 64*1024), maxLogLineSize)
+	for scanner.Scan() {
+		if err := send(LogLine{Pod: pod, Container: container, Line: scanner.Text()}); err != nil {
+			return err
+		}
+	}
+	if err := scanner.Err(); err != nil && ctx.Err() == nil {
+		return fmt.Errorf("erro ao ler logs de %s/%s: %w", pod, container, err)
+	}
+	return nil
+}
+
+func defaultContainer(pod *v1.Pod) string {
+	if name := pod.Annotations[defaultContainerAnnotation]; name != "" {
+		return name
+	}
+	if len(pod.Spec.Containers) > 0 {
+		return pod.Spec.Containers[0].Name
+	}
+	return ""
+}
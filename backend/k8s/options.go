@@ -0,0 +1,46 @@
+package k8s
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// MutateOptions são as opções comuns às operações que alteram o cluster.
+// Com DryRun, o API server valida o pedido e executa os admission webhooks,
+// mas nada é persistido.
+type MutateOptions struct {
+	DryRun bool
+}
+
+func (o MutateOptions) dryRun() []string {
+	if o.DryRun {
+		return []string{metav1.DryRunAll}
+	}
+	return nil
+}
+
+func (o MutateOptions) createOptions() metav1.CreateOptions {
+	return metav1.CreateOptions{DryRun: o.dryRun()}
+}
+
+func (o MutateOptions) updateOptions() metav1.UpdateOptions {
+	return metav1.UpdateOptions{DryRun: o.dryRun()}
+}
+
+// deleteOptions exige que o objeto removido seja o mesmo lido antes (mesmo UID),
+// já que ele é devolvido como resultado da exclusão.
+func (o MutateOptions) deleteOptions(uid types.UID) metav1.DeleteOptions {
+	return metav1.DeleteOptions{
+		DryRun:        o.dryRun(),
+		Preconditions: metav1.NewUIDPreconditions(string(uid)),
+	}
+}
+
+// cascadeDeleteOptions remove também os objetos dependentes (ex.: os pods de um Job),
+// que a API de batch/v1 deixaria órfãos por padrão.
+func (o MutateOptions) cascadeDeleteOptions(uid types.UID) metav1.DeleteOptions {
+	opts := o.deleteOptions(uid)
+	policy := metav1.DeletePropagationBackground
+	opts.PropagationPolicy = &policy
+	return opts
+}
@@ -0,0 +1,180 @@
+package k8s
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"sort"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ErrInvalidContinue é retornado quando o token 'continue' recebido não pode ser decodificado.
+var ErrInvalidContinue = errors.New("token 'continue' inválido")
+
+// AllNamespaces é o valor de namespace que ativa a listagem em todos os namespaces.
+const AllNamespaces = "_all"
+
+// ListOptions reúne os parâmetros de paginação e ordenação aceitos pelas listagens.
+// Sort contém nomes de campos JSON do modelo, com prefixo '-' para ordem decrescente.
+type ListOptions struct {
+	Limit    int64
+	Continue string
+	Sort     []string
+}
+
+// ListResult é o envelope devolvido pelas listagens paginadas.
+// Total só é preenchido quando o Kubernetes informa quantos itens restam.
+// Groups só é preenchido no modo de todos os namespaces.
+type ListResult[T any] struct {
+	Items    []T              `json:"items"`
+	Total    *int64           `json:"total,omitempty"`
+	Continue string           `json:"continue,omitempty"`
+	Groups   []NamespaceGroup `json:"groups,omitempty"`
+}
+
+// NamespaceGroup resume quantos itens da página pertencem a cada namespace.
+// Sem 'sort', os itens da página vêm ordenados por namespace, na mesma ordem dos grupos.
+type NamespaceGroup struct {
+	Namespace string `json:"namespace"`
+	Count     int    `json:"count"`
+}
+
+// Namespaced é implementado pelos modelos de listagem que pertencem a um namespace.
+type Namespaced interface {
+	GetNamespace() string
+	GetName() string
+}
+
+// pageToken é o conteúdo do token opaco 'continue' entregue ao cliente.
+// Guarda o token do Kubernetes e quantos itens já foram entregues,
+// permitindo calcular o total a partir de RemainingItemCount. Nas listagens
+// ordenadas só o Offset é usado, pois a paginação é feita em memória.
+type pageToken struct {
+	Continue string `json:"c,omitempty"`
+	Offset   int64  `json:"o"`
+}
+
+func encodePageToken(t pageToken) string {
+	raw, _ := json.Marshal(t)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+func decodePageToken(s string) (pageToken, error) {
+	var t pageToken
+	if s == "" {
+		return t, nil
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return t, ErrInvalidContinue
+	}
+	if err := json.Unmarshal(raw, &t); err != nil || t.Offset < 0 {
+		return t, ErrInvalidContinue
+	}
+	return t, nil
+}
+
+// listPage traduz ListOptions para a paginação nativa do Kubernetes
+// (ListOptions.Limit/Continue) e monta o envelope com total e próximo token.
+func listPage[T any](opts ListOptions, fetch func(metav1.ListOptions) ([]T, metav1.ListMeta, error)) (ListResult[T], error) {
+	token, err := decodePageToken(opts.Continue)
+	if err != nil {
+		return ListResult[T]{}, err
+	}
+	if len(opts.Sort) > 0 {
+		return listSortedPage(opts, token, fetch)
+	}
+
+	items, meta, err := fetch(metav1.ListOptions{Limit: opts.Limit, Continue: token.Continue})
+	if err != nil {
+		return ListResult[T]{}, err
+	}
+	if items == nil {
+		items = []T{}
+	}
+
+	result := ListResult[T]{Items: items}
+	delivered := token.Offset + int64(len(items))
+	switch {
+	case meta.Continue == "":
+		result.Total = &delivered
+	case meta.RemainingItemCount != nil:
+		total := delivered + *meta.RemainingItemCount
+		result.Total = &total
+	}
+	if meta.Continue != "" {
+		result.Continue = encodePageToken(pageToken{Continue: meta.Continue, Offset: delivered})
+	}
+	return result, nil
+}
+
+// listSortedPage busca a lista completa, ordena e pagina em memória.
+// O Kubernetes só pagina na ordem das chaves, então é a única forma de manter
+// a mesma ordenação entre as páginas.
+func listSortedPage[T any](opts ListOptions, token pageToken, fetch func(metav1.ListOptions) ([]T, metav1.ListMeta, error)) (ListResult[T], error) {
+	items, _, err := fetch(metav1.ListOptions{})
+	if err != nil {
+		return ListResult[T]{}, err
+	}
+	if err := sortItems(items, opts.Sort); err != nil {
+		return ListResult[T]{}, err
+	}
+
+	total := int64(len(items))
+	start := min(token.Offset, total)
+	end := total
+	if opts.Limit > 0 {
+		end = min(start+opts.Limit, total)
+	}
+
+	result := ListResult[T]{Items: append([]T{}, items[start:end]...), Total: &total}
+	if end < total {
+		result.Continue = encodePageToken(pageToken{Offset: end})
+	}
+	return result, nil
+}
+
+// listNamespacedPage faz a listagem paginada de um recurso com namespace.
+// Quando namespace é AllNamespaces, lista o cluster inteiro e agrupa os itens
+// por namespace; sem 'sort' explícito, a página é ordenada por namespace e nome.
+func listNamespacedPage[T Namespaced](namespace string, opts ListOptions, fetch func(string, metav1.ListOptions) ([]T, metav1.ListMeta, error)) (ListResult[T], error) {
+	allNamespaces := namespace == AllNamespaces
+	if allNamespaces {
+		namespace = metav1.NamespaceAll
+	}
+
+	result, err := listPage(opts, func(listOpts metav1.ListOptions) ([]T, metav1.ListMeta, error) {
+		return fetch(namespace, listOpts)
+	})
+	if err != nil || !allNamespaces {
+		return result, err
+	}
+
+	if len(opts.Sort) == 0 {
+		sort.SliceStable(result.Items, func(i, j int) bool {
+			a, b := result.Items[i], result.Items[j]
+			if a.GetNamespace() != b.GetNamespace() {
+				return a.GetNamespace() < b.GetNamespace()
+			}
+			return a.GetName() < b.GetName()
+		})
+	}
+	result.Groups = groupByNamespace(result.Items)
+	return result, nil
+}
+
+// groupByNamespace conta os itens por namespace, na ordem em que cada namespace aparece.
+func groupByNamespace[T Namespaced](items []T) []NamespaceGroup {
+	groups := []NamespaceGroup{}
+	index := map[string]int{}
+	for _, item := range items {
+		if i, ok := index[item.GetNamespace()]; ok {
+			groups[i].Count++
+			continue
+		}
+		index[item.GetNamespace()] = len(groups)
+		groups = append(groups, NamespaceGroup{Namespace: item.GetNamespace(), Count: 1})
+	}
+	return groups
+}
@@ -0,0 +1,133 @@
+package k8s
+
+import (
+	"encoding/base64"
+	"errors"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestPageToken(t *testing.T) {
+	tests := []struct {
+		name    string
+		token   string
+		want    pageToken
+		wantErr bool
+	}{
+		{name: "vazio é a primeira página", token: "", want: pageToken{}},
+		{name: "token do kubernetes e offset", token: encodePageToken(pageToken{Continue: "abc", Offset: 20}), want: pageToken{Continue: "abc", Offset: 20}},
+		{name: "só offset", token: encodePageToken(pageToken{Offset: 50}), want: pageToken{Offset: 50}},
+		{name: "base64 inválido", token: "não é base64", wantErr: true},
+		{name: "json inválido", token: base64.RawURLEncoding.EncodeToString([]byte("{")), wantErr: true},
+		{name: "offset negativo", token: base64.RawURLEncoding.EncodeToString([]byte(`{"o":-1}`)), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := decodePageToken(tt.token)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidContinue) {
+					t.Fatalf("decodePageToken() erro = %v, esperado ErrInvalidContinue", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("decodePageToken() erro inesperado: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("decodePageToken() = %+v, esperado %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestListPage(t *testing.T) {
+	remaining := int64(5)
+	total := func(v int64) *int64 { return &v }
+
+	tests := []struct {
+		name         string
+		opts         ListOptions
+		items        []string
+		meta         metav1.ListMeta
+		wantRequest  metav1.ListOptions
+		wantTotal    *int64
+		wantContinue *pageToken
+	}{
+		{
+			name:        "última página sem token calcula o total",
+			items:       []string{"a", "b"},
+			wantRequest: metav1.ListOptions{},
+			wantTotal:   total(2),
+		},
+		{
+			name:         "página com itens restantes",
+			opts:         ListOptions{Limit: 2},
+			items:        []string{"a", "b"},
+			meta:         metav1.ListMeta{Continue: "k8s-1", RemainingItemCount: &remaining},
+			wantRequest:  metav1.ListOptions{Limit: 2},
+			wantTotal:    total(7),
+			wantContinue: &pageToken{Continue: "k8s-1", Offset: 2},
+		},
+		{
+			name:         "sem RemainingItemCount o total fica de fora",
+			opts:         ListOptions{Limit: 2, Continue: encodePageToken(pageToken{Continue: "k8s-1", Offset: 2})},
+			items:        []string{"c", "d"},
+			meta:         metav1.ListMeta{Continue: "k8s-2"},
+			wantRequest:  metav1.ListOptions{Limit: 2, Continue: "k8s-1"},
+			wantContinue: &pageToken{Continue: "k8s-2", Offset: 4},
+		},
+		{
+			name:        "última página soma o offset",
+			opts:        ListOptions{Limit: 2, Continue: encodePageToken(pageToken{Continue: "k8s-2", Offset: 4})},
+			items:       []string{"e"},
+			wantRequest: metav1.ListOptions{Limit: 2, Continue: "k8s-2"},
+			wantTotal:   total(5),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var request metav1.ListOptions
+			result, err := listPage(tt.opts, func(opts metav1.ListOptions) ([]string, metav1.ListMeta, error) {
+				request = opts
+				return tt.items, tt.meta, nil
+			})
+			if err != nil {
+				t.Fatalf("listPage() erro inesperado: %v", err)
+			}
+
+			if request.Limit != tt.wantRequest.Limit || request.Continue != tt.wantRequest.Continue {
+				t.Errorf("requisição ao kubernetes = limit %d, continue %q; esperado limit %d, continue %q",
+					request.Limit, request.Continue, tt.wantRequest.Limit, tt.wantRequest.Continue)
+			}
+			switch {
+			case tt.wantTotal == nil && result.Total != nil:
+				t.Errorf("total = %d, esperado nenhum", *result.Total)
+			case tt.wantTotal != nil && (result.Total == nil || *result.Total != *tt.wantTotal):
+				t.Errorf("total = %v, esperado %d", result.Total, *tt.wantTotal)
+			}
+			if tt.wantContinue == nil {
+				if result.Continue != "" {
+					t.Errorf("continue = %q, esperado vazio", result.Continue)
+				}
+				return
+			}
+			got, err := decodePageToken(result.Continue)
+			if err != nil || got != *tt.wantContinue {
+				t.Errorf("continue = %+v (erro %v), esperado %+v", got, err, *tt.wantContinue)
+			}
+		})
+	}
+}
+
+func TestListPageInvalidContinue(t *testing.T) {
+	_, err := listPage(ListOptions{Continue: "%%%"}, func(metav1.ListOptions) ([]string, metav1.ListMeta, error) {
+		t.Fatal("fetch não deveria ser chamado com token inválido")
+		return nil, metav1.ListMeta{}, nil
+	})
+	if !errors.Is(err, ErrInvalidContinue) {
+		t.Errorf("listPage() erro = %v, esperado ErrInvalidContinue", err)
+	}
+}
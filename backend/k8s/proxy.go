@@ -0,0 +1,95 @@
+package k8s
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/http/httputil"
+	"strings"
+	"sync"
+
+	"k8s.io/client-go/rest"
+)
+
+// ErrInvalidProxyTarget indica um alvo de proxy fora do formato esperado.
+var ErrInvalidProxyTarget = errors.New("alvo de proxy inválido")
+
+// proxyResources traduz os nomes de recurso usados nas rotas para o recurso da API
+// que possui o subrecurso proxy.
+var proxyResources = map[string]string{
+	"pod":     "pods",
+	"service": "services",
+}
+
+// proxyTransport é o transporte autenticado com as credenciais do kubeconfig,
+// compartilhado entre as requisições para reaproveitar conexões.
+var proxyTransport = sync.OnceValues(func() (http.RoundTripper, error) {
+	return rest.TransportFor(restConfig)
+})
+
+// NewProxy cria um reverse proxy para uma porta de pod ou service usando o subrecurso
+// proxy do API server, como o kubectl proxy. target é "nome:porta" (a porta pode ser
+// o nome da porta e aceita o prefixo "https:"). prefix é o caminho da rota local, que
+// é removido da requisição e recolocado nos redirecionamentos devolvidos pela aplicação.
+func NewProxy(namespace, kind, target, prefix string) (*httputil.ReverseProxy, error) {
+	resource, ok := proxyResources[kind]
+	if !ok {
+		return nil, fmt.Errorf("%w: tipo '%s' não suportado (use pod ou service)", ErrInvalidProxyTarget, kind)
+	}
+	name, port, ok := strings.Cut(strings.TrimPrefix(strings.TrimPrefix(target, "https:"), "http:"), ":")
+	if !ok || name == "" || port == "" {
+		return nil, fmt.Errorf("%w: use o formato nome:porta", ErrInvalidProxyTarget)
+	}
+
+	transport, err := proxyTransport()
+	if err != nil {
+		return nil, fmt.Errorf("erro ao preparar proxy: %w", err)
+	}
+
+	base := client.CoreV1().RESTClient().Get().
+		Namespace(namespace).
+		Resource(resource).
+		Name(target).
+		SubResource("proxy").
+		URL()
+	prefix = strings.TrimSuffix(prefix, "/")
+
+	return &httputil.ReverseProxy{
+		Transport: transport,
+		Rewrite: func(pr *httputil.ProxyRequest) {
+			path := strings.TrimPrefix(pr.In.URL.Path, prefix)
+			pr.Out.URL.Scheme = base.Scheme
+			pr.Out.URL.Host = base.Host
+			pr.Out.URL.Path = base.Path + "/" + strings.TrimPrefix(path, "/")
+			pr.Out.URL.RawPath = ""
+			pr.Out.Host = base.Host
+			// O transporte só adiciona as credenciais do kubeconfig se não houver Authorization
+			pr.Out.Header.Del("Authorization")
+		},
+		ModifyResponse: func(resp *http.Response) error {
+			// O API server reescreve redirecionamentos para o próprio caminho de proxy
+			if location := resp.Header.Get("Location"); strings.HasPrefix(location, base.Path) {
+				resp.Header.Set("Location", prefix+strings.TrimPrefix(location, base.Path))
+			}
+			removeUpstreamCredentials(resp.Header)
+			return nil
+		},
+		ErrorHandler: func(w http.ResponseWriter, r *http.Request, err error) {
+			log.Printf("❌ ERRO: Falha no proxy para %s %s/%s: %v", kind, namespace, target, err)
+			http.Error(w, "Erro ao encaminhar a requisição para o cluster", http.StatusBadGateway)
+		},
+	}, nil
+}
+
+// removeUpstreamCredentials tira da resposta os headers com que a aplicação poderia
+// agir sobre a origem do k8s-manager: cookies (que valeriam para todas as rotas) e
+// CORS (que liberariam outras origens a ler as respostas com o token do usuário).
+func removeUpstreamCredentials(header http.Header) {
+	header.Del("Set-Cookie")
+	for name := range header {
+		if strings.HasPrefix(name, "Access-Control-") {
+			header.Del(name)
+		}
+	}
+}
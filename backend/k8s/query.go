@@ -0,0 +1,194 @@
+package k8s
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// ErrInvalidField é retornado quando 'sort' ou 'fields' referenciam um campo inexistente.
+var ErrInvalidField = errors.New("campo inválido")
+
+// ProjectFields reduz cada item do resultado aos campos JSON pedidos em fields,
+// mantendo total, token de continuação e grupos.
+func ProjectFields[T any](result ListResult[T], fields []string) (ListResult[map[string]any], error) {
+	projected := ListResult[map[string]any]{
+		Items:    make([]map[string]any, 0, len(result.Items)),
+		Total:    result.Total,
+		Continue: result.Continue,
+		Groups:   result.Groups,
+	}
+
+	known := jsonFieldNames(reflect.TypeFor[T]())
+	for _, field := range fields {
+		if !known[field] {
+			return projected, fmt.Errorf("%w: %s", ErrInvalidField, field)
+		}
+	}
+
+	for _, item := range result.Items {
+		row, err := toJSONMap(item)
+		if err != nil {
+			return projected, err
+		}
+		selected := make(map[string]any, len(fields))
+		for _, field := range fields {
+			if value, ok := row[field]; ok {
+				selected[field] = value
+			}
+		}
+		projected.Items = append(projected.Items, selected)
+	}
+	return projected, nil
+}
+
+// sortItems ordena items pelos campos JSON em keys. Um prefixo '-' inverte a ordem
+// do campo; empates seguem a ordem original, o que mantém a listagem estável.
+func sortItems[T any](items []T, keys []string) error {
+	type sortKey struct {
+		field string
+		desc  bool
+	}
+
+	known := jsonFieldNames(reflect.TypeFor[T]())
+	var parsed []sortKey
+	for _, key := range keys {
+		field, desc := strings.TrimPrefix(key, "-"), strings.HasPrefix(key, "-")
+		if field == "age" {
+			// A idade é texto ("5m", "2h", "10d") e não ordena; a data de origem ordena ao contrário
+			source := ageSortField(known)
+			if source == "" {
+				return fmt.Errorf("%w: %s", ErrInvalidField, field)
+			}
+			field, desc = source, !desc
+		}
+		if !known[field] {
+			return fmt.Errorf("%w: %s", ErrInvalidField, field)
+		}
+		parsed = append(parsed, sortKey{field: field, desc: desc})
+	}
+
+	type row struct {
+		item   T
+		values map[string]any
+	}
+	rows := make([]row, len(items))
+	for i, item := range items {
+		values, err := toJSONMap(item)
+		if err != nil {
+			return err
+		}
+		rows[i] = row{item: item, values: values}
+	}
+
+	sort.SliceStable(rows, func(i, j int) bool {
+		for _, key := range parsed {
+			cmp := compareValues(rows[i].values[key.field], rows[j].values[key.field])
+			if cmp == 0 {
+				continue
+			}
+			if key.desc {
+				return cmp > 0
+			}
+			return cmp < 0
+		}
+		return false
+	})
+
+	for i := range rows {
+		items[i] = rows[i].item
+	}
+	return nil
+}
+
+// ageSources são as datas das quais a idade dos itens é calculada, na ordem de preferência.
+var ageSources = []string{"creationTimestamp", "lastTimestamp"}
+
+// ageSortField devolve o campo de data usado para ordenar por idade, ou "" se o item não tiver nenhum.
+func ageSortField(known map[string]bool) string {
+	for _, field := range ageSources {
+		if known[field] {
+			return field
+		}
+	}
+	return ""
+}
+
+// compareValues compara valores decodificados de JSON (números, strings, booleanos e nulos).
+// Datas são serializadas em RFC3339 UTC, então a comparação de strings já as ordena.
+func compareValues(a, b any) int {
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		return -1
+	case b == nil:
+		return 1
+	}
+
+	switch av := a.(type) {
+	case float64:
+		if bv, ok := b.(float64); ok {
+			switch {
+			case av < bv:
+				return -1
+			case av > bv:
+				return 1
+			}
+			return 0
+		}
+	case string:
+		if bv, ok := b.(string); ok {
+			return strings.Compare(av, bv)
+		}
+	case bool:
+		if bv, ok := b.(bool); ok {
+			switch {
+			case av == bv:
+				return 0
+			case !av:
+				return -1
+			}
+			return 1
+		}
+	}
+	return strings.Compare(fmt.Sprint(a), fmt.Sprint(b))
+}
+
+func toJSONMap(item any) (map[string]any, error) {
+	raw, err := json.Marshal(item)
+	if err != nil {
+		return nil, err
+	}
+	var values map[string]any
+	if err := json.Unmarshal(raw, &values); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+// jsonFieldNames devolve os nomes JSON dos campos de um struct.
+func jsonFieldNames(t reflect.Type) map[string]bool {
+	names := map[string]bool{}
+	if t.Kind() != reflect.Struct {
+		return names
+	}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		switch name {
+		case "-":
+			continue
+		case "":
+			name = field.Name
+		}
+		names[name] = true
+	}
+	return names
+}
@@ -0,0 +1,132 @@
+package k8s
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type sortTestItem struct {
+	Nome              string      `json:"nome"`
+	Status            string      `json:"status"`
+	Restarts          int         `json:"restarts"`
+	Ready             bool        `json:"ready"`
+	Node              *string     `json:"node"`
+	CreationTimestamp metav1.Time `json:"creationTimestamp"`
+	Age               string      `json:"age"`
+}
+
+func TestSortItems(t *testing.T) {
+	base := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	node := "node-1"
+	items := []sortTestItem{
+		// Idades em texto que, comparadas como string, ficariam fora de ordem
+		{Nome: "c", Status: "Running", Restarts: 10, Ready: true, CreationTimestamp: metav1.NewTime(base), Age: "10d"},
+		{Nome: "a", Status: "Pending", Restarts: 2, CreationTimestamp: metav1.NewTime(base.Add(240 * time.Hour)), Age: "2h", Node: &node},
+		{Nome: "b", Status: "Running", Restarts: 2, Ready: true, CreationTimestamp: metav1.NewTime(base.Add(240*time.Hour + 115*time.Minute)), Age: "5m"},
+	}
+
+	tests := []struct {
+		name    string
+		keys    []string
+		want    []string
+		wantErr bool
+	}{
+		{name: "string crescente", keys: []string{"nome"}, want: []string{"a", "b", "c"}},
+		{name: "string decrescente", keys: []string{"-nome"}, want: []string{"c", "b", "a"}},
+		{name: "números comparados como números", keys: []string{"restarts"}, want: []string{"a", "b", "c"}},
+		{name: "empate segue a ordem original", keys: []string{"status"}, want: []string{"a", "c", "b"}},
+		{name: "desempate pelo segundo campo", keys: []string{"status", "-restarts"}, want: []string{"a", "c", "b"}},
+		{name: "booleanos", keys: []string{"-ready", "nome"}, want: []string{"b", "c", "a"}},
+		{name: "nulos primeiro", keys: []string{"node", "nome"}, want: []string{"b", "c", "a"}},
+		{name: "datas", keys: []string{"creationTimestamp"}, want: []string{"c", "a", "b"}},
+		{name: "idade do mais novo para o mais antigo", keys: []string{"age"}, want: []string{"b", "a", "c"}},
+		{name: "idade do mais antigo para o mais novo", keys: []string{"-age"}, want: []string{"c", "a", "b"}},
+		{name: "campo inexistente", keys: []string{"idade"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sorted := slices.Clone(items)
+			err := sortItems(sorted, tt.keys)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidField) {
+					t.Fatalf("sortItems() erro = %v, esperado ErrInvalidField", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("sortItems() erro inesperado: %v", err)
+			}
+			var got []string
+			for _, item := range sorted {
+				got = append(got, item.Nome)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("sortItems(%v) = %v, esperado %v", tt.keys, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSortItemsAgeWithoutSource(t *testing.T) {
+	type item struct {
+		Age string `json:"age"`
+	}
+	err := sortItems([]item{{Age: "5m"}, {Age: "2h"}}, []string{"age"})
+	if !errors.Is(err, ErrInvalidField) {
+		t.Errorf("sortItems() erro = %v, esperado ErrInvalidField", err)
+	}
+}
+
+func TestCompareValues(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b any
+		want int
+	}{
+		{name: "nulos iguais", a: nil, b: nil, want: 0},
+		{name: "nulo antes", a: nil, b: "x", want: -1},
+		{name: "nulo depois", a: 1.0, b: nil, want: 1},
+		{name: "números", a: 2.0, b: 10.0, want: -1},
+		{name: "números iguais", a: 3.0, b: 3.0, want: 0},
+		{name: "strings", a: "b", b: "a", want: 1},
+		{name: "datas RFC3339", a: "2024-01-02T00:00:00Z", b: "2024-01-10T00:00:00Z", want: -1},
+		{name: "false antes de true", a: false, b: true, want: -1},
+		{name: "booleanos iguais", a: true, b: true, want: 0},
+		{name: "tipos diferentes pelo texto", a: 1.0, b: "a", want: -1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := compareValues(tt.a, tt.b); got != tt.want {
+				t.Errorf("compareValues(%v, %v) = %d, esperado %d", tt.a, tt.b, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProjectFields(t *testing.T) {
+	total := int64(1)
+	result := ListResult[sortTestItem]{Items: []sortTestItem{{Nome: "a", Restarts: 3}}, Total: &total, Continue: "x"}
+
+	projected, err := ProjectFields(result, []string{"nome", "restarts"})
+	if err != nil {
+		t.Fatalf("ProjectFields() erro inesperado: %v", err)
+	}
+	if projected.Total != &total || projected.Continue != "x" {
+		t.Errorf("ProjectFields() não manteve total e continue")
+	}
+	want := map[string]any{"nome": "a", "restarts": 3.0}
+	if len(projected.Items) != 1 || len(projected.Items[0]) != len(want) ||
+		projected.Items[0]["nome"] != want["nome"] || projected.Items[0]["restarts"] != want["restarts"] {
+		t.Errorf("ProjectFields() = %v, esperado [%v]", projected.Items, want)
+	}
+
+	if _, err := ProjectFields(result, []string{"senha"}); !errors.Is(err, ErrInvalidField) {
+		t.Errorf("ProjectFields() com campo inexistente: erro = %v, esperado ErrInvalidField", err)
+	}
+}
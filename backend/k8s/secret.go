@@ -0,0 +1,252 @@
+package k8s
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"unicode/utf8"
+
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// secretMask substitui os valores de um Secret nas listagens e detalhes.
+const secretMask = "********"
+
+// SecretInfo resume um Secret sem expor os valores: apenas nomes e tamanhos das chaves.
+type SecretInfo struct {
+	Nome              string            `json:"nome"`
+	Namespace         string            `json:"namespace"`
+	Type              string            `json:"type"`
+	Keys              []DataKeyInfo     `json:"keys"`
+	Immutable         bool              `json:"immutable"`
+	UsedBy            []string          `json:"usedBy"`
+	Labels            map[string]string `json:"labels,omitempty"`
+	CreationTimestamp metav1.Time       `json:"creationTimestamp"`
+	Age               string            `json:"age"`
+}
+
+// SecretDetail inclui as chaves com os valores mascarados. Os valores reais só saem
+// por RevealSecret. Em Secrets TLS, Certificate resume o certificado.
+type SecretDetail struct {
+	SecretInfo
+	Annotations map[string]string `json:"annotations,omitempty"`
+	Data        map[string]string `json:"data"`
+	Certificate *CertificateInfo  `json:"certificate,omitempty"`
+	Events      []EventInfo       `json:"events"`
+}
+
+// SecretValues são os valores revelados de um Secret. Valores que não são UTF-8 vão
+// para BinaryData (serializado em base64).
+type SecretValues struct {
+	Nome       string            `json:"nome"`
+	Namespace  string            `json:"namespace"`
+	Data       map[string]string `json:"data"`
+	BinaryData map[string][]byte `json:"binaryData,omitempty"`
+}
+
+// SecretUpdate altera chaves de um Secret: Set e SetBinary criam ou substituem
+// chaves e Remove as apaga. As demais chaves são mantidas.
+type SecretUpdate struct {
+	Set       map[string]string
+	SetBinary map[string][]byte
+	Remove    []string
+}
+
+func (s SecretInfo) GetNamespace() string { return s.Namespace }
+func (s SecretInfo) GetName() string      { return s.Nome }
+
+// ListSecrets retorna uma página de SecretInfo do namespace informado (ou de todos, com AllNamespaces).
+func ListSecrets(namespace string, opts ListOptions) (ListResult[SecretInfo], error) {
+	return listNamespacedPage(namespace, opts, listSecrets)
+}
+
+func listSecrets(namespace string, listOpts metav1.ListOptions) ([]SecretInfo, metav1.ListMeta, error) {
+	secrets, err := client.CoreV1().Secrets(namespace).List(context.TODO(), listOpts)
+	if err != nil {
+		return nil, metav1.ListMeta{}, fmt.Errorf("erro ao listar secrets: %w", err)
+	}
+	usedBy, err := secretUsers(namespace)
+	if err != nil {
+		return nil, metav1.ListMeta{}, err
+	}
+
+	infos := make([]SecretInfo, 0, len(secrets.Items))
+	for _, secret := range secrets.Items {
+		infos = append(infos, newSecretInfo(secret, usedBy))
+	}
+	return infos, secrets.ListMeta, nil
+}
+
+// GetSecret retorna o Secret com os valores mascarados, os deployments que o usam e eventos recentes.
+func GetSecret(namespace, name string) (SecretDetail, error) {
+	secret, err := client.CoreV1().Secrets(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+	if err != nil {
+		return SecretDetail{}, fmt.Errorf("secret não encontrado: %w", err)
+	}
+	usedBy, err := secretUsers(namespace)
+	if err != nil {
+		return SecretDetail{}, err
+	}
+
+	// A anotação do kubectl apply guarda o manifesto inteiro, inclusive os valores
+	detail := SecretDetail{
+		SecretInfo:  newSecretInfo(*secret, usedBy),
+		Annotations: withoutLastApplied(secret.Annotations),
+		Data:        make(map[string]string, len(secret.Data)),
+		Certificate: SecretCertificate(secret),
+	}
+	for key := range secret.Data {
+		detail.Data[key] = secretMask
+	}
+
+	detail.Events = detailEvents(namespace, v1.SchemeGroupVersion.WithKind("Secret"), secret.Name, secret.UID)
+	return detail, nil
+}
+
+// RevealSecret retorna os valores das chaves pedidas, ou de todas quando keys é vazio.
+// Chaves inexistentes retornam ErrInvalidData.
+func RevealSecret(namespace, name string, keys []string) (SecretValues, error) {
+	secret, err := client.CoreV1().Secrets(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+	if err != nil {
+		return SecretValues{}, fmt.Errorf("secret não encontrado: %w", err)
+	}
+
+	if len(keys) == 0 {
+		for key := range secret.Data {
+			keys = append(keys, key)
+		}
+	}
+	values := SecretValues{Nome: secret.Name, Namespace: secret.Namespace, Data: map[string]string{}}
+	for _, key := range keys {
+		value, ok := secret.Data[key]
+		if !ok {
+			return SecretValues{}, fmt.Errorf("%w: chave '%s' não existe no secret '%s'", ErrInvalidData, key, name)
+		}
+		if utf8.Valid(value) {
+			values.Data[key] = string(value)
+			continue
+		}
+		if values.BinaryData == nil {
+			values.BinaryData = map[string][]byte{}
+		}
+		values.BinaryData[key] = value
+	}
+	return values, nil
+}
+
+// UpdateSecretKeys cria, substitui ou remove chaves do Secret, mantendo as demais.
+// O Secret devolvido não inclui os valores.
+func UpdateSecretKeys(namespace, name string, update SecretUpdate, opts MutateOptions) (*v1.Secret, error) {
+	if err := validateDataKeys(update.Set, update.SetBinary); err != nil {
+		return nil, err
+	}
+
+	secrets := client.CoreV1().Secrets(namespace)
+	secret, err := secrets.Get(context.TODO(), name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("secret não encontrado: %w", err)
+	}
+	if secret.Immutable != nil && *secret.Immutable {
+		return nil, apierrors.NewConflict(v1.Resource("secrets"), name, errors.New("Secret imutável; crie um novo Secret"))
+	}
+
+	if secret.Data == nil {
+		secret.Data = map[string][]byte{}
+	}
+	for _, key := range update.Remove {
+		delete(secret.Data, key)
+	}
+	for key, value := range update.Set {
+		secret.Data[key] = []byte(value)
+	}
+	for key, value := range update.SetBinary {
+		secret.Data[key] = value
+	}
+	if err := ValidateSecretData(secret.Type, secret.Data); err != nil {
+		return nil, err
+	}
+
+	updated, err := secrets.Update(context.TODO(), secret, opts.updateOptions())
+	if err != nil {
+		return nil, fmt.Errorf("erro ao atualizar secret: %w", err)
+	}
+	return withoutSecretValues(updated), nil
+}
+
+// withoutSecretValues remove os valores do Secret antes de devolvê-lo ao cliente,
+// inclusive a cópia guardada pelo kubectl apply.
+func withoutSecretValues(secret *v1.Secret) *v1.Secret {
+	secret.Data = nil
+	secret.StringData = nil
+	secret.Annotations = withoutLastApplied(secret.Annotations)
+	return secret
+}
+
+func newSecretInfo(secret v1.Secret, usedBy map[string][]string) SecretInfo {
+	info := SecretInfo{
+		Nome:              secret.Name,
+		Namespace:         secret.Namespace,
+		Type:              string(secret.Type),
+		Keys:              []DataKeyInfo{},
+		Immutable:         secret.Immutable != nil && *secret.Immutable,
+		UsedBy:            usedBy[secret.Namespace+"/"+secret.Name],
+		Labels:            secret.Labels,
+		CreationTimestamp: secret.CreationTimestamp,
+		Age:               age(secret.CreationTimestamp),
+	}
+	if info.UsedBy == nil {
+		info.UsedBy = []string{}
+	}
+
+	for key, value := range secret.Data {
+		info.Keys = append(info.Keys, DataKeyInfo{Name: key, Size: len(value), Binary: !utf8.Valid(value)})
+	}
+	sort.Slice(info.Keys, func(i, j int) bool { return info.Keys[i].Name < info.Keys[j].Name })
+	return info
+}
+
+// secretUsers mapeia "namespace/nome" de cada Secret para os deployments que o usam.
+func secretUsers(namespace string) (map[string][]string, error) {
+	deployments, err := client.AppsV1().Deployments(namespace).List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("erro ao listar deployments: %w", err)
+	}
+	return deploymentReferences(deployments.Items, podSpecSecrets), nil
+}
+
+// podSpecSecrets devolve os Secrets usados pelo pod em variáveis de ambiente, volumes
+// (inclusive projetados) e imagePullSecrets.
+func podSpecSecrets(spec v1.PodSpec) map[string]bool {
+	names := map[string]bool{}
+	for _, c := range append(append([]v1.Container{}, spec.InitContainers...), spec.Containers...) {
+		for _, envFrom := range c.EnvFrom {
+			if envFrom.SecretRef != nil {
+				names[envFrom.SecretRef.Name] = true
+			}
+		}
+		for _, env := range c.Env {
+			if env.ValueFrom != nil && env.ValueFrom.SecretKeyRef != nil {
+				names[env.ValueFrom.SecretKeyRef.Name] = true
+			}
+		}
+	}
+	for _, volume := range spec.Volumes {
+		if volume.Secret != nil {
+			names[volume.Secret.SecretName] = true
+		}
+		if volume.Projected != nil {
+			for _, source := range volume.Projected.Sources {
+				if source.Secret != nil {
+					names[source.Secret.Name] = true
+				}
+			}
+		}
+	}
+	for _, ref := range spec.ImagePullSecrets {
+		names[ref.Name] = true
+	}
+	return names
+}
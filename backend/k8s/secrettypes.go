@@ -0,0 +1,240 @@
+package k8s
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"strings"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// defaultRegistryServer é o registry usado pelo docker quando nenhum é informado.
+const defaultRegistryServer = "https://index.docker.io/v1/"
+
+// DockerRegistryAuth são as credenciais de um Secret kubernetes.io/dockerconfigjson,
+// como no kubectl create secret docker-registry.
+type DockerRegistryAuth struct {
+	Server   string `json:"server,omitempty"`
+	Username string `json:"username"`
+	Password string `json:"password"`
+	Email    string `json:"email,omitempty"`
+}
+
+// CertificateInfo resume o certificado de um Secret kubernetes.io/tls. Não contém a chave.
+type CertificateInfo struct {
+	Subject     string      `json:"subject"`
+	Issuer      string      `json:"issuer"`
+	DNSNames    []string    `json:"dnsNames,omitempty"`
+	IPAddresses []string    `json:"ipAddresses,omitempty"`
+	NotBefore   metav1.Time `json:"notBefore"`
+	NotAfter    metav1.Time `json:"notAfter"`
+	Expired     bool        `json:"expired"`
+}
+
+// dockerConfigJSON é o formato de ~/.docker/config.json aceito pelo kubelet.
+type dockerConfigJSON struct {
+	Auths map[string]dockerConfigEntry `json:"auths"`
+}
+
+type dockerConfigEntry struct {
+	Username string `json:"username,omitempty"`
+	Password string `json:"password,omitempty"`
+	Email    string `json:"email,omitempty"`
+	Auth     string `json:"auth,omitempty"`
+}
+
+// BuildDockerRegistrySecret monta um Secret kubernetes.io/dockerconfigjson com as
+// credenciais de um registry.
+func BuildDockerRegistrySecret(namespace, name string, auth DockerRegistryAuth) (*v1.Secret, error) {
+	if auth.Username == "" || auth.Password == "" {
+		return nil, fmt.Errorf("%w: 'username' e 'password' do registry são obrigatórios", ErrInvalidData)
+	}
+	if auth.Server == "" {
+		auth.Server = defaultRegistryServer
+	}
+
+	config, err := json.Marshal(dockerConfigJSON{Auths: map[string]dockerConfigEntry{
+		auth.Server: {
+			Username: auth.Username,
+			Password: auth.Password,
+			Email:    auth.Email,
+			Auth:     base64.StdEncoding.EncodeToString([]byte(auth.Username + ":" + auth.Password)),
+		},
+	}})
+	if err != nil {
+		return nil, fmt.Errorf("erro ao montar .dockerconfigjson: %w", err)
+	}
+	return newTypedSecret(namespace, name, v1.SecretTypeDockerConfigJson, map[string][]byte{
+		v1.DockerConfigJsonKey: config,
+	}), nil
+}
+
+// BuildTLSSecret monta um Secret kubernetes.io/tls depois de validar o par certificado/chave.
+// Com hosts, cada host precisa estar coberto pelos SANs do certificado.
+func BuildTLSSecret(namespace, name string, cert, key []byte, hosts []string) (*v1.Secret, *CertificateInfo, error) {
+	info, err := validateTLSPair(cert, key, hosts)
+	if err != nil {
+		return nil, nil, err
+	}
+	return newTypedSecret(namespace, name, v1.SecretTypeTLS, map[string][]byte{
+		v1.TLSCertKey:       cert,
+		v1.TLSPrivateKeyKey: key,
+	}), info, nil
+}
+
+// ValidateSecretData confere as chaves exigidas pelos tipos de Secret embutidos no
+// Kubernetes, antes de enviar o objeto ao API server. Tipos desconhecidos não são validados.
+func ValidateSecretData(secretType v1.SecretType, data map[string][]byte) error {
+	switch secretType {
+	case v1.SecretTypeDockerConfigJson:
+		if err := requireSecretKeys(secretType, data, v1.DockerConfigJsonKey); err != nil {
+			return err
+		}
+		var config dockerConfigJSON
+		if err := json.Unmarshal(data[v1.DockerConfigJsonKey], &config); err != nil || len(config.Auths) == 0 {
+			return fmt.Errorf("%w: '%s' deve ser um JSON com ao menos um registry em 'auths'", ErrInvalidData, v1.DockerConfigJsonKey)
+		}
+	case v1.SecretTypeTLS:
+		if err := requireSecretKeys(secretType, data, v1.TLSCertKey, v1.TLSPrivateKeyKey); err != nil {
+			return err
+		}
+		// Validade e SANs só são exigidos na criação (BuildTLSSecret): um certificado
+		// expirado ou só com CN não pode impedir a edição das demais chaves
+		_, err := matchTLSPair(data[v1.TLSCertKey], data[v1.TLSPrivateKeyKey])
+		return err
+	case v1.SecretTypeBasicAuth:
+		// Como no API server, basta uma das duas chaves
+		if len(data[v1.BasicAuthUsernameKey]) == 0 && len(data[v1.BasicAuthPasswordKey]) == 0 {
+			return fmt.Errorf("%w: secret %s exige a chave '%s' ou '%s'", ErrInvalidData, secretType, v1.BasicAuthUsernameKey, v1.BasicAuthPasswordKey)
+		}
+	case v1.SecretTypeSSHAuth:
+		if err := requireSecretKeys(secretType, data, v1.SSHAuthPrivateKey); err != nil {
+			return err
+		}
+		block, _ := pem.Decode(data[v1.SSHAuthPrivateKey])
+		if block == nil || !strings.HasSuffix(block.Type, "PRIVATE KEY") {
+			return fmt.Errorf("%w: '%s' deve ser uma chave privada em formato PEM/OpenSSH", ErrInvalidData, v1.SSHAuthPrivateKey)
+		}
+	}
+	return nil
+}
+
+// SecretCertificate resume o certificado de um Secret TLS; devolve nil para outros tipos
+// ou certificados ilegíveis.
+func SecretCertificate(secret *v1.Secret) *CertificateInfo {
+	if secret.Type != v1.SecretTypeTLS {
+		return nil
+	}
+	leaf, err := parseLeafCertificate(secret.Data[v1.TLSCertKey])
+	if err != nil {
+		return nil
+	}
+	return newCertificateInfo(leaf)
+}
+
+func newTypedSecret(namespace, name string, secretType v1.SecretType, data map[string][]byte) *v1.Secret {
+	return &v1.Secret{
+		TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "Secret"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Type: secretType,
+		Data: data,
+	}
+}
+
+func requireSecretKeys(secretType v1.SecretType, data map[string][]byte, keys ...string) error {
+	var missing []string
+	for _, key := range keys {
+		if len(data[key]) == 0 {
+			missing = append(missing, "'"+key+"'")
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("%w: secret %s exige as chaves %s", ErrInvalidData, secretType, strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// validateTLSPair confere que a chave corresponde ao certificado, que o certificado está
+// dentro da validade, que declara SANs e, com hosts, que cobre cada um deles.
+func validateTLSPair(cert, key []byte, hosts []string) (*CertificateInfo, error) {
+	leaf, err := matchTLSPair(cert, key)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if now.After(leaf.NotAfter) {
+		return nil, fmt.Errorf("%w: certificado expirou em %s", ErrInvalidData, leaf.NotAfter.UTC().Format(time.RFC3339))
+	}
+	if now.Before(leaf.NotBefore) {
+		return nil, fmt.Errorf("%w: certificado só é válido a partir de %s", ErrInvalidData, leaf.NotBefore.UTC().Format(time.RFC3339))
+	}
+	// Clientes atuais ignoram o CN; sem SANs o certificado não serve para nenhum host
+	if len(leaf.DNSNames) == 0 && len(leaf.IPAddresses) == 0 {
+		return nil, fmt.Errorf("%w: certificado não possui Subject Alternative Names (DNS ou IP)", ErrInvalidData)
+	}
+	var uncovered []string
+	for _, host := range hosts {
+		if err := leaf.VerifyHostname(host); err != nil {
+			uncovered = append(uncovered, host)
+		}
+	}
+	if len(uncovered) > 0 {
+		return nil, fmt.Errorf("%w: certificado não cobre os hosts %s", ErrInvalidData, strings.Join(uncovered, ", "))
+	}
+	return newCertificateInfo(leaf), nil
+}
+
+// matchTLSPair confere que a chave corresponde ao certificado e devolve o primeiro
+// certificado da cadeia.
+func matchTLSPair(cert, key []byte) (*x509.Certificate, error) {
+	if _, err := tls.X509KeyPair(cert, key); err != nil {
+		return nil, fmt.Errorf("%w: par certificado/chave inválido: %v", ErrInvalidData, err)
+	}
+	return parseLeafCertificate(cert)
+}
+
+// parseLeafCertificate lê o primeiro certificado da cadeia em PEM.
+func parseLeafCertificate(cert []byte) (*x509.Certificate, error) {
+	for rest := bytes.TrimSpace(cert); len(rest) > 0; {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		leaf, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("%w: certificado ilegível: %v", ErrInvalidData, err)
+		}
+		return leaf, nil
+	}
+	return nil, fmt.Errorf("%w: nenhum certificado PEM encontrado", ErrInvalidData)
+}
+
+func newCertificateInfo(leaf *x509.Certificate) *CertificateInfo {
+	info := &CertificateInfo{
+		Subject:   leaf.Subject.String(),
+		Issuer:    leaf.Issuer.String(),
+		DNSNames:  leaf.DNSNames,
+		NotBefore: metav1.NewTime(leaf.NotBefore),
+		NotAfter:  metav1.NewTime(leaf.NotAfter),
+		Expired:   time.Now().After(leaf.NotAfter),
+	}
+	for _, ip := range leaf.IPAddresses {
+		info.IPAddresses = append(info.IPAddresses, ip.String())
+	}
+	return info
+}
@@ -0,0 +1,203 @@
+package k8s
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+// testCertificate descreve um certificado autoassinado gerado pelos testes; generate
+// devolve o certificado e a chave correspondente em PEM.
+type testCertificate struct {
+	commonName string
+	dnsNames   []string
+	ips        []net.IP
+	notBefore  time.Time
+	notAfter   time.Time
+}
+
+func (c testCertificate) generate(t *testing.T) (cert, key []byte) {
+	t.Helper()
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("erro ao gerar chave: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: c.commonName},
+		DNSNames:     c.dnsNames,
+		IPAddresses:  c.ips,
+		NotBefore:    c.notBefore,
+		NotAfter:     c.notAfter,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &priv.PublicKey, priv)
+	if err != nil {
+		t.Fatalf("erro ao gerar certificado: %v", err)
+	}
+	keyDER, err := x509.MarshalPKCS8PrivateKey(priv)
+	if err != nil {
+		t.Fatalf("erro ao serializar chave: %v", err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER})
+}
+
+func TestValidateTLSPair(t *testing.T) {
+	now := time.Now()
+	valid := testCertificate{
+		commonName: "app.example.com",
+		dnsNames:   []string{"app.example.com", "*.api.example.com"},
+		ips:        []net.IP{net.ParseIP("10.0.0.1")},
+		notBefore:  now.Add(-time.Hour),
+		notAfter:   now.Add(24 * time.Hour),
+	}
+	cert, key := valid.generate(t)
+	_, otherKey := valid.generate(t)
+	expiredCert, expiredKey := testCertificate{dnsNames: []string{"app.example.com"}, notBefore: now.Add(-48 * time.Hour), notAfter: now.Add(-24 * time.Hour)}.generate(t)
+	futureCert, futureKey := testCertificate{dnsNames: []string{"app.example.com"}, notBefore: now.Add(24 * time.Hour), notAfter: now.Add(48 * time.Hour)}.generate(t)
+	cnOnlyCert, cnOnlyKey := testCertificate{commonName: "app.example.com", notBefore: now.Add(-time.Hour), notAfter: now.Add(time.Hour)}.generate(t)
+
+	tests := []struct {
+		name    string
+		cert    []byte
+		key     []byte
+		hosts   []string
+		wantErr string
+	}{
+		{name: "par válido", cert: cert, key: key},
+		{name: "hosts cobertos", cert: cert, key: key, hosts: []string{"app.example.com", "v1.api.example.com", "10.0.0.1"}},
+		{name: "chave de outro certificado", cert: cert, key: otherKey, wantErr: "par certificado/chave inválido"},
+		{name: "certificado ilegível", cert: []byte("não é PEM"), key: key, wantErr: "par certificado/chave inválido"},
+		{name: "certificado expirado", cert: expiredCert, key: expiredKey, wantErr: "expirou"},
+		{name: "certificado ainda não válido", cert: futureCert, key: futureKey, wantErr: "só é válido a partir"},
+		{name: "certificado só com CN", cert: cnOnlyCert, key: cnOnlyKey, wantErr: "Subject Alternative Names"},
+		{name: "host não coberto", cert: cert, key: key, hosts: []string{"app.example.com", "outro.example.com"}, wantErr: "outro.example.com"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info, err := validateTLSPair(tt.cert, tt.key, tt.hosts)
+			if tt.wantErr != "" {
+				if !errors.Is(err, ErrInvalidData) || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("validateTLSPair() erro = %v, esperado ErrInvalidData com %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("validateTLSPair() erro inesperado: %v", err)
+			}
+			if info.Expired || len(info.DNSNames) != 2 || len(info.IPAddresses) != 1 || info.IPAddresses[0] != "10.0.0.1" {
+				t.Errorf("validateTLSPair() info = %+v", info)
+			}
+		})
+	}
+}
+
+func TestValidateSecretData(t *testing.T) {
+	now := time.Now()
+	cert, key := testCertificate{dnsNames: []string{"app.example.com"}, notBefore: now.Add(-time.Hour), notAfter: now.Add(time.Hour)}.generate(t)
+	_, otherKey := testCertificate{dnsNames: []string{"app.example.com"}, notBefore: now.Add(-time.Hour), notAfter: now.Add(time.Hour)}.generate(t)
+	expiredCert, expiredKey := testCertificate{dnsNames: []string{"app.example.com"}, notBefore: now.Add(-48 * time.Hour), notAfter: now.Add(-24 * time.Hour)}.generate(t)
+	cnOnlyCert, cnOnlyKey := testCertificate{commonName: "app.example.com", notBefore: now.Add(-time.Hour), notAfter: now.Add(time.Hour)}.generate(t)
+
+	tests := []struct {
+		name       string
+		secretType v1.SecretType
+		data       map[string][]byte
+		wantErr    bool
+	}{
+		{name: "opaque aceita qualquer chave", secretType: v1.SecretTypeOpaque, data: map[string][]byte{"qualquer": []byte("x")}},
+		{name: "tipo desconhecido não é validado", secretType: "example.com/custom", data: nil},
+		{
+			name:       "dockerconfigjson válido",
+			secretType: v1.SecretTypeDockerConfigJson,
+			data:       map[string][]byte{v1.DockerConfigJsonKey: []byte(`{"auths":{"registry.example.com":{"auth":"dTpw"}}}`)},
+		},
+		{name: "dockerconfigjson sem a chave", secretType: v1.SecretTypeDockerConfigJson, data: map[string][]byte{}, wantErr: true},
+		{
+			name:       "dockerconfigjson sem registries",
+			secretType: v1.SecretTypeDockerConfigJson,
+			data:       map[string][]byte{v1.DockerConfigJsonKey: []byte(`{"auths":{}}`)},
+			wantErr:    true,
+		},
+		{
+			name:       "tls válido",
+			secretType: v1.SecretTypeTLS,
+			data:       map[string][]byte{v1.TLSCertKey: cert, v1.TLSPrivateKeyKey: key},
+		},
+		{
+			name:       "tls com ca.crt adicionado",
+			secretType: v1.SecretTypeTLS,
+			data:       map[string][]byte{v1.TLSCertKey: cert, v1.TLSPrivateKeyKey: key, "ca.crt": cert},
+		},
+		{
+			// Só a criação (BuildTLSSecret) confere validade e SANs
+			name:       "tls expirado pode ser editado",
+			secretType: v1.SecretTypeTLS,
+			data:       map[string][]byte{v1.TLSCertKey: expiredCert, v1.TLSPrivateKeyKey: expiredKey, "ca.crt": expiredCert},
+		},
+		{
+			name:       "tls só com CN pode ser editado",
+			secretType: v1.SecretTypeTLS,
+			data:       map[string][]byte{v1.TLSCertKey: cnOnlyCert, v1.TLSPrivateKeyKey: cnOnlyKey},
+		},
+		{name: "tls sem a chave privada", secretType: v1.SecretTypeTLS, data: map[string][]byte{v1.TLSCertKey: cert}, wantErr: true},
+		{
+			name:       "tls com chave de outro certificado",
+			secretType: v1.SecretTypeTLS,
+			data:       map[string][]byte{v1.TLSCertKey: cert, v1.TLSPrivateKeyKey: otherKey},
+			wantErr:    true,
+		},
+		{name: "basic-auth só com usuário", secretType: v1.SecretTypeBasicAuth, data: map[string][]byte{v1.BasicAuthUsernameKey: []byte("admin")}},
+		{name: "basic-auth sem usuário e senha", secretType: v1.SecretTypeBasicAuth, data: map[string][]byte{}, wantErr: true},
+		{name: "ssh-auth com chave PEM", secretType: v1.SecretTypeSSHAuth, data: map[string][]byte{v1.SSHAuthPrivateKey: key}},
+		{name: "ssh-auth com certificado", secretType: v1.SecretTypeSSHAuth, data: map[string][]byte{v1.SSHAuthPrivateKey: cert}, wantErr: true},
+		{name: "ssh-auth sem a chave", secretType: v1.SecretTypeSSHAuth, data: map[string][]byte{}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateSecretData(tt.secretType, tt.data)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidData) {
+					t.Fatalf("ValidateSecretData() erro = %v, esperado ErrInvalidData", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ValidateSecretData() erro inesperado: %v", err)
+			}
+		})
+	}
+}
+
+func TestBuildTLSSecret(t *testing.T) {
+	now := time.Now()
+	expiredCert, expiredKey := testCertificate{dnsNames: []string{"app.example.com"}, notBefore: now.Add(-48 * time.Hour), notAfter: now.Add(-24 * time.Hour)}.generate(t)
+	if _, _, err := BuildTLSSecret("default", "tls", expiredCert, expiredKey, nil); !errors.Is(err, ErrInvalidData) {
+		t.Errorf("BuildTLSSecret() com certificado expirado: erro = %v, esperado ErrInvalidData", err)
+	}
+
+	cert, key := testCertificate{dnsNames: []string{"app.example.com"}, notBefore: now.Add(-time.Hour), notAfter: now.Add(time.Hour)}.generate(t)
+	secret, info, err := BuildTLSSecret("default", "tls", cert, key, []string{"app.example.com"})
+	if err != nil {
+		t.Fatalf("BuildTLSSecret() erro inesperado: %v", err)
+	}
+	if secret.Type != v1.SecretTypeTLS || string(secret.Data[v1.TLSCertKey]) != string(cert) || string(secret.Data[v1.TLSPrivateKeyKey]) != string(key) {
+		t.Errorf("BuildTLSSecret() = %+v", secret)
+	}
+	if info == nil || info.DNSNames[0] != "app.example.com" {
+		t.Errorf("BuildTLSSecret() info = %+v", info)
+	}
+}
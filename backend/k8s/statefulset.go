@@ -0,0 +1,612 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// StatefulSetInfo resume um StatefulSet e o progresso do seu rollout.
+// Status é calculado por statefulSetStatus: Ready, Progressing, Partitioned, Degraded ou ScaledToZero.
+type StatefulSetInfo struct {
+	Nome                string              `json:"nome"`
+	Namespace           string              `json:"namespace"`
+	Status              string              `json:"status"`
+	Image               string              `json:"image"`
+	Replicas            int32               `json:"replicas"`
+	ReadyReplicas       int32               `json:"readyReplicas"`
+	CurrentReplicas     int32               `json:"currentReplicas"`
+	UpdatedReplicas     int32               `json:"updatedReplicas"`
+	AvailableReplicas   int32               `json:"availableReplicas"`
+	CurrentRevision     string              `json:"currentRevision,omitempty"`
+	UpdateRevision      string              `json:"updateRevision,omitempty"`
+	ServiceName         string              `json:"serviceName"`
+	PodManagementPolicy string              `json:"podManagementPolicy"`
+	UpdateStrategy      string              `json:"updateStrategy"`
+	Partition           int32               `json:"partition"`
+	Generation          int64               `json:"generation"`
+	ObservedGeneration  int64               `json:"observedGeneration"`
+	Containers          []ContainerSpecInfo `json:"containers"`
+	Selector            map[string]string   `json:"selector"`
+	Labels              map[string]string   `json:"labels,omitempty"`
+	CreationTimestamp   metav1.Time         `json:"creationTimestamp"`
+	Age                 string              `json:"age"`
+}
+
+// StatefulSetDetail é o modelo completo de um StatefulSet, com o estado de cada
+// ordinal, os templates de volume e eventos recentes.
+type StatefulSetDetail struct {
+	StatefulSetInfo
+	Annotations          map[string]string         `json:"annotations,omitempty"`
+	Ordinals             []StatefulSetOrdinal      `json:"ordinals"`
+	VolumeClaimTemplates []VolumeClaimTemplateInfo `json:"volumeClaimTemplates"`
+	RetentionPolicy      *PVCRetentionInfo         `json:"pvcRetentionPolicy,omitempty"`
+	Events               []EventInfo               `json:"events"`
+}
+
+// StatefulSetOrdinal descreve o pod de um ordinal e os PVCs criados para ele.
+// Pod é nil quando o pod ainda não existe (ex.: criação ordenada em andamento).
+// Updated indica que o pod já está na revisão mais recente.
+type StatefulSetOrdinal struct {
+	Ordinal int32        `json:"ordinal"`
+	PodName string       `json:"podName"`
+	Pod     *PodInfo     `json:"pod,omitempty"`
+	Ready   bool         `json:"ready"`
+	Updated bool         `json:"updated"`
+	Claims  []PVCBinding `json:"claims"`
+}
+
+// PVCBinding mostra a situação do PVC de um ordinal. Phase é Missing quando o PVC
+// ainda não foi criado.
+type PVCBinding struct {
+	Name         string `json:"name"`
+	Template     string `json:"template"`
+	Phase        string `json:"phase"`
+	Volume       string `json:"volume,omitempty"`
+	Capacity     string `json:"capacity,omitempty"`
+	StorageClass string `json:"storageClass,omitempty"`
+}
+
+// VolumeClaimTemplateInfo resume um template de PVC do StatefulSet.
+type VolumeClaimTemplateInfo struct {
+	Name         string   `json:"name"`
+	Storage      string   `json:"storage"`
+	StorageClass string   `json:"storageClass,omitempty"`
+	AccessModes  []string `json:"accessModes"`
+}
+
+// PVCRetentionInfo indica o que acontece com os PVCs quando o StatefulSet é removido
+// ou reduzido (Retain ou Delete).
+type PVCRetentionInfo struct {
+	WhenDeleted string `json:"whenDeleted"`
+	WhenScaled  string `json:"whenScaled"`
+}
+
+// VolumeClaimTemplate define um PVC por pod do StatefulSet, montado em MountPath no container.
+type VolumeClaimTemplate struct {
+	Name         string   `json:"name"`
+	MountPath    string   `json:"mountPath"`
+	Storage      string   `json:"storage"`
+	StorageClass string   `json:"storageClass,omitempty"`
+	AccessModes  []string `json:"accessModes,omitempty"`
+}
+
+// StatefulSetSpec reúne os parâmetros de criação de um StatefulSet. ServiceName é o
+// service headless que dá nome estável aos pods (padrão: o nome do StatefulSet).
+// PodManagementPolicy é OrderedReady (padrão) ou Parallel.
+type StatefulSetSpec struct {
+	Image                string
+	Replicas             int32
+	ContainerPort        int32
+	Env                  map[string]string
+	ServiceName          string
+	PodManagementPolicy  string
+	VolumeClaimTemplates []VolumeClaimTemplate
+}
+
+// StatefulSetUpdate lista as alterações de UpdateStatefulSet; campos nil não são alterados.
+// Container escolhe o container da imagem (padrão: o primeiro). Partition mantém os
+// ordinais abaixo dele na revisão atual durante o rollout.
+type StatefulSetUpdate struct {
+	Image     *string
+	Container string
+	Replicas  *int32
+	Partition *int32
+}
+
+func (s StatefulSetInfo) GetNamespace() string { return s.Namespace }
+func (s StatefulSetInfo) GetName() string      { return s.Nome }
+
+// ListStatefulSets retorna uma página de StatefulSetInfo do namespace informado (ou de todos, com AllNamespaces).
+func ListStatefulSets(namespace string, opts ListOptions) (ListResult[StatefulSetInfo], error) {
+	return listNamespacedPage(namespace, opts, listStatefulSets)
+}
+
+func listStatefulSets(namespace string, listOpts metav1.ListOptions) ([]StatefulSetInfo, metav1.ListMeta, error) {
+	statefulSets, err := client.AppsV1().StatefulSets(namespace).List(context.TODO(), listOpts)
+	if err != nil {
+		return nil, metav1.ListMeta{}, fmt.Errorf("erro ao listar statefulsets: %w", err)
+	}
+
+	infos := make([]StatefulSetInfo, 0, len(statefulSets.Items))
+	for _, sts := range statefulSets.Items {
+		infos = append(infos, newStatefulSetInfo(sts))
+	}
+	return infos, statefulSets.ListMeta, nil
+}
+
+// GetStatefulSet retorna os detalhes de um StatefulSet, com o pod e os PVCs de cada ordinal.
+func GetStatefulSet(namespace, name string) (StatefulSetDetail, error) {
+	sts, err := client.AppsV1().StatefulSets(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+	if err != nil {
+		return StatefulSetDetail{}, fmt.Errorf("statefulset não encontrado: %w", err)
+	}
+
+	detail := StatefulSetDetail{
+		StatefulSetInfo:      newStatefulSetInfo(*sts),
+		Annotations:          withoutLastApplied(sts.Annotations),
+		VolumeClaimTemplates: []VolumeClaimTemplateInfo{},
+	}
+	for _, template := range sts.Spec.VolumeClaimTemplates {
+		detail.VolumeClaimTemplates = append(detail.VolumeClaimTemplates, newVolumeClaimTemplateInfo(template))
+	}
+	if policy := sts.Spec.PersistentVolumeClaimRetentionPolicy; policy != nil {
+		detail.RetentionPolicy = &PVCRetentionInfo{
+			WhenDeleted: string(policy.WhenDeleted),
+			WhenScaled:  string(policy.WhenScaled),
+		}
+	}
+
+	detail.Ordinals, err = statefulSetOrdinals(sts)
+	if err != nil {
+		return detail, err
+	}
+	detail.Events = detailEvents(namespace, appsv1.SchemeGroupVersion.WithKind("StatefulSet"), sts.Name, sts.UID)
+	return detail, nil
+}
+
+// BuildStatefulSet monta um StatefulSet com um container e um PVC por pod para cada
+// template de volume.
+func BuildStatefulSet(namespace, name string, spec StatefulSetSpec) (*appsv1.StatefulSet, error) {
+	if spec.ServiceName == "" {
+		spec.ServiceName = name
+	}
+	policy := appsv1.PodManagementPolicyType(spec.PodManagementPolicy)
+	switch policy {
+	case "":
+		policy = appsv1.OrderedReadyPodManagement
+	case appsv1.OrderedReadyPodManagement, appsv1.ParallelPodManagement:
+	default:
+		return nil, fmt.Errorf("%w: podManagementPolicy deve ser OrderedReady ou Parallel", ErrInvalidUpdate)
+	}
+
+	// BuildDeployment já monta o container com porta e variáveis de ambiente
+	container := BuildDeployment(namespace, name, spec.Image, spec.Replicas, spec.ContainerPort, spec.Env).Spec.Template.Spec.Containers[0]
+	var claims []v1.PersistentVolumeClaim
+	for _, template := range spec.VolumeClaimTemplates {
+		claim, err := buildVolumeClaimTemplate(template)
+		if err != nil {
+			return nil, err
+		}
+		claims = append(claims, claim)
+		container.VolumeMounts = append(container.VolumeMounts, v1.VolumeMount{Name: template.Name, MountPath: template.MountPath})
+	}
+
+	return &appsv1.StatefulSet{
+		TypeMeta: metav1.TypeMeta{APIVersion: "apps/v1", Kind: "StatefulSet"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: appsv1.StatefulSetSpec{
+			Replicas:            &spec.Replicas,
+			ServiceName:         spec.ServiceName,
+			PodManagementPolicy: policy,
+			Selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"app": name},
+			},
+			Template: v1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{"app": name},
+				},
+				Spec: v1.PodSpec{
+					Containers: []v1.Container{container},
+				},
+			},
+			VolumeClaimTemplates: claims,
+		},
+	}, nil
+}
+
+// BuildHeadlessService monta o service sem ClusterIP que dá o DNS estável
+// (<pod>.<service>) aos pods de um StatefulSet.
+func BuildHeadlessService(namespace, name string, selector map[string]string, port int32) *v1.Service {
+	service := &v1.Service{
+		TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "Service"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: v1.ServiceSpec{
+			ClusterIP: v1.ClusterIPNone,
+			Selector:  selector,
+		},
+	}
+	if port > 0 {
+		service.Spec.Ports = []v1.ServicePort{{Port: port}}
+	}
+	return service
+}
+
+// CreateStatefulSet cria o StatefulSet e, se ainda não existir, o service headless
+// indicado em spec.ServiceName.
+func CreateStatefulSet(namespace, name string, spec StatefulSetSpec, opts MutateOptions) (*appsv1.StatefulSet, error) {
+	sts, err := BuildStatefulSet(namespace, name, spec)
+	if err != nil {
+		return nil, err
+	}
+
+	services := client.CoreV1().Services(namespace)
+	_, err = services.Get(context.TODO(), sts.Spec.ServiceName, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		service := BuildHeadlessService(namespace, sts.Spec.ServiceName, sts.Spec.Selector.MatchLabels, spec.ContainerPort)
+		_, err = services.Create(context.TODO(), service, opts.createOptions())
+	}
+	if err != nil {
+		return nil, fmt.Errorf("erro ao preparar service headless '%s': %w", sts.Spec.ServiceName, err)
+	}
+
+	return client.AppsV1().StatefulSets(namespace).Create(context.TODO(), sts, opts.createOptions())
+}
+
+// UpdateStatefulSet aplica imagem, réplicas e partition em uma única atualização.
+// Ao reduzir réplicas, o controller remove os pods do maior ordinal para o menor.
+func UpdateStatefulSet(namespace, name string, update StatefulSetUpdate, opts MutateOptions) (*appsv1.StatefulSet, error) {
+	return updateStatefulSet(namespace, name, opts, "erro ao atualizar statefulset", func(sts *appsv1.StatefulSet) error {
+		if update.Image != nil {
+			if err := setContainerImage(&sts.Spec.Template.Spec, update.Container, *update.Image); err != nil {
+				return err
+			}
+		}
+		if update.Replicas != nil {
+			if *update.Replicas < 0 {
+				return fmt.Errorf("%w: número de réplicas não pode ser negativo", ErrInvalidUpdate)
+			}
+			sts.Spec.Replicas = update.Replicas
+		}
+		if update.Partition != nil {
+			return setStatefulSetPartition(sts, *update.Partition)
+		}
+		return nil
+	})
+}
+
+// RestartStatefulSet recria os pods, um ordinal por vez do maior para o menor,
+// respeitando a partition.
+func RestartStatefulSet(namespace, name string, opts MutateOptions) (*appsv1.StatefulSet, error) {
+	return updateStatefulSet(namespace, name, opts, "erro ao reiniciar statefulset", func(sts *appsv1.StatefulSet) error {
+		if sts.Spec.Template.ObjectMeta.Annotations == nil {
+			sts.Spec.Template.ObjectMeta.Annotations = make(map[string]string)
+		}
+		sts.Spec.Template.ObjectMeta.Annotations[restartedAtAnnotation] = metav1.Now().Format(time.RFC3339)
+		return nil
+	})
+}
+
+// DeleteStatefulSet remove o StatefulSet. Os PVCs dos ordinais são mantidos, a não ser
+// que a política de retenção diga o contrário; RetainedClaims lista os que continuam.
+func DeleteStatefulSet(name string, namespace string, opts MutateOptions) (*appsv1.StatefulSet, []string, error) {
+	statefulSets := client.AppsV1().StatefulSets(namespace)
+	sts, err := statefulSets.Get(context.Background(), name, metav1.GetOptions{})
+	if err != nil {
+		return nil, nil, fmt.Errorf("statefulset não encontrado: %w", err)
+	}
+	if err := statefulSets.Delete(context.Background(), name, opts.deleteOptions(sts.UID)); err != nil {
+		return nil, nil, fmt.Errorf("erro ao deletar statefulset: %w", err)
+	}
+
+	policy := sts.Spec.PersistentVolumeClaimRetentionPolicy
+	if policy != nil && policy.WhenDeleted == appsv1.DeletePersistentVolumeClaimRetentionPolicyType {
+		return sts, nil, nil
+	}
+	// O StatefulSet já foi removido; uma falha ao listar os PVCs não desfaz a exclusão
+	claims, err := statefulSetClaims(sts)
+	if err != nil {
+		log.Printf("⚠️ Não foi possível listar os PVCs retidos do statefulset %s/%s: %v", namespace, name, err)
+		return sts, nil, nil
+	}
+	retained := make([]string, 0, len(claims))
+	for _, claim := range claims {
+		retained = append(retained, claim.Name)
+	}
+	return sts, retained, nil
+}
+
+func newStatefulSetInfo(sts appsv1.StatefulSet) StatefulSetInfo {
+	var replicas int32 = 1
+	if sts.Spec.Replicas != nil {
+		replicas = *sts.Spec.Replicas
+	}
+
+	info := StatefulSetInfo{
+		Nome:                sts.Name,
+		Namespace:           sts.Namespace,
+		Status:              statefulSetStatus(sts, replicas),
+		Replicas:            replicas,
+		ReadyReplicas:       sts.Status.ReadyReplicas,
+		CurrentReplicas:     sts.Status.CurrentReplicas,
+		UpdatedReplicas:     sts.Status.UpdatedReplicas,
+		AvailableReplicas:   sts.Status.AvailableReplicas,
+		CurrentRevision:     sts.Status.CurrentRevision,
+		UpdateRevision:      sts.Status.UpdateRevision,
+		ServiceName:         sts.Spec.ServiceName,
+		PodManagementPolicy: string(sts.Spec.PodManagementPolicy),
+		UpdateStrategy:      string(sts.Spec.UpdateStrategy.Type),
+		Partition:           statefulSetPartition(sts),
+		Generation:          sts.Generation,
+		ObservedGeneration:  sts.Status.ObservedGeneration,
+		Containers:          containerSpecInfos(sts.Spec.Template.Spec.Containers),
+		Labels:              sts.Labels,
+		CreationTimestamp:   sts.CreationTimestamp,
+		Age:                 age(sts.CreationTimestamp),
+	}
+	if sts.Spec.Selector != nil {
+		info.Selector = sts.Spec.Selector.MatchLabels
+	}
+	if len(info.Containers) > 0 {
+		info.Image = info.Containers[0].Image
+	}
+	return info
+}
+
+// statefulSetStatus resume o estado do StatefulSet em um único valor:
+//   - ScaledToZero: o StatefulSet foi escalado para 0 réplicas
+//   - Progressing: o controller ainda não observou a última geração, há pods sendo
+//     criados ou removidos ou pods fora da revisão mais recente
+//   - Partitioned: o rollout parou na partition; os ordinais abaixo dela seguem na revisão anterior
+//   - Degraded: todos os pods existem, mas nem todos estão prontos
+//   - Ready: todos os pods estão prontos e na revisão mais recente
+func statefulSetStatus(sts appsv1.StatefulSet, replicas int32) string {
+	st := sts.Status
+	if replicas == 0 {
+		if st.Replicas > 0 {
+			return "Progressing"
+		}
+		return "ScaledToZero"
+	}
+	if st.ObservedGeneration < sts.Generation || st.Replicas != replicas {
+		return "Progressing"
+	}
+	if st.UpdateRevision != "" && st.CurrentRevision != st.UpdateRevision {
+		partition := statefulSetPartition(sts)
+		if partition > 0 && st.UpdatedReplicas >= replicas-partition {
+			return "Partitioned"
+		}
+		return "Progressing"
+	}
+	if st.ReadyReplicas < replicas {
+		return "Degraded"
+	}
+	return "Ready"
+}
+
+func statefulSetPartition(sts appsv1.StatefulSet) int32 {
+	if ru := sts.Spec.UpdateStrategy.RollingUpdate; ru != nil && ru.Partition != nil {
+		return *ru.Partition
+	}
+	return 0
+}
+
+// setStatefulSetPartition define a partition do RollingUpdate. Com partition N, só os
+// ordinais >= N recebem a nova revisão; 0 conclui o rollout.
+func setStatefulSetPartition(sts *appsv1.StatefulSet, partition int32) error {
+	if partition < 0 {
+		return fmt.Errorf("%w: partition não pode ser negativa", ErrInvalidUpdate)
+	}
+	if sts.Spec.UpdateStrategy.Type == appsv1.OnDeleteStatefulSetStrategyType {
+		return fmt.Errorf("%w: partition só vale para a estratégia RollingUpdate (o statefulset usa OnDelete)", ErrInvalidUpdate)
+	}
+	sts.Spec.UpdateStrategy.Type = appsv1.RollingUpdateStatefulSetStrategyType
+	if sts.Spec.UpdateStrategy.RollingUpdate == nil {
+		sts.Spec.UpdateStrategy.RollingUpdate = &appsv1.RollingUpdateStatefulSetStrategy{}
+	}
+	sts.Spec.UpdateStrategy.RollingUpdate.Partition = &partition
+	return nil
+}
+
+// statefulSetOrdinals monta o estado de cada ordinal esperado, mais os pods que ainda
+// existem fora do intervalo (ex.: durante uma redução de réplicas).
+func statefulSetOrdinals(sts *appsv1.StatefulSet) ([]StatefulSetOrdinal, error) {
+	pods, err := statefulSetPods(sts)
+	if err != nil {
+		return nil, err
+	}
+	claims, err := statefulSetClaims(sts)
+	if err != nil {
+		return nil, err
+	}
+
+	var start, replicas int32 = 0, 1
+	if sts.Spec.Ordinals != nil {
+		start = sts.Spec.Ordinals.Start
+	}
+	if sts.Spec.Replicas != nil {
+		replicas = *sts.Spec.Replicas
+	}
+	ordinals := map[int32]bool{}
+	for ordinal := start; ordinal < start+replicas; ordinal++ {
+		ordinals[ordinal] = true
+	}
+	podsByOrdinal := map[int32]v1.Pod{}
+	for _, pod := range pods {
+		if ordinal, ok := podOrdinal(sts.Name, pod.Name); ok {
+			podsByOrdinal[ordinal] = pod
+			ordinals[ordinal] = true
+		}
+	}
+	claimsByName := map[string]v1.PersistentVolumeClaim{}
+	for _, claim := range claims {
+		claimsByName[claim.Name] = claim
+	}
+
+	sorted := make([]int32, 0, len(ordinals))
+	for ordinal := range ordinals {
+		sorted = append(sorted, ordinal)
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	result := make([]StatefulSetOrdinal, 0, len(sorted))
+	for _, ordinal := range sorted {
+		item := StatefulSetOrdinal{
+			Ordinal: ordinal,
+			PodName: fmt.Sprintf("%s-%d", sts.Name, ordinal),
+			Claims:  []PVCBinding{},
+		}
+		if pod, ok := podsByOrdinal[ordinal]; ok {
+			info := newPodInfo(pod)
+			item.Pod = &info
+			item.Ready = info.Ready
+			item.Updated = pod.Labels[appsv1.StatefulSetRevisionLabel] == sts.Status.UpdateRevision
+		}
+		for _, template := range sts.Spec.VolumeClaimTemplates {
+			claimName := fmt.Sprintf("%s-%s", template.Name, item.PodName)
+			binding := PVCBinding{Name: claimName, Template: template.Name, Phase: "Missing"}
+			if claim, ok := claimsByName[claimName]; ok {
+				binding = newPVCBinding(claim, template.Name)
+			}
+			item.Claims = append(item.Claims, binding)
+		}
+		result = append(result, item)
+	}
+	return result, nil
+}
+
+// statefulSetPods retorna os pods controlados pelo StatefulSet.
+func statefulSetPods(sts *appsv1.StatefulSet) ([]v1.Pod, error) {
+	selector, err := metav1.LabelSelectorAsSelector(sts.Spec.Selector)
+	if err != nil {
+		return nil, fmt.Errorf("seletor inválido: %w", err)
+	}
+	list, err := client.CoreV1().Pods(sts.Namespace).List(context.TODO(), metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return nil, fmt.Errorf("erro ao listar pods: %w", err)
+	}
+
+	var owned []v1.Pod
+	for _, pod := range list.Items {
+		if ref := metav1.GetControllerOfNoCopy(&pod); ref != nil && ref.UID == sts.UID {
+			owned = append(owned, pod)
+		}
+	}
+	return owned, nil
+}
+
+// statefulSetClaims retorna os PVCs criados a partir dos templates do StatefulSet,
+// reconhecidos pelo nome <template>-<statefulset>-<ordinal>.
+func statefulSetClaims(sts *appsv1.StatefulSet) ([]v1.PersistentVolumeClaim, error) {
+	if len(sts.Spec.VolumeClaimTemplates) == 0 {
+		return nil, nil
+	}
+	list, err := client.CoreV1().PersistentVolumeClaims(sts.Namespace).List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("erro ao listar pvcs: %w", err)
+	}
+
+	var claims []v1.PersistentVolumeClaim
+	for _, claim := range list.Items {
+		for _, template := range sts.Spec.VolumeClaimTemplates {
+			if podName, ok := strings.CutPrefix(claim.Name, template.Name+"-"); ok {
+				if _, isOrdinal := podOrdinal(sts.Name, podName); isOrdinal {
+					claims = append(claims, claim)
+					break
+				}
+			}
+		}
+	}
+	return claims, nil
+}
+
+// podOrdinal extrai o ordinal de um nome <statefulset>-<ordinal>.
+func podOrdinal(statefulSet, podName string) (int32, bool) {
+	suffix, ok := strings.CutPrefix(podName, statefulSet+"-")
+	if !ok {
+		return 0, false
+	}
+	ordinal, err := strconv.ParseInt(suffix, 10, 32)
+	if err != nil || ordinal < 0 {
+		return 0, false
+	}
+	return int32(ordinal), true
+}
+
+func newPVCBinding(claim v1.PersistentVolumeClaim, template string) PVCBinding {
+	binding := PVCBinding{
+		Name:     claim.Name,
+		Template: template,
+		Phase:    string(claim.Status.Phase),
+		Volume:   claim.Spec.VolumeName,
+	}
+	if capacity, ok := claim.Status.Capacity[v1.ResourceStorage]; ok {
+		binding.Capacity = capacity.String()
+	}
+	if claim.Spec.StorageClassName != nil {
+		binding.StorageClass = *claim.Spec.StorageClassName
+	}
+	return binding
+}
+
+func newVolumeClaimTemplateInfo(template v1.PersistentVolumeClaim) VolumeClaimTemplateInfo {
+	info := VolumeClaimTemplateInfo{Name: template.Name, AccessModes: []string{}}
+	if storage, ok := template.Spec.Resources.Requests[v1.ResourceStorage]; ok {
+		info.Storage = storage.String()
+	}
+	if template.Spec.StorageClassName != nil {
+		info.StorageClass = *template.Spec.StorageClassName
+	}
+	for _, mode := range template.Spec.AccessModes {
+		info.AccessModes = append(info.AccessModes, string(mode))
+	}
+	return info
+}
+
+// buildVolumeClaimTemplate valida e monta o template de PVC. Sem accessModes, usa ReadWriteOnce.
+func buildVolumeClaimTemplate(template VolumeClaimTemplate) (v1.PersistentVolumeClaim, error) {
+	if template.Name == "" || template.MountPath == "" {
+		return v1.PersistentVolumeClaim{}, fmt.Errorf("%w: 'name' e 'mountPath' são obrigatórios em volumeClaimTemplates", ErrInvalidUpdate)
+	}
+	spec, err := newClaimSpec(template.Storage, template.StorageClass, template.AccessModes, "")
+	if err != nil {
+		return v1.PersistentVolumeClaim{}, fmt.Errorf("template '%s': %w", template.Name, err)
+	}
+	return v1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: template.Name},
+		Spec:       spec,
+	}, nil
+}
+
+// updateStatefulSet lê o StatefulSet, aplica mutate e grava o resultado.
+func updateStatefulSet(namespace, name string, opts MutateOptions, errMsg string, mutate func(*appsv1.StatefulSet) error) (*appsv1.StatefulSet, error) {
+	sts, err := client.AppsV1().StatefulSets(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("statefulset não encontrado: %w", err)
+	}
+
+	if err := mutate(sts); err != nil {
+		return nil, err
+	}
+
+	updated, err := client.AppsV1().StatefulSets(namespace).Update(context.TODO(), sts, opts.updateOptions())
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", errMsg, err)
+	}
+	return updated, nil
+}
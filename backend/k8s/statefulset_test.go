@@ -0,0 +1,146 @@
+package k8s
+
+import (
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestStatefulSetStatus(t *testing.T) {
+	partition := func(p int32) appsv1.StatefulSetUpdateStrategy {
+		return appsv1.StatefulSetUpdateStrategy{
+			Type:          appsv1.RollingUpdateStatefulSetStrategyType,
+			RollingUpdate: &appsv1.RollingUpdateStatefulSetStrategy{Partition: &p},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		sts      appsv1.StatefulSet
+		replicas int32
+		want     string
+	}{
+		{
+			name:     "sem réplicas e sem pods",
+			replicas: 0,
+			want:     "ScaledToZero",
+		},
+		{
+			name:     "reduzindo para zero",
+			sts:      appsv1.StatefulSet{Status: appsv1.StatefulSetStatus{Replicas: 2}},
+			replicas: 0,
+			want:     "Progressing",
+		},
+		{
+			name: "geração ainda não observada",
+			sts: appsv1.StatefulSet{
+				ObjectMeta: metav1.ObjectMeta{Generation: 2},
+				Status:     appsv1.StatefulSetStatus{ObservedGeneration: 1, Replicas: 3, ReadyReplicas: 3},
+			},
+			replicas: 3,
+			want:     "Progressing",
+		},
+		{
+			name: "criando réplicas",
+			sts: appsv1.StatefulSet{
+				Status: appsv1.StatefulSetStatus{Replicas: 1, ReadyReplicas: 1},
+			},
+			replicas: 3,
+			want:     "Progressing",
+		},
+		{
+			name: "rollout em andamento",
+			sts: appsv1.StatefulSet{
+				Status: appsv1.StatefulSetStatus{
+					Replicas: 3, ReadyReplicas: 3, UpdatedReplicas: 1,
+					CurrentRevision: "web-1", UpdateRevision: "web-2",
+				},
+			},
+			replicas: 3,
+			want:     "Progressing",
+		},
+		{
+			name: "rollout parado na partição",
+			sts: appsv1.StatefulSet{
+				Spec: appsv1.StatefulSetSpec{UpdateStrategy: partition(2)},
+				Status: appsv1.StatefulSetStatus{
+					Replicas: 3, ReadyReplicas: 3, UpdatedReplicas: 1,
+					CurrentRevision: "web-1", UpdateRevision: "web-2",
+				},
+			},
+			replicas: 3,
+			want:     "Partitioned",
+		},
+		{
+			name: "partição ainda não alcançada",
+			sts: appsv1.StatefulSet{
+				Spec: appsv1.StatefulSetSpec{UpdateStrategy: partition(1)},
+				Status: appsv1.StatefulSetStatus{
+					Replicas: 3, ReadyReplicas: 3, UpdatedReplicas: 1,
+					CurrentRevision: "web-1", UpdateRevision: "web-2",
+				},
+			},
+			replicas: 3,
+			want:     "Progressing",
+		},
+		{
+			name: "pods não prontos",
+			sts: appsv1.StatefulSet{
+				Status: appsv1.StatefulSetStatus{
+					Replicas: 3, ReadyReplicas: 2,
+					CurrentRevision: "web-1", UpdateRevision: "web-1",
+				},
+			},
+			replicas: 3,
+			want:     "Degraded",
+		},
+		{
+			name: "pronto",
+			sts: appsv1.StatefulSet{
+				Status: appsv1.StatefulSetStatus{
+					Replicas: 3, ReadyReplicas: 3,
+					CurrentRevision: "web-1", UpdateRevision: "web-1",
+				},
+			},
+			replicas: 3,
+			want:     "Ready",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := statefulSetStatus(tt.sts, tt.replicas); got != tt.want {
+				t.Errorf("statefulSetStatus() = %q, esperado %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPodOrdinal(t *testing.T) {
+	tests := []struct {
+		name        string
+		statefulSet string
+		pod         string
+		want        int32
+		wantOK      bool
+	}{
+		{name: "primeiro ordinal", statefulSet: "web", pod: "web-0", want: 0, wantOK: true},
+		{name: "ordinal com dois dígitos", statefulSet: "web", pod: "web-12", want: 12, wantOK: true},
+		{name: "nome com hífen", statefulSet: "my-db", pod: "my-db-3", want: 3, wantOK: true},
+		{name: "outro statefulset", statefulSet: "web", pod: "api-0", wantOK: false},
+		{name: "prefixo de outro statefulset", statefulSet: "web", pod: "web-db-0", wantOK: false},
+		{name: "sem ordinal", statefulSet: "web", pod: "web-", wantOK: false},
+		{name: "ordinal negativo", statefulSet: "web", pod: "web--1", wantOK: false},
+		{name: "ordinal fora de int32", statefulSet: "web", pod: "web-4294967296", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := podOrdinal(tt.statefulSet, tt.pod)
+			if ok != tt.wantOK || got != tt.want {
+				t.Errorf("podOrdinal(%q, %q) = (%d, %v), esperado (%d, %v)", tt.statefulSet, tt.pod, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
@@ -0,0 +1,463 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+
+	v1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Anotação que marca a StorageClass usada pelos PVCs sem storageClassName.
+const defaultStorageClassAnnotation = "storageclass.kubernetes.io/is-default-class"
+
+// PVCInfo resume um PersistentVolumeClaim. Capacity é o tamanho provisionado e Requested
+// o pedido no spec; os dois diferem enquanto uma expansão está em andamento (Resizing).
+// Expandable indica que a StorageClass permite expansão; UsedBy lista os pods que montam o PVC.
+type PVCInfo struct {
+	Nome              string            `json:"nome"`
+	Namespace         string            `json:"namespace"`
+	Phase             string            `json:"phase"`
+	Capacity          string            `json:"capacity,omitempty"`
+	Requested         string            `json:"requested,omitempty"`
+	AccessModes       []string          `json:"accessModes"`
+	VolumeMode        string            `json:"volumeMode,omitempty"`
+	StorageClass      string            `json:"storageClass,omitempty"`
+	Volume            string            `json:"volume,omitempty"`
+	Expandable        bool              `json:"expandable"`
+	Resizing          string            `json:"resizing,omitempty"`
+	UsedBy            []string          `json:"usedBy"`
+	Labels            map[string]string `json:"labels,omitempty"`
+	CreationTimestamp metav1.Time       `json:"creationTimestamp"`
+	Age               string            `json:"age"`
+}
+
+// PVCDetail é o modelo completo de um PVC, com os pods que o montam e eventos recentes.
+type PVCDetail struct {
+	PVCInfo
+	Annotations map[string]string `json:"annotations,omitempty"`
+	Conditions  []PVCCondition    `json:"conditions,omitempty"`
+	Pods        []PodInfo         `json:"pods"`
+	Events      []EventInfo       `json:"events"`
+}
+
+type PVCCondition struct {
+	Type               string      `json:"type"`
+	Status             string      `json:"status"`
+	Message            string      `json:"message,omitempty"`
+	LastTransitionTime metav1.Time `json:"lastTransitionTime"`
+}
+
+// PVCSpec reúne os parâmetros de criação de um PVC. Sem storageClass, o cluster usa a
+// StorageClass padrão; sem accessModes, ReadWriteOnce.
+type PVCSpec struct {
+	Storage      string   `json:"storage"`
+	StorageClass string   `json:"storageClass,omitempty"`
+	AccessModes  []string `json:"accessModes,omitempty"`
+	VolumeMode   string   `json:"volumeMode,omitempty"`
+}
+
+// StorageClassInfo resume uma StorageClass. Default indica a classe usada pelos PVCs
+// que não informam storageClassName.
+type StorageClassInfo struct {
+	Nome                 string            `json:"nome"`
+	Provisioner          string            `json:"provisioner"`
+	Default              bool              `json:"default"`
+	ReclaimPolicy        string            `json:"reclaimPolicy,omitempty"`
+	VolumeBindingMode    string            `json:"volumeBindingMode,omitempty"`
+	AllowVolumeExpansion bool              `json:"allowVolumeExpansion"`
+	Parameters           map[string]string `json:"parameters,omitempty"`
+	CreationTimestamp    metav1.Time       `json:"creationTimestamp"`
+	Age                  string            `json:"age"`
+}
+
+// PersistentVolumeInfo resume um PersistentVolume. Claim é o PVC ligado a ele, como namespace/nome.
+type PersistentVolumeInfo struct {
+	Nome              string      `json:"nome"`
+	Phase             string      `json:"phase"`
+	Capacity          string      `json:"capacity,omitempty"`
+	AccessModes       []string    `json:"accessModes"`
+	VolumeMode        string      `json:"volumeMode,omitempty"`
+	ReclaimPolicy     string      `json:"reclaimPolicy"`
+	StorageClass      string      `json:"storageClass,omitempty"`
+	Claim             string      `json:"claim,omitempty"`
+	Driver            string      `json:"driver,omitempty"`
+	Reason            string      `json:"reason,omitempty"`
+	CreationTimestamp metav1.Time `json:"creationTimestamp"`
+	Age               string      `json:"age"`
+}
+
+func (p PVCInfo) GetNamespace() string { return p.Namespace }
+func (p PVCInfo) GetName() string      { return p.Nome }
+
+// ListPVCs retorna uma página de PVCInfo do namespace informado (ou de todos, com AllNamespaces).
+func ListPVCs(namespace string, opts ListOptions) (ListResult[PVCInfo], error) {
+	return listNamespacedPage(namespace, opts, listPVCs)
+}
+
+func listPVCs(namespace string, listOpts metav1.ListOptions) ([]PVCInfo, metav1.ListMeta, error) {
+	claims, err := client.CoreV1().PersistentVolumeClaims(namespace).List(context.TODO(), listOpts)
+	if err != nil {
+		return nil, metav1.ListMeta{}, fmt.Errorf("erro ao listar pvcs: %w", err)
+	}
+
+	// Os pods só servem para o UsedBy; sem permissão para listá-los, a lista segue sem ele
+	var users map[string][]v1.Pod
+	pods, err := client.CoreV1().Pods(namespace).List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		log.Printf("⚠️ Não foi possível listar os pods que usam os pvcs: %v", err)
+	} else {
+		users = claimUsers(pods.Items)
+	}
+	classes := expandableStorageClasses()
+
+	infos := make([]PVCInfo, 0, len(claims.Items))
+	for _, claim := range claims.Items {
+		info := newPVCInfo(claim, classes)
+		for _, pod := range users[claim.Namespace+"/"+claim.Name] {
+			info.UsedBy = append(info.UsedBy, pod.Name)
+		}
+		infos = append(infos, info)
+	}
+	return infos, claims.ListMeta, nil
+}
+
+// GetPVC retorna os detalhes de um PVC.
+func GetPVC(namespace, name string) (PVCDetail, error) {
+	claim, err := client.CoreV1().PersistentVolumeClaims(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+	if err != nil {
+		return PVCDetail{}, fmt.Errorf("pvc não encontrado: %w", err)
+	}
+
+	detail := PVCDetail{
+		PVCInfo:     newPVCInfo(*claim, expandableStorageClasses()),
+		Annotations: withoutLastApplied(claim.Annotations),
+		Pods:        []PodInfo{},
+	}
+	for _, cond := range claim.Status.Conditions {
+		detail.Conditions = append(detail.Conditions, PVCCondition{
+			Type:               string(cond.Type),
+			Status:             string(cond.Status),
+			Message:            cond.Message,
+			LastTransitionTime: cond.LastTransitionTime,
+		})
+	}
+
+	pods, err := claimPods(namespace, name)
+	if err != nil {
+		return detail, err
+	}
+	for _, pod := range pods {
+		detail.UsedBy = append(detail.UsedBy, pod.Name)
+		detail.Pods = append(detail.Pods, newPodInfo(pod))
+	}
+
+	detail.Events = detailEvents(namespace, v1.SchemeGroupVersion.WithKind("PersistentVolumeClaim"), claim.Name, claim.UID)
+	return detail, nil
+}
+
+// BuildPVC monta um PVC depois de validar o tamanho, os modos de acesso e o volumeMode.
+func BuildPVC(namespace, name string, spec PVCSpec) (*v1.PersistentVolumeClaim, error) {
+	claimSpec, err := newClaimSpec(spec.Storage, spec.StorageClass, spec.AccessModes, spec.VolumeMode)
+	if err != nil {
+		return nil, err
+	}
+	return &v1.PersistentVolumeClaim{
+		TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "PersistentVolumeClaim"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: claimSpec,
+	}, nil
+}
+
+// ExpandPVC aumenta o tamanho pedido pelo PVC. Só é aceito quando o PVC está Bound e a
+// StorageClass tem allowVolumeExpansion; o Kubernetes não permite reduzir um PVC.
+func ExpandPVC(namespace, name, storage string, opts MutateOptions) (*v1.PersistentVolumeClaim, error) {
+	size, err := resource.ParseQuantity(storage)
+	if err != nil {
+		return nil, fmt.Errorf("%w: 'storage' inválido (ex.: 10Gi)", ErrInvalidUpdate)
+	}
+
+	claims := client.CoreV1().PersistentVolumeClaims(namespace)
+	claim, err := claims.Get(context.TODO(), name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("pvc não encontrado: %w", err)
+	}
+	if claim.Status.Phase != v1.ClaimBound {
+		return nil, fmt.Errorf("%w: o pvc '%s' não está Bound (%s)", ErrInvalidUpdate, name, claim.Status.Phase)
+	}
+	current := claim.Spec.Resources.Requests[v1.ResourceStorage]
+	if size.Cmp(current) <= 0 {
+		return nil, fmt.Errorf("%w: o novo tamanho deve ser maior que %s", ErrInvalidUpdate, current.String())
+	}
+
+	className := claimStorageClass(*claim)
+	if className == "" {
+		return nil, fmt.Errorf("%w: o pvc '%s' não tem storage class e não pode ser expandido", ErrInvalidUpdate, name)
+	}
+	class, err := client.StorageV1().StorageClasses().Get(context.TODO(), className, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("storage class não encontrada: %w", err)
+	}
+	if !allowsExpansion(*class) {
+		return nil, fmt.Errorf("%w: a storage class '%s' não permite expansão de volumes", ErrInvalidUpdate, className)
+	}
+
+	claim.Spec.Resources.Requests[v1.ResourceStorage] = size
+	updated, err := claims.Update(context.TODO(), claim, opts.updateOptions())
+	if err != nil {
+		return nil, fmt.Errorf("erro ao expandir pvc: %w", err)
+	}
+	return updated, nil
+}
+
+// DeletePVC remove o PVC e devolve os pods em execução que ainda o montam. Nesse caso
+// o Kubernetes mantém o PVC em Terminating até que os pods parem (finalizer pvc-protection).
+func DeletePVC(name string, namespace string, opts MutateOptions) (*v1.PersistentVolumeClaim, []string, error) {
+	claims := client.CoreV1().PersistentVolumeClaims(namespace)
+	claim, err := claims.Get(context.Background(), name, metav1.GetOptions{})
+	if err != nil {
+		return nil, nil, fmt.Errorf("pvc não encontrado: %w", err)
+	}
+
+	pods, err := claimPods(namespace, name)
+	if err != nil {
+		return nil, nil, err
+	}
+	var running []string
+	for _, pod := range pods {
+		if pod.Status.Phase == v1.PodRunning {
+			running = append(running, pod.Name)
+		}
+	}
+
+	if err := claims.Delete(context.Background(), name, opts.deleteOptions(claim.UID)); err != nil {
+		return nil, nil, fmt.Errorf("erro ao deletar pvc: %w", err)
+	}
+	return claim, running, nil
+}
+
+// ListStorageClasses retorna uma página de StorageClassInfo.
+func ListStorageClasses(opts ListOptions) (ListResult[StorageClassInfo], error) {
+	return listPage(opts, listStorageClasses)
+}
+
+func listStorageClasses(listOpts metav1.ListOptions) ([]StorageClassInfo, metav1.ListMeta, error) {
+	classes, err := client.StorageV1().StorageClasses().List(context.TODO(), listOpts)
+	if err != nil {
+		return nil, metav1.ListMeta{}, fmt.Errorf("erro ao listar storage classes: %w", err)
+	}
+
+	infos := make([]StorageClassInfo, 0, len(classes.Items))
+	for _, class := range classes.Items {
+		info := StorageClassInfo{
+			Nome:                 class.Name,
+			Provisioner:          class.Provisioner,
+			Default:              class.Annotations[defaultStorageClassAnnotation] == "true",
+			AllowVolumeExpansion: allowsExpansion(class),
+			Parameters:           class.Parameters,
+			CreationTimestamp:    class.CreationTimestamp,
+			Age:                  age(class.CreationTimestamp),
+		}
+		if class.ReclaimPolicy != nil {
+			info.ReclaimPolicy = string(*class.ReclaimPolicy)
+		}
+		if class.VolumeBindingMode != nil {
+			info.VolumeBindingMode = string(*class.VolumeBindingMode)
+		}
+		infos = append(infos, info)
+	}
+	return infos, classes.ListMeta, nil
+}
+
+// ListPersistentVolumes retorna uma página de PersistentVolumeInfo.
+func ListPersistentVolumes(opts ListOptions) (ListResult[PersistentVolumeInfo], error) {
+	return listPage(opts, listPersistentVolumes)
+}
+
+func listPersistentVolumes(listOpts metav1.ListOptions) ([]PersistentVolumeInfo, metav1.ListMeta, error) {
+	volumes, err := client.CoreV1().PersistentVolumes().List(context.TODO(), listOpts)
+	if err != nil {
+		return nil, metav1.ListMeta{}, fmt.Errorf("erro ao listar persistent volumes: %w", err)
+	}
+
+	infos := make([]PersistentVolumeInfo, 0, len(volumes.Items))
+	for _, pv := range volumes.Items {
+		info := PersistentVolumeInfo{
+			Nome:              pv.Name,
+			Phase:             string(pv.Status.Phase),
+			AccessModes:       accessModes(pv.Spec.AccessModes),
+			ReclaimPolicy:     string(pv.Spec.PersistentVolumeReclaimPolicy),
+			StorageClass:      pv.Spec.StorageClassName,
+			Reason:            pv.Status.Reason,
+			CreationTimestamp: pv.CreationTimestamp,
+			Age:               age(pv.CreationTimestamp),
+		}
+		if capacity, ok := pv.Spec.Capacity[v1.ResourceStorage]; ok {
+			info.Capacity = capacity.String()
+		}
+		if pv.Spec.VolumeMode != nil {
+			info.VolumeMode = string(*pv.Spec.VolumeMode)
+		}
+		if ref := pv.Spec.ClaimRef; ref != nil {
+			info.Claim = ref.Namespace + "/" + ref.Name
+		}
+		if pv.Spec.CSI != nil {
+			info.Driver = pv.Spec.CSI.Driver
+		}
+		infos = append(infos, info)
+	}
+	return infos, volumes.ListMeta, nil
+}
+
+func newPVCInfo(claim v1.PersistentVolumeClaim, expandable map[string]bool) PVCInfo {
+	info := PVCInfo{
+		Nome:              claim.Name,
+		Namespace:         claim.Namespace,
+		Phase:             string(claim.Status.Phase),
+		AccessModes:       accessModes(claim.Status.AccessModes),
+		StorageClass:      claimStorageClass(claim),
+		Volume:            claim.Spec.VolumeName,
+		UsedBy:            []string{},
+		Labels:            claim.Labels,
+		CreationTimestamp: claim.CreationTimestamp,
+		Age:               age(claim.CreationTimestamp),
+	}
+	// Antes do bind o status ainda não tem os modos de acesso
+	if len(info.AccessModes) == 0 {
+		info.AccessModes = accessModes(claim.Spec.AccessModes)
+	}
+	info.Expandable = expandable[info.StorageClass]
+	if capacity, ok := claim.Status.Capacity[v1.ResourceStorage]; ok {
+		info.Capacity = capacity.String()
+	}
+	if requested, ok := claim.Spec.Resources.Requests[v1.ResourceStorage]; ok {
+		info.Requested = requested.String()
+	}
+	if claim.Spec.VolumeMode != nil {
+		info.VolumeMode = string(*claim.Spec.VolumeMode)
+	}
+	for _, cond := range claim.Status.Conditions {
+		if cond.Status == v1.ConditionTrue &&
+			(cond.Type == v1.PersistentVolumeClaimResizing || cond.Type == v1.PersistentVolumeClaimFileSystemResizePending) {
+			info.Resizing = string(cond.Type)
+		}
+	}
+	return info
+}
+
+// newClaimSpec valida e monta o spec de um PVC. Sem accessModes, usa ReadWriteOnce.
+func newClaimSpec(storage, storageClass string, modes []string, volumeMode string) (v1.PersistentVolumeClaimSpec, error) {
+	size, err := resource.ParseQuantity(storage)
+	if err != nil {
+		return v1.PersistentVolumeClaimSpec{}, fmt.Errorf("%w: 'storage' inválido (ex.: 1Gi)", ErrInvalidUpdate)
+	}
+
+	spec := v1.PersistentVolumeClaimSpec{
+		Resources: v1.VolumeResourceRequirements{
+			Requests: v1.ResourceList{v1.ResourceStorage: size},
+		},
+	}
+	if storageClass != "" {
+		spec.StorageClassName = &storageClass
+	}
+	if len(modes) == 0 {
+		modes = []string{string(v1.ReadWriteOnce)}
+	}
+	for _, mode := range modes {
+		switch m := v1.PersistentVolumeAccessMode(mode); m {
+		case v1.ReadWriteOnce, v1.ReadOnlyMany, v1.ReadWriteMany, v1.ReadWriteOncePod:
+			spec.AccessModes = append(spec.AccessModes, m)
+		default:
+			return v1.PersistentVolumeClaimSpec{}, fmt.Errorf("%w: modo de acesso '%s' inválido", ErrInvalidUpdate, mode)
+		}
+	}
+	switch m := v1.PersistentVolumeMode(volumeMode); m {
+	case "":
+	case v1.PersistentVolumeFilesystem, v1.PersistentVolumeBlock:
+		spec.VolumeMode = &m
+	default:
+		return v1.PersistentVolumeClaimSpec{}, fmt.Errorf("%w: volumeMode deve ser Filesystem ou Block", ErrInvalidUpdate)
+	}
+	return spec, nil
+}
+
+// claimStorageClass devolve a StorageClass do PVC, considerando a anotação usada
+// pelos PVCs antigos.
+func claimStorageClass(claim v1.PersistentVolumeClaim) string {
+	if claim.Spec.StorageClassName != nil {
+		return *claim.Spec.StorageClassName
+	}
+	return claim.Annotations[v1.BetaStorageClassAnnotation]
+}
+
+// expandableStorageClasses devolve as StorageClasses com allowVolumeExpansion. Sem permissão
+// para listar recursos do cluster, nenhum PVC aparece como expansível.
+func expandableStorageClasses() map[string]bool {
+	classes, err := client.StorageV1().StorageClasses().List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		log.Printf("⚠️ Não foi possível listar storage classes: %v", err)
+		return nil
+	}
+	result := make(map[string]bool)
+	for _, class := range classes.Items {
+		result[class.Name] = allowsExpansion(class)
+	}
+	return result
+}
+
+func allowsExpansion(class storagev1.StorageClass) bool {
+	return class.AllowVolumeExpansion != nil && *class.AllowVolumeExpansion
+}
+
+// claimUsers agrupa os pods por PVC montado (namespace/nome).
+func claimUsers(pods []v1.Pod) map[string][]v1.Pod {
+	users := make(map[string][]v1.Pod)
+	for _, pod := range pods {
+		for _, claim := range podClaims(pod) {
+			key := pod.Namespace + "/" + claim
+			users[key] = append(users[key], pod)
+		}
+	}
+	return users
+}
+
+// claimPods retorna os pods do namespace que montam o PVC, ordenados por nome.
+func claimPods(namespace, claim string) ([]v1.Pod, error) {
+	list, err := client.CoreV1().Pods(namespace).List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("erro ao listar pods: %w", err)
+	}
+	pods := claimUsers(list.Items)[namespace+"/"+claim]
+	sort.Slice(pods, func(i, j int) bool { return pods[i].Name < pods[j].Name })
+	return pods, nil
+}
+
+// podClaims lista os PVCs montados pelo pod, incluindo os volumes efêmeros,
+// cujo PVC é criado com o nome <pod>-<volume>.
+func podClaims(pod v1.Pod) []string {
+	var claims []string
+	for _, vol := range pod.Spec.Volumes {
+		switch {
+		case vol.PersistentVolumeClaim != nil:
+			claims = append(claims, vol.PersistentVolumeClaim.ClaimName)
+		case vol.Ephemeral != nil:
+			claims = append(claims, pod.Name+"-"+vol.Name)
+		}
+	}
+	return claims
+}
+
+func accessModes(modes []v1.PersistentVolumeAccessMode) []string {
+	result := make([]string, 0, len(modes))
+	for _, mode := range modes {
+		result = append(result, string(mode))
+	}
+	return result
+}
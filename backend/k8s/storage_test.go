@@ -0,0 +1,111 @@
+package k8s
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+func TestNewClaimSpec(t *testing.T) {
+	tests := []struct {
+		name             string
+		storage          string
+		storageClass     string
+		modes            []string
+		volumeMode       string
+		wantStorage      string
+		wantStorageClass string
+		wantModes        []v1.PersistentVolumeAccessMode
+		wantVolumeMode   v1.PersistentVolumeMode
+		wantErr          bool
+	}{
+		{
+			name:        "padrões",
+			storage:     "1Gi",
+			wantStorage: "1Gi",
+			wantModes:   []v1.PersistentVolumeAccessMode{v1.ReadWriteOnce},
+		},
+		{
+			name:             "todos os campos",
+			storage:          "500Mi",
+			storageClass:     "fast",
+			modes:            []string{"ReadWriteMany", "ReadOnlyMany"},
+			volumeMode:       "Block",
+			wantStorage:      "500Mi",
+			wantStorageClass: "fast",
+			wantModes:        []v1.PersistentVolumeAccessMode{v1.ReadWriteMany, v1.ReadOnlyMany},
+			wantVolumeMode:   v1.PersistentVolumeBlock,
+		},
+		{
+			name:           "ReadWriteOncePod",
+			storage:        "10Gi",
+			modes:          []string{"ReadWriteOncePod"},
+			volumeMode:     "Filesystem",
+			wantStorage:    "10Gi",
+			wantModes:      []v1.PersistentVolumeAccessMode{v1.ReadWriteOncePod},
+			wantVolumeMode: v1.PersistentVolumeFilesystem,
+		},
+		{
+			name:    "tamanho inválido",
+			storage: "muito",
+			wantErr: true,
+		},
+		{
+			name:    "tamanho vazio",
+			wantErr: true,
+		},
+		{
+			name:    "modo de acesso inválido",
+			storage: "1Gi",
+			modes:   []string{"ReadWriteSometimes"},
+			wantErr: true,
+		},
+		{
+			name:       "volumeMode inválido",
+			storage:    "1Gi",
+			volumeMode: "Raw",
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec, err := newClaimSpec(tt.storage, tt.storageClass, tt.modes, tt.volumeMode)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidUpdate) {
+					t.Fatalf("newClaimSpec() erro = %v, esperado ErrInvalidUpdate", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("newClaimSpec() erro inesperado: %v", err)
+			}
+
+			if got := spec.Resources.Requests[v1.ResourceStorage]; got.String() != tt.wantStorage {
+				t.Errorf("storage = %s, esperado %s", got.String(), tt.wantStorage)
+			}
+			var storageClass string
+			if spec.StorageClassName != nil {
+				storageClass = *spec.StorageClassName
+			}
+			if storageClass != tt.wantStorageClass {
+				t.Errorf("storageClass = %q, esperado %q", storageClass, tt.wantStorageClass)
+			}
+			if tt.wantStorageClass == "" && spec.StorageClassName != nil {
+				t.Errorf("storageClassName deveria ficar nulo para usar a classe padrão")
+			}
+			if !slices.Equal(spec.AccessModes, tt.wantModes) {
+				t.Errorf("accessModes = %v, esperado %v", spec.AccessModes, tt.wantModes)
+			}
+			var volumeMode v1.PersistentVolumeMode
+			if spec.VolumeMode != nil {
+				volumeMode = *spec.VolumeMode
+			}
+			if volumeMode != tt.wantVolumeMode {
+				t.Errorf("volumeMode = %q, esperado %q", volumeMode, tt.wantVolumeMode)
+			}
+		})
+	}
+}
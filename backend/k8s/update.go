@@ -3,66 +3,123 @@ package k8s
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
-func ScaleDeployment(namespace, name string, replicas int32) error {
-	if replicas < 0 {
-		return fmt.Errorf("número de réplicas não pode ser negativo")
-	}
+// ErrInvalidUpdate indica parâmetros inválidos na criação ou alteração de um workload.
+var ErrInvalidUpdate = errors.New("alteração inválida")
 
-	deployment, err := client.AppsV1().Deployments(namespace).Get(context.TODO(), name, metav1.GetOptions{})
-	if err != nil {
-		return fmt.Errorf("deployment não encontrado: %w", err)
+// restartedAtAnnotation é a anotação do template usada pelo kubectl rollout restart.
+const restartedAtAnnotation = "kubectl.kubernetes.io/restartedAt"
+
+// DeploymentUpdate lista as alterações de UpdateDeployment; campos nil não são alterados.
+type DeploymentUpdate struct {
+	Image    *string
+	Replicas *int32
+}
+
+// UpdateDeployment aplica a imagem e as réplicas em uma única atualização, para que
+// um dry-run mostre o resultado das duas alterações juntas. Alterar as réplicas de um
+// deployment controlado por um HPA ativo é recusado com conflito.
+func UpdateDeployment(namespace, name string, update DeploymentUpdate, opts MutateOptions) (*appsv1.Deployment, error) {
+	if update.Replicas != nil {
+		if err := checkManualScale(namespace, name); err != nil {
+			return nil, err
+		}
 	}
+	return updateDeployment(namespace, name, opts, "erro ao atualizar deployment", func(deployment *appsv1.Deployment) error {
+		if update.Image != nil {
+			if err := setDeploymentImage(deployment, *update.Image); err != nil {
+				return err
+			}
+		}
+		if update.Replicas != nil {
+			if *update.Replicas < 0 {
+				return fmt.Errorf("número de réplicas não pode ser negativo")
+			}
+			deployment.Spec.Replicas = update.Replicas
+		}
+		return nil
+	})
+}
 
-	deployment.Spec.Replicas = &replicas
-	_, err = client.AppsV1().Deployments(namespace).Update(context.TODO(), deployment, metav1.UpdateOptions{})
-	if err != nil {
-		return fmt.Errorf("erro ao escalar deployment: %w", err)
+func ScaleDeployment(namespace, name string, replicas int32, opts MutateOptions) (*appsv1.Deployment, error) {
+	if replicas < 0 {
+		return nil, fmt.Errorf("número de réplicas não pode ser negativo")
+	}
+	if err := checkManualScale(namespace, name); err != nil {
+		return nil, err
 	}
 
-	return nil
+	return updateDeployment(namespace, name, opts, "erro ao escalar deployment", func(deployment *appsv1.Deployment) error {
+		deployment.Spec.Replicas = &replicas
+		return nil
+	})
 }
 
-func UpdateDeploymentImage(namespace, name, image string) error {
-	deployment, err := client.AppsV1().Deployments(namespace).Get(context.TODO(), name, metav1.GetOptions{})
-	if err != nil {
-		return fmt.Errorf("deployment não encontrado: %w", err)
-	}
+func UpdateDeploymentImage(namespace, name, image string, opts MutateOptions) (*appsv1.Deployment, error) {
+	return updateDeployment(namespace, name, opts, "erro ao atualizar imagem", func(deployment *appsv1.Deployment) error {
+		return setDeploymentImage(deployment, image)
+	})
+}
+
+func RestartDeployment(namespace, name string, opts MutateOptions) (*appsv1.Deployment, error) {
+	return updateDeployment(namespace, name, opts, "erro ao reiniciar deployment", func(deployment *appsv1.Deployment) error {
+		// Adiciona anotação para forçar restart
+		if deployment.Spec.Template.ObjectMeta.Annotations == nil {
+			deployment.Spec.Template.ObjectMeta.Annotations = make(map[string]string)
+		}
+		deployment.Spec.Template.ObjectMeta.Annotations[restartedAtAnnotation] = metav1.Now().Format(time.RFC3339)
+		return nil
+	})
+}
 
+func setDeploymentImage(deployment *appsv1.Deployment, image string) error {
 	if len(deployment.Spec.Template.Spec.Containers) == 0 {
 		return fmt.Errorf("deployment não possui containers")
 	}
-
 	deployment.Spec.Template.Spec.Containers[0].Image = image
-	_, err = client.AppsV1().Deployments(namespace).Update(context.TODO(), deployment, metav1.UpdateOptions{})
-	if err != nil {
-		return fmt.Errorf("erro ao atualizar imagem: %w", err)
-	}
-
 	return nil
 }
 
-func RestartDeployment(namespace, name string) error {
+// setContainerImage troca a imagem do container indicado ou, sem nome, do primeiro container.
+func setContainerImage(spec *v1.PodSpec, container, image string) error {
+	if len(spec.Containers) == 0 {
+		return fmt.Errorf("%w: o template não possui containers", ErrInvalidUpdate)
+	}
+	if container == "" {
+		spec.Containers[0].Image = image
+		return nil
+	}
+	for i := range spec.Containers {
+		if spec.Containers[i].Name == container {
+			spec.Containers[i].Image = image
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: container '%s' não existe no template", ErrInvalidUpdate, container)
+}
+
+// updateDeployment lê o deployment, aplica mutate e grava o resultado.
+func updateDeployment(namespace, name string, opts MutateOptions, errMsg string, mutate func(*appsv1.Deployment) error) (*appsv1.Deployment, error) {
 	deployment, err := client.AppsV1().Deployments(namespace).Get(context.TODO(), name, metav1.GetOptions{})
 	if err != nil {
-		return fmt.Errorf("deployment não encontrado: %w", err)
+		return nil, fmt.Errorf("deployment não encontrado: %w", err)
 	}
 
-	// Adiciona anotação para forçar restart
-	if deployment.Spec.Template.ObjectMeta.Annotations == nil {
-		deployment.Spec.Template.ObjectMeta.Annotations = make(map[string]string)
+	if err := mutate(deployment); err != nil {
+		return nil, err
 	}
-	deployment.Spec.Template.ObjectMeta.Annotations["kubectl.kubernetes.io/restartedAt"] = metav1.Now().Format(time.RFC3339)
 
-	_, err = client.AppsV1().Deployments(namespace).Update(context.TODO(), deployment, metav1.UpdateOptions{})
+	updated, err := client.AppsV1().Deployments(namespace).Update(context.TODO(), deployment, opts.updateOptions())
 	if err != nil {
-		return fmt.Errorf("erro ao reiniciar deployment: %w", err)
+		return nil, fmt.Errorf("%s: %w", errMsg, err)
 	}
-
-	return nil
+	return updated, nil
 }
@@ -0,0 +1,70 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/tools/cache"
+	watchtools "k8s.io/client-go/tools/watch"
+)
+
+// WatchEvent é uma alteração enviada ao cliente durante um watch.
+// Type é ADDED, MODIFIED ou DELETED.
+type WatchEvent[T any] struct {
+	Type   string `json:"type"`
+	Object T      `json:"object"`
+}
+
+// watchList envia o estado atual como ADDED e, em seguida, cada alteração observada,
+// reconectando o watch quando o API server o encerra. Termina quando ctx é cancelado
+// ou quando send retorna erro (ex.: o cliente HTTP desconectou).
+func watchList[T any](
+	ctx context.Context,
+	list func(context.Context) ([]T, string, error),
+	watchFunc func(context.Context, metav1.ListOptions) (watch.Interface, error),
+	convert func(runtime.Object) (T, bool),
+	send func(WatchEvent[T]) error,
+) error {
+	items, resourceVersion, err := list(ctx)
+	if err != nil {
+		return err
+	}
+	for _, item := range items {
+		if err := send(WatchEvent[T]{Type: string(watch.Added), Object: item}); err != nil {
+			return err
+		}
+	}
+
+	watcher, err := watchtools.NewRetryWatcherWithContext(ctx, resourceVersion, &cache.ListWatch{WatchFuncWithContext: watchFunc})
+	if err != nil {
+		return fmt.Errorf("erro ao iniciar watch: %w", err)
+	}
+	defer watcher.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case ev, ok := <-watcher.ResultChan():
+			if !ok {
+				return nil
+			}
+			switch ev.Type {
+			case watch.Added, watch.Modified, watch.Deleted:
+				item, ok := convert(ev.Object)
+				if !ok {
+					continue
+				}
+				if err := send(WatchEvent[T]{Type: string(ev.Type), Object: item}); err != nil {
+					return err
+				}
+			case watch.Error:
+				return fmt.Errorf("erro no watch: %w", apierrors.FromObject(ev.Object))
+			}
+		}
+	}
+}
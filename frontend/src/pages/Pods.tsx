@@ -132,11 +132,14 @@ function Pods() {
   const getStatusColor = (status: string) => {
     switch (status.toLowerCase()) {
       case 'running':
+      case 'ready':
         return 'bg-green-100 text-green-800'
       case 'pending':
+      case 'progressing':
         return 'bg-yellow-100 text-yellow-800'
       case 'failed':
       case 'error':
+      case 'degraded':
         return 'bg-red-100 text-red-800'
       default:
         return 'bg-gray-100 text-gray-800'
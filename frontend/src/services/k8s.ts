@@ -12,23 +12,320 @@ const api = axios.create({
   },
 })
 
+// Envelope devolvido pelas listagens paginadas do backend
+export interface ListResult<T> {
+  items: T[]
+  total?: number
+  continue?: string
+}
+
+export interface TerminationInfo {
+  reason?: string
+  message?: string
+  exitCode: number
+  startedAt: string
+  finishedAt: string
+}
+
+export interface ContainerInfo {
+  name: string
+  image: string
+  ready: boolean
+  restartCount: number
+  state: 'Running' | 'Waiting' | 'Terminated' | 'Unknown'
+  reason?: string
+  message?: string
+  exitCode?: number
+  startedAt?: string
+  lastTermination?: TerminationInfo
+}
+
+export interface PodConditionInfo {
+  type: string
+  status: string
+  reason?: string
+  message?: string
+  lastTransitionTime: string
+}
+
+export interface OwnerInfo {
+  kind: string
+  name: string
+}
+
 export interface PodInfo {
   nome: string
   namespace: string
   status: string
+  reason?: string
+  ready: boolean
+  readyContainers: number
+  totalContainers: number
   ip: string
   node: string
   image: string
+  restarts: number
+  qosClass: string
+  owner?: OwnerInfo
+  labels?: Record<string, string>
+  containers: ContainerInfo[]
+  initContainers?: ContainerInfo[]
+  conditions?: PodConditionInfo[]
+  creationTimestamp: string
+  age: string
+}
+
+export interface ContainerPortInfo {
+  name?: string
+  containerPort: number
+  protocol: string
+}
+
+export interface ContainerSpecInfo {
+  name: string
+  image: string
+  ports?: ContainerPortInfo[]
+}
+
+export interface DeploymentConditionInfo {
+  type: string
+  status: string
+  reason?: string
+  message?: string
+  lastUpdateTime: string
+  lastTransitionTime: string
 }
 
 export interface DeploymentInfo {
   nome: string
   namespace: string
-  status: string
+  status: 'Ready' | 'Progressing' | 'Degraded' | 'Paused' | 'ScaledToZero'
   image: string
   replicas: number
+  readyReplicas: number
+  updatedReplicas: number
+  availableReplicas: number
+  unavailableReplicas: number
+  generation: number
+  observedGeneration: number
+  strategy: string
+  maxSurge?: string
+  maxUnavailable?: string
   containerPort: number
+  containers: ContainerSpecInfo[]
+  conditions?: DeploymentConditionInfo[]
+  selector: Record<string, string>
+  labels?: Record<string, string>
+  creationTimestamp: string
+  age: string
+}
+
+export interface StatefulSetInfo {
+  nome: string
+  namespace: string
+  status: 'Ready' | 'Progressing' | 'Partitioned' | 'Degraded' | 'ScaledToZero'
+  image: string
+  replicas: number
+  readyReplicas: number
+  currentReplicas: number
+  updatedReplicas: number
+  availableReplicas: number
+  currentRevision?: string
+  updateRevision?: string
+  serviceName: string
+  podManagementPolicy: string
+  updateStrategy: string
+  partition: number
+  generation: number
+  observedGeneration: number
+  containers: ContainerSpecInfo[]
+  selector: Record<string, string>
+  labels?: Record<string, string>
+  creationTimestamp: string
+  age: string
+}
+
+export interface PVCBinding {
+  name: string
+  template: string
+  // Missing quando o PVC ainda não foi criado
+  phase: string
+  volume?: string
+  capacity?: string
+  storageClass?: string
+}
+
+export interface StatefulSetOrdinal {
+  ordinal: number
+  podName: string
+  pod?: PodInfo
+  ready: boolean
+  updated: boolean
+  claims: PVCBinding[]
+}
+
+export interface VolumeClaimTemplate {
+  name: string
+  mountPath: string
+  storage: string
+  storageClass?: string
+  accessModes?: string[]
+}
+
+export interface DaemonSetInfo {
+  nome: string
+  namespace: string
+  status: 'Ready' | 'Progressing' | 'Degraded' | 'NoNodes'
+  image: string
+  desiredNumberScheduled: number
+  currentNumberScheduled: number
+  numberReady: number
+  updatedNumberScheduled: number
+  numberAvailable: number
+  numberUnavailable: number
+  numberMisscheduled: number
+  updateStrategy: string
+  maxUnavailable?: string
+  maxSurge?: string
+  nodeSelector?: Record<string, string>
+  generation: number
+  observedGeneration: number
+  containers: ContainerSpecInfo[]
   selector: Record<string, string>
+  labels?: Record<string, string>
+  creationTimestamp: string
+  age: string
+}
+
+export interface DaemonSetNode {
+  node: string
+  eligible: boolean
+  pod?: PodInfo
+  ready: boolean
+  updated: boolean
+  misscheduled: boolean
+}
+
+export interface JobInfo {
+  nome: string
+  namespace: string
+  status: 'Complete' | 'Failed' | 'Suspended' | 'Running' | 'Pending'
+  image: string
+  completions?: number
+  parallelism?: number
+  active: number
+  ready: number
+  succeeded: number
+  failed: number
+  startTime?: string
+  completionTime?: string
+  duration?: string
+  owner?: OwnerInfo
+  labels?: Record<string, string>
+  creationTimestamp: string
+  age: string
+}
+
+export interface CronJobInfo {
+  nome: string
+  namespace: string
+  schedule: string
+  timeZone?: string
+  suspend: boolean
+  concurrencyPolicy: 'Allow' | 'Forbid' | 'Replace'
+  image: string
+  active: number
+  lastScheduleTime?: string
+  lastSuccessfulTime?: string
+  // Vazio enquanto o cronjob estiver suspenso ou com a expressão inválida (scheduleError)
+  nextScheduleTime?: string
+  scheduleError?: string
+  labels?: Record<string, string>
+  creationTimestamp: string
+  age: string
+}
+
+export interface PVCInfo {
+  nome: string
+  namespace: string
+  phase: 'Pending' | 'Bound' | 'Lost'
+  capacity?: string
+  requested?: string
+  accessModes: string[]
+  volumeMode?: 'Filesystem' | 'Block'
+  storageClass?: string
+  volume?: string
+  expandable: boolean
+  // Etapa da expansão em andamento: Resizing ou FileSystemResizePending
+  resizing?: string
+  usedBy: string[]
+  labels?: Record<string, string>
+  creationTimestamp: string
+  age: string
+}
+
+export interface StorageClassInfo {
+  nome: string
+  provisioner: string
+  default: boolean
+  reclaimPolicy?: string
+  volumeBindingMode?: string
+  allowVolumeExpansion: boolean
+  parameters?: Record<string, string>
+  creationTimestamp: string
+  age: string
+}
+
+export interface PersistentVolumeInfo {
+  nome: string
+  phase: string
+  capacity?: string
+  accessModes: string[]
+  volumeMode?: string
+  reclaimPolicy: string
+  storageClass?: string
+  claim?: string
+  driver?: string
+  reason?: string
+  creationTimestamp: string
+  age: string
+}
+
+export interface HPAInfo {
+  nome: string
+  namespace: string
+  deployment: string
+  minReplicas: number
+  maxReplicas: number
+  currentReplicas: number
+  desiredReplicas: number
+  // Utilização em % dos requests; current fica vazio até o metrics-server informar
+  metrics: { resource: string; target: number; current?: number }[]
+  scalingActive: boolean
+  scalingLimited: boolean
+  lastScaleTime?: string
+  conditions?: { type: string; status: string; reason?: string; message?: string }[]
+  labels?: Record<string, string>
+  creationTimestamp: string
+  age: string
+}
+
+export interface ServicePortInfo {
+  name?: string
+  protocol: string
+  port: number
+  targetPort: number | string
+  nodePort?: number
+}
+
+export interface LoadBalancerAddress {
+  ip?: string
+  hostname?: string
+}
+
+export interface EndpointCounts {
+  ready: number
+  notReady: number
 }
 
 export interface ServiceInfo {
@@ -36,15 +333,419 @@ export interface ServiceInfo {
   namespace: string
   port: number
   targetPort: number
+  ports: ServicePortInfo[]
   type: string
   selector: Record<string, string>
   clusterIP: string
   externalIP: string
+  externalIPs?: string[]
+  externalName?: string
   loadBalancerIP: string
+  loadBalancer?: LoadBalancerAddress[]
+  endpoints?: EndpointCounts
+  labels?: Record<string, string>
+  creationTimestamp: string
+  age: string
+}
+
+export interface NamespaceCounts {
+  pods: number
+  deployments: number
+  services: number
+}
+
+export interface QuotaInfo {
+  nome: string
+  hard: Record<string, string>
+  used: Record<string, string>
+}
+
+export interface NamespaceInfo {
+  nome: string
+  status: 'Active' | 'Terminating'
+  labels?: Record<string, string>
+  annotations?: Record<string, string>
+  creationTimestamp: string
+  age: string
+  counts?: NamespaceCounts
+  quotas?: QuotaInfo[]
+}
+
+export interface ObjectRef {
+  kind: string
+  namespace?: string
+  name: string
+}
+
+export interface EventInfo {
+  nome: string
+  namespace: string
+  type: 'Normal' | 'Warning'
+  reason: string
+  note: string
+  action?: string
+  regarding: ObjectRef
+  reportingController?: string
+  count: number
+  firstTimestamp: string
+  lastTimestamp: string
+  age: string
+}
+
+export interface WatchEvent<T> {
+  type: 'ADDED' | 'MODIFIED' | 'DELETED'
+  object: T
+}
+
+export interface EventFilter {
+  type?: 'Normal' | 'Warning'
+  reason?: string
+}
+
+export interface LogLine {
+  pod: string
+  container: string
+  line: string
+}
+
+export interface LogOptions {
+  container?: string
+  follow?: boolean
+  tailLines?: number
+  sinceSeconds?: number
+  timestamps?: boolean
+  previous?: boolean
+}
+
+export interface ApplyResult {
+  apiVersion: string
+  kind: string
+  namespace?: string
+  name: string
+  result: 'created' | 'configured' | 'unchanged' | 'error'
+  error?: string
+  object?: any
+}
+
+export interface FieldChange {
+  path: string
+  type: 'added' | 'removed' | 'changed'
+  old?: any
+  new?: any
+}
+
+export interface DiffResult {
+  apiVersion: string
+  kind: string
+  namespace?: string
+  name: string
+  exists: boolean
+  changes: FieldChange[]
+  diff: string
+  error?: string
+}
+
+export interface ApplyOptions {
+  namespace?: string
+  force?: boolean
+  dryRun?: boolean
+}
+
+export interface ExecOptions {
+  container?: string
+  command?: string[]
+  tty?: boolean
+  token?: string
+}
+
+// Parâmetro das operações que alteram o cluster: com dryRun, só valida no API server
+const dryRunConfig = (dryRun: boolean) => (dryRun ? { params: { dryRun: 'true' } } : undefined)
+
+// Canais do protocolo de exec: cada mensagem binária começa com um destes bytes
+export const ExecChannel = {
+  stdin: 0,
+  stdout: 1,
+  stderr: 2,
+  status: 3,
+  resize: 4,
+} as const
+
+export interface PodDetail extends PodInfo {
+  hostIP?: string
+  podIPs?: string[]
+  serviceAccount?: string
+  restartPolicy: string
+  startTime?: string
+  annotations?: Record<string, string>
+  ownerChain: OwnerInfo[]
+  events: EventInfo[]
+}
+
+export interface ReplicaSetInfo {
+  nome: string
+  revision: number
+  replicas: number
+  readyReplicas: number
+  availableReplicas: number
+  images: string[]
+  creationTimestamp: string
+  age: string
+}
+
+export interface DeploymentDetail extends DeploymentInfo {
+  paused: boolean
+  revisionHistoryLimit?: number
+  annotations?: Record<string, string>
+  replicaSets: ReplicaSetInfo[]
+  // HPA que controla as réplicas; com ele ativo, /updateDeployment recusa 'replicas' (409)
+  autoscaler?: HPAInfo
+  pods: PodInfo[]
+  events: EventInfo[]
+}
+
+export interface EndpointInfo {
+  addresses: string[]
+  ready: boolean
+  serving: boolean
+  terminating: boolean
+  target?: ObjectRef
+  nodeName?: string
+  zone?: string
+  ports?: { name?: string; port: number; protocol: string }[]
+}
+
+export interface StatefulSetDetail extends StatefulSetInfo {
+  annotations?: Record<string, string>
+  ordinals: StatefulSetOrdinal[]
+  volumeClaimTemplates: { name: string; storage: string; storageClass?: string; accessModes: string[] }[]
+  pvcRetentionPolicy?: { whenDeleted: string; whenScaled: string }
+  events: EventInfo[]
+}
+
+export interface CreateStatefulSetRequest {
+  namespace: string
+  name: string
+  image: string
+  replicas: number
+  containerPort?: number
+  env?: Record<string, string>
+  serviceName?: string
+  podManagementPolicy?: 'OrderedReady' | 'Parallel'
+  volumeClaimTemplates?: VolumeClaimTemplate[]
+}
+
+export interface UpdateStatefulSetRequest {
+  namespace: string
+  name: string
+  image?: string
+  container?: string
+  replicas?: number
+  partition?: number
+}
+
+export interface DaemonSetDetail extends DaemonSetInfo {
+  annotations?: Record<string, string>
+  nodes: DaemonSetNode[]
+  nodesWithoutReadyPod: string[]
+  events: EventInfo[]
+}
+
+export interface CreateDaemonSetRequest {
+  namespace: string
+  name: string
+  image: string
+  containerPort?: number
+  env?: Record<string, string>
+  nodeSelector?: Record<string, string>
+  tolerateAllTaints?: boolean
+}
+
+export interface JobCondition {
+  type: string
+  status: string
+  reason?: string
+  message?: string
+  lastTransitionTime: string
+}
+
+export interface JobDetail extends JobInfo {
+  backoffLimit?: number
+  ttlSecondsAfterFinished?: number
+  activeDeadlineSeconds?: number
+  command?: string[]
+  args?: string[]
+  annotations?: Record<string, string>
+  conditions?: JobCondition[]
+  pods: PodInfo[]
+  events: EventInfo[]
+}
+
+export interface JobSpec {
+  image: string
+  command?: string[]
+  args?: string[]
+  env?: Record<string, string>
+  backoffLimit?: number
+  completions?: number
+  parallelism?: number
+  ttlSecondsAfterFinished?: number
+}
+
+export interface CreateJobRequest extends JobSpec {
+  namespace: string
+  name: string
+}
+
+export interface CronJobDetail extends CronJobInfo {
+  startingDeadlineSeconds?: number
+  successfulJobsHistoryLimit?: number
+  failedJobsHistoryLimit?: number
+  annotations?: Record<string, string>
+  jobs: JobInfo[]
+  events: EventInfo[]
+}
+
+export interface CreateCronJobRequest {
+  namespace: string
+  name: string
+  schedule: string
+  timeZone?: string
+  concurrencyPolicy?: 'Allow' | 'Forbid' | 'Replace'
+  suspend?: boolean
+  job: JobSpec
+}
+
+export interface PVCDetail extends PVCInfo {
+  annotations?: Record<string, string>
+  conditions?: { type: string; status: string; message?: string; lastTransitionTime: string }[]
+  pods: PodInfo[]
+  events: EventInfo[]
+}
+
+export interface CreatePVCRequest {
+  namespace: string
+  name: string
+  storage: string
+  storageClass?: string
+  accessModes?: string[]
+  volumeMode?: 'Filesystem' | 'Block'
+}
+
+export interface HPADetail extends HPAInfo {
+  annotations?: Record<string, string>
+  events: EventInfo[]
+}
+
+export interface HPARequest {
+  namespace: string
+  name: string
+  // Obrigatório na criação; ignorado na alteração
+  deployment?: string
+  minReplicas?: number
+  maxReplicas: number
+  cpuUtilization?: number
+  memoryUtilization?: number
+}
+
+export interface ServiceDetail extends ServiceInfo {
+  sessionAffinity?: string
+  annotations?: Record<string, string>
+  backends: EndpointInfo[]
+  events: EventInfo[]
+}
+
+export interface DataKeyInfo {
+  name: string
+  size: number
+  binary?: boolean
+}
+
+export interface ConfigMapInfo {
+  nome: string
+  namespace: string
+  keys: DataKeyInfo[]
+  immutable: boolean
+  // Deployments que usam o ConfigMap, como "namespace/nome"
+  usedBy: string[]
+  labels?: Record<string, string>
+  creationTimestamp: string
+  age: string
+}
+
+export interface ConfigMapDetail extends ConfigMapInfo {
+  annotations?: Record<string, string>
+  data: Record<string, string>
+  // Valores em base64
+  binaryData?: Record<string, string>
+  events: EventInfo[]
+}
+
+export interface UpdateConfigMapRequest {
+  namespace: string
+  name: string
+  set?: Record<string, string>
+  setBinary?: Record<string, string>
+  remove?: string[]
+}
+
+export interface SecretInfo {
+  nome: string
+  namespace: string
+  type: string
+  keys: DataKeyInfo[]
+  immutable: boolean
+  usedBy: string[]
+  labels?: Record<string, string>
+  creationTimestamp: string
+  age: string
+}
+
+export interface CertificateInfo {
+  subject: string
+  issuer: string
+  dnsNames?: string[]
+  ipAddresses?: string[]
+  notBefore: string
+  notAfter: string
+  expired: boolean
+}
+
+export interface SecretDetail extends SecretInfo {
+  annotations?: Record<string, string>
+  // Valores mascarados; use revealSecret para lê-los
+  data: Record<string, string>
+  certificate?: CertificateInfo
+  events: EventInfo[]
+}
+
+export interface SecretValues {
+  nome: string
+  namespace: string
+  data: Record<string, string>
+  // Valores que não são texto, em base64
+  binaryData?: Record<string, string>
+}
+
+export interface CreateSecretRequest {
+  namespace: string
+  name: string
+  mode: 'generic' | 'docker-registry' | 'tls' | 'basic-auth' | 'ssh-auth'
+  secretType?: string
+  data?: Record<string, string>
+  registry?: { server?: string; username: string; password: string; email?: string }
+  tls?: { cert: string; key: string; hosts?: string[] }
+}
+
+export interface UpdateSecretRequest {
+  namespace: string
+  name: string
+  set?: Record<string, string>
+  setBinary?: Record<string, string>
+  remove?: string[]
 }
 
 export interface CreateResourceRequest {
-  kind: 'container' | 'pod' | 'deployment' | 'secret' | 'ingress' | 'namespace' | 'service'
+  kind: 'container' | 'pod' | 'deployment' | 'secret' | 'configmap' | 'ingress' | 'namespace' | 'service'
   namespace?: string
   name: string
   image?: string
@@ -76,6 +777,9 @@ export interface CreateApplicationRequest {
 export interface CreateResourceResponse {
   status: string
   message: string
+  // Com dryRun, o objeto como o API server o persistiria; nada é alterado
+  dryRun?: boolean
+  object?: any
 }
 
 export interface UpdateDeploymentRequest {
@@ -88,9 +792,9 @@ export interface UpdateDeploymentRequest {
 export const k8sService = {
   async listPods(namespace: string): Promise<PodInfo[]> {
     try {
-      const response = await api.get<PodInfo[]>(`/listAllPods/${namespace}`)
-      // Garante que sempre retorna um array, mesmo se response.data for null/undefined
-      return Array.isArray(response.data) ? response.data : []
+      const response = await api.get<ListResult<PodInfo>>(`/listAllPods/${namespace}`)
+      // Garante que sempre retorna um array, mesmo se response.data.items for null/undefined
+      return Array.isArray(response.data?.items) ? response.data.items : []
     } catch (error) {
       console.error('Erro ao listar pods:', error)
       // Retorna array vazio em caso de erro ao invés de lançar exceção
@@ -99,10 +803,17 @@ export const k8sService = {
   },
 
   async listNamespaces(): Promise<string[]> {
+    const namespaces = await this.listNamespaceInfos()
+    return namespaces.map((ns) => ns.nome)
+  },
+
+  async listNamespaceInfos(counts = false): Promise<NamespaceInfo[]> {
     try {
-      const response = await api.get<string[]>('/listAllNs')
-      // Garante que sempre retorna um array, mesmo se response.data for null/undefined
-      return Array.isArray(response.data) ? response.data : []
+      const response = await api.get<ListResult<NamespaceInfo>>('/listAllNs', {
+        params: counts ? { counts: true } : undefined,
+      })
+      // Garante que sempre retorna um array, mesmo se response.data.items for null/undefined
+      return Array.isArray(response.data?.items) ? response.data.items : []
     } catch (error) {
       console.error('Erro ao listar namespaces:', error)
       // Retorna array vazio em caso de erro ao invés de lançar exceção
@@ -110,38 +821,193 @@ export const k8sService = {
     }
   },
 
-  async createResource(data: CreateResourceRequest): Promise<CreateResourceResponse> {
-    try {
-      const response = await api.post<CreateResourceResponse>('/createResource', data)
-      return response.data
-    } catch (error: any) {
-      console.error('Erro ao criar recurso:', error)
-      const errorMessage = error?.response?.data?.message || error?.message || 'Erro ao criar recurso'
-      throw new Error(errorMessage)
-    }
+  async getPod(namespace: string, name: string): Promise<PodDetail> {
+    const response = await api.get<PodDetail>(`/resource/${namespace}/pod/${name}`)
+    return response.data
   },
 
-  async deletePod(name: string, namespace: string): Promise<CreateResourceResponse> {
-    try {
-      const response = await api.post<CreateResourceResponse>('/deletePod', {
-        name,
-        namespace,
-      })
-      return response.data
-    } catch (error: any) {
-      console.error('Erro ao deletar pod:', error)
-      const errorMessage = error?.response?.data?.message || error?.message || 'Erro ao deletar pod'
-      throw new Error(errorMessage)
-    }
+  async getDeployment(namespace: string, name: string): Promise<DeploymentDetail> {
+    const response = await api.get<DeploymentDetail>(`/resource/${namespace}/deployment/${name}`)
+    return response.data
   },
 
-  async deleteDeployment(name: string, namespace: string): Promise<CreateResourceResponse> {
-    try {
-      const response = await api.post<CreateResourceResponse>('/deleteDeployment', {
-        name,
-        namespace,
-      })
-      return response.data
+  async getStatefulSet(namespace: string, name: string): Promise<StatefulSetDetail> {
+    const response = await api.get<StatefulSetDetail>(`/resource/${namespace}/statefulset/${name}`)
+    return response.data
+  },
+
+  async getDaemonSet(namespace: string, name: string): Promise<DaemonSetDetail> {
+    const response = await api.get<DaemonSetDetail>(`/resource/${namespace}/daemonset/${name}`)
+    return response.data
+  },
+
+  async getJob(namespace: string, name: string): Promise<JobDetail> {
+    const response = await api.get<JobDetail>(`/resource/${namespace}/job/${name}`)
+    return response.data
+  },
+
+  async getCronJob(namespace: string, name: string): Promise<CronJobDetail> {
+    const response = await api.get<CronJobDetail>(`/resource/${namespace}/cronjob/${name}`)
+    return response.data
+  },
+
+  async getPVC(namespace: string, name: string): Promise<PVCDetail> {
+    const response = await api.get<PVCDetail>(`/resource/${namespace}/pvc/${name}`)
+    return response.data
+  },
+
+  async getHPA(namespace: string, name: string): Promise<HPADetail> {
+    const response = await api.get<HPADetail>(`/resource/${namespace}/hpa/${name}`)
+    return response.data
+  },
+
+  async getService(namespace: string, name: string): Promise<ServiceDetail> {
+    const response = await api.get<ServiceDetail>(`/resource/${namespace}/service/${name}`)
+    return response.data
+  },
+
+  async getConfigMap(namespace: string, name: string): Promise<ConfigMapDetail> {
+    const response = await api.get<ConfigMapDetail>(`/resource/${namespace}/configmap/${name}`)
+    return response.data
+  },
+
+  async getSecret(namespace: string, name: string): Promise<SecretDetail> {
+    const response = await api.get<SecretDetail>(`/resource/${namespace}/secret/${name}`)
+    return response.data
+  },
+
+  // Requer a permissão 'secrets' no backend; cada chamada fica registrada na auditoria
+  async revealSecret(namespace: string, name: string, keys: string[] = [], token?: string): Promise<SecretValues> {
+    const response = await api.post<SecretValues>('/revealSecret', { namespace, name, keys }, {
+      headers: token ? { Authorization: `Bearer ${token}` } : undefined,
+    })
+    return response.data
+  },
+
+  // YAML reaplicável de um objeto de qualquer tipo
+  async exportResource(namespace: string, kind: string, name: string, secrets: 'redact' | 'omit' = 'redact'): Promise<string> {
+    const response = await api.get<string>(`/resource/${namespace}/${kind}/${name}`, {
+      params: { format: 'yaml', secrets },
+      responseType: 'text',
+    })
+    return response.data
+  },
+
+  // URL para baixar o YAML com os recursos de um namespace
+  exportNamespaceUrl(namespace: string, options: { secrets?: 'redact' | 'omit'; kinds?: string[] } = {}): string {
+    const params = new URLSearchParams({ download: 'true' })
+    if (options.secrets) params.set('secrets', options.secrets)
+    if (options.kinds?.length) params.set('kinds', options.kinds.join(','))
+    return `${API_BASE_URL}/export/${namespace}?${params}`
+  },
+
+  async listEvents(namespace: string, filter: EventFilter = {}): Promise<EventInfo[]> {
+    try {
+      const response = await api.get<ListResult<EventInfo>>(`/listAllEvents/${namespace}`, {
+        params: { ...filter, sort: '-lastTimestamp' },
+      })
+      return Array.isArray(response.data?.items) ? response.data.items : []
+    } catch (error) {
+      console.error('Erro ao listar eventos:', error)
+      return []
+    }
+  },
+
+  async getObjectEvents(namespace: string, kind: string, name: string, filter: EventFilter = {}): Promise<EventInfo[]> {
+    const response = await api.get<EventInfo[]>(`/events/${namespace}/${kind}/${name}`, { params: filter })
+    return Array.isArray(response.data) ? response.data : []
+  },
+
+  // Abre um stream SSE de eventos; chame close() no EventSource retornado para encerrar
+  watchEvents(
+    namespace: string,
+    onEvent: (event: WatchEvent<EventInfo>) => void,
+    filter: EventFilter & { kind?: string; name?: string } = {},
+  ): EventSource {
+    const params = new URLSearchParams(
+      Object.entries(filter).filter(([, value]) => value) as [string, string][],
+    )
+    const source = new EventSource(`${API_BASE_URL}/watchEvents/${namespace}?${params}`)
+    source.onmessage = (message) => onEvent(JSON.parse(message.data))
+    return source
+  },
+
+  // Abre um stream SSE de logs de um pod ou, com kind 'deployment' ou 'job', de todos os pods do recurso
+  streamLogs(
+    kind: 'pod' | 'deployment' | 'job',
+    namespace: string,
+    name: string,
+    onLine: (line: LogLine) => void,
+    options: LogOptions = {},
+  ): EventSource {
+    const params = new URLSearchParams({ format: 'sse' })
+    Object.entries(options).forEach(([key, value]) => {
+      if (value !== undefined && value !== '') params.set(key, String(value))
+    })
+    const path = { pod: 'logs', deployment: 'deploymentLogs', job: 'jobLogs' }[kind]
+    const source = new EventSource(`${API_BASE_URL}/${path}/${namespace}/${name}?${params}`)
+    source.onmessage = (message) => onLine(JSON.parse(message.data))
+    return source
+  },
+
+  // URL para baixar o .tar.gz com os logs atuais e anteriores dos pods de um deployment
+  deploymentLogsArchiveUrl(namespace: string, name: string, options: { sinceSeconds?: number; sinceTime?: string } = {}): string {
+    const params = new URLSearchParams()
+    if (options.sinceSeconds !== undefined) params.set('sinceSeconds', String(options.sinceSeconds))
+    if (options.sinceTime) params.set('sinceTime', options.sinceTime)
+    return `${API_BASE_URL}/deploymentLogs/${namespace}/${name}/archive?${params}`
+  },
+
+  // Abre um terminal no container; exige a permissão 'exec' habilitada no backend
+  openExec(namespace: string, pod: string, options: ExecOptions = {}): WebSocket {
+    const params = new URLSearchParams()
+    if (options.container) params.set('container', options.container)
+    options.command?.forEach((arg) => params.append('command', arg))
+    if (options.tty === false) params.set('tty', 'false')
+    if (options.token) params.set('token', options.token)
+    const socket = new WebSocket(`${API_BASE_URL.replace(/^http/, 'ws')}/exec/${namespace}/${pod}?${params}`)
+    socket.binaryType = 'arraybuffer'
+    return socket
+  },
+
+  // URL para abrir no navegador uma página servida por um pod ou service; exige a permissão 'proxy'
+  proxyUrl(namespace: string, kind: 'pod' | 'service', name: string, port: number | string, path = '', token?: string): string {
+    const params = token ? `?${new URLSearchParams({ token })}` : ''
+    return `${API_BASE_URL}/proxy/${namespace}/${kind}/${name}:${port}/${path.replace(/^\//, '')}${params}`
+  },
+
+  async createResource(data: CreateResourceRequest, dryRun = false): Promise<CreateResourceResponse> {
+    try {
+      const response = await api.post<CreateResourceResponse>('/createResource', data, dryRunConfig(dryRun))
+      return response.data
+    } catch (error: any) {
+      console.error('Erro ao criar recurso:', error)
+      const errorMessage = error?.response?.data?.message || error?.message || 'Erro ao criar recurso'
+      throw new Error(errorMessage)
+    }
+  },
+
+  async deletePod(name: string, namespace: string, dryRun = false): Promise<CreateResourceResponse> {
+    try {
+      const response = await api.post<CreateResourceResponse>('/deletePod', {
+        name,
+        namespace,
+      }, dryRunConfig(dryRun))
+      return response.data
+    } catch (error: any) {
+      console.error('Erro ao deletar pod:', error)
+      const errorMessage = error?.response?.data?.message || error?.message || 'Erro ao deletar pod'
+      throw new Error(errorMessage)
+    }
+  },
+
+  async deleteDeployment(name: string, namespace: string, dryRun = false): Promise<CreateResourceResponse> {
+    try {
+      const response = await api.post<CreateResourceResponse>('/deleteDeployment', {
+        name,
+        namespace,
+      }, dryRunConfig(dryRun))
+      return response.data
     } catch (error: any) {
       console.error('Erro ao deletar deployment:', error)
       const errorMessage = error?.response?.data?.message || error?.message || 'Erro ao deletar deployment'
@@ -149,12 +1015,12 @@ export const k8sService = {
     }
   },
 
-  async deleteService(name: string, namespace: string): Promise<CreateResourceResponse> {
+  async deleteService(name: string, namespace: string, dryRun = false): Promise<CreateResourceResponse> {
     try {
       const response = await api.post<CreateResourceResponse>('/deleteService', {
         name,
         namespace,
-      })
+      }, dryRunConfig(dryRun))
       return response.data
     } catch (error: any) {
       console.error('Erro ao deletar service:', error)
@@ -165,27 +1031,420 @@ export const k8sService = {
 
   async listDeployments(namespace: string): Promise<DeploymentInfo[]> {
     try {
-      const response = await api.get<DeploymentInfo[]>(`/listAllDeployments/${namespace}`)
-      return Array.isArray(response.data) ? response.data : []
+      const response = await api.get<ListResult<DeploymentInfo>>(`/listAllDeployments/${namespace}`)
+      return Array.isArray(response.data?.items) ? response.data.items : []
     } catch (error) {
       console.error('Erro ao listar deployments:', error)
       return []
     }
   },
 
+  async listStatefulSets(namespace: string): Promise<StatefulSetInfo[]> {
+    try {
+      const response = await api.get<ListResult<StatefulSetInfo>>(`/listAllStatefulSets/${namespace}`)
+      return Array.isArray(response.data?.items) ? response.data.items : []
+    } catch (error) {
+      console.error('Erro ao listar statefulsets:', error)
+      return []
+    }
+  },
+
+  async createStatefulSet(data: CreateStatefulSetRequest, dryRun = false): Promise<CreateResourceResponse> {
+    try {
+      const response = await api.post<CreateResourceResponse>('/createStatefulSet', data, dryRunConfig(dryRun))
+      return response.data
+    } catch (error: any) {
+      console.error('Erro ao criar statefulset:', error)
+      const errorMessage = error?.response?.data?.message || error?.message || 'Erro ao criar statefulset'
+      throw new Error(errorMessage)
+    }
+  },
+
+  async updateStatefulSet(data: UpdateStatefulSetRequest, dryRun = false): Promise<CreateResourceResponse> {
+    try {
+      const response = await api.post<CreateResourceResponse>('/updateStatefulSet', data, dryRunConfig(dryRun))
+      return response.data
+    } catch (error: any) {
+      console.error('Erro ao atualizar statefulset:', error)
+      const errorMessage = error?.response?.data?.message || error?.message || 'Erro ao atualizar statefulset'
+      throw new Error(errorMessage)
+    }
+  },
+
+  async restartStatefulSet(name: string, namespace: string, dryRun = false): Promise<CreateResourceResponse> {
+    try {
+      const response = await api.post<CreateResourceResponse>('/restartStatefulSet', { name, namespace }, dryRunConfig(dryRun))
+      return response.data
+    } catch (error: any) {
+      console.error('Erro ao reiniciar statefulset:', error)
+      const errorMessage = error?.response?.data?.message || error?.message || 'Erro ao reiniciar statefulset'
+      throw new Error(errorMessage)
+    }
+  },
+
+  async deleteStatefulSet(name: string, namespace: string, dryRun = false): Promise<CreateResourceResponse> {
+    try {
+      const response = await api.post<CreateResourceResponse>('/deleteStatefulSet', { name, namespace }, dryRunConfig(dryRun))
+      return response.data
+    } catch (error: any) {
+      console.error('Erro ao deletar statefulset:', error)
+      const errorMessage = error?.response?.data?.message || error?.message || 'Erro ao deletar statefulset'
+      throw new Error(errorMessage)
+    }
+  },
+
+  async listDaemonSets(namespace: string): Promise<DaemonSetInfo[]> {
+    try {
+      const response = await api.get<ListResult<DaemonSetInfo>>(`/listAllDaemonSets/${namespace}`)
+      return Array.isArray(response.data?.items) ? response.data.items : []
+    } catch (error) {
+      console.error('Erro ao listar daemonsets:', error)
+      return []
+    }
+  },
+
+  async createDaemonSet(data: CreateDaemonSetRequest, dryRun = false): Promise<CreateResourceResponse> {
+    try {
+      const response = await api.post<CreateResourceResponse>('/createDaemonSet', data, dryRunConfig(dryRun))
+      return response.data
+    } catch (error: any) {
+      console.error('Erro ao criar daemonset:', error)
+      const errorMessage = error?.response?.data?.message || error?.message || 'Erro ao criar daemonset'
+      throw new Error(errorMessage)
+    }
+  },
+
+  async updateDaemonSetImage(namespace: string, name: string, image: string, container?: string, dryRun = false): Promise<CreateResourceResponse> {
+    try {
+      const response = await api.post<CreateResourceResponse>('/updateDaemonSet', { namespace, name, image, container }, dryRunConfig(dryRun))
+      return response.data
+    } catch (error: any) {
+      console.error('Erro ao atualizar daemonset:', error)
+      const errorMessage = error?.response?.data?.message || error?.message || 'Erro ao atualizar daemonset'
+      throw new Error(errorMessage)
+    }
+  },
+
+  async restartDaemonSet(name: string, namespace: string, dryRun = false): Promise<CreateResourceResponse> {
+    try {
+      const response = await api.post<CreateResourceResponse>('/restartDaemonSet', { name, namespace }, dryRunConfig(dryRun))
+      return response.data
+    } catch (error: any) {
+      console.error('Erro ao reiniciar daemonset:', error)
+      const errorMessage = error?.response?.data?.message || error?.message || 'Erro ao reiniciar daemonset'
+      throw new Error(errorMessage)
+    }
+  },
+
+  async deleteDaemonSet(name: string, namespace: string, dryRun = false): Promise<CreateResourceResponse> {
+    try {
+      const response = await api.post<CreateResourceResponse>('/deleteDaemonSet', { name, namespace }, dryRunConfig(dryRun))
+      return response.data
+    } catch (error: any) {
+      console.error('Erro ao deletar daemonset:', error)
+      const errorMessage = error?.response?.data?.message || error?.message || 'Erro ao deletar daemonset'
+      throw new Error(errorMessage)
+    }
+  },
+
+  async listJobs(namespace: string): Promise<JobInfo[]> {
+    try {
+      const response = await api.get<ListResult<JobInfo>>(`/listAllJobs/${namespace}`)
+      return Array.isArray(response.data?.items) ? response.data.items : []
+    } catch (error) {
+      console.error('Erro ao listar jobs:', error)
+      return []
+    }
+  },
+
+  async createJob(data: CreateJobRequest, dryRun = false): Promise<CreateResourceResponse> {
+    try {
+      const response = await api.post<CreateResourceResponse>('/createJob', data, dryRunConfig(dryRun))
+      return response.data
+    } catch (error: any) {
+      console.error('Erro ao criar job:', error)
+      const errorMessage = error?.response?.data?.message || error?.message || 'Erro ao criar job'
+      throw new Error(errorMessage)
+    }
+  },
+
+  async deleteJob(name: string, namespace: string, dryRun = false): Promise<CreateResourceResponse> {
+    try {
+      const response = await api.post<CreateResourceResponse>('/deleteJob', { name, namespace }, dryRunConfig(dryRun))
+      return response.data
+    } catch (error: any) {
+      console.error('Erro ao deletar job:', error)
+      const errorMessage = error?.response?.data?.message || error?.message || 'Erro ao deletar job'
+      throw new Error(errorMessage)
+    }
+  },
+
+  async listCronJobs(namespace: string): Promise<CronJobInfo[]> {
+    try {
+      const response = await api.get<ListResult<CronJobInfo>>(`/listAllCronJobs/${namespace}`)
+      return Array.isArray(response.data?.items) ? response.data.items : []
+    } catch (error) {
+      console.error('Erro ao listar cronjobs:', error)
+      return []
+    }
+  },
+
+  async createCronJob(data: CreateCronJobRequest, dryRun = false): Promise<CreateResourceResponse> {
+    try {
+      const response = await api.post<CreateResourceResponse>('/createCronJob', data, dryRunConfig(dryRun))
+      return response.data
+    } catch (error: any) {
+      console.error('Erro ao criar cronjob:', error)
+      const errorMessage = error?.response?.data?.message || error?.message || 'Erro ao criar cronjob'
+      throw new Error(errorMessage)
+    }
+  },
+
+  async setCronJobSuspend(namespace: string, name: string, suspend: boolean, dryRun = false): Promise<CreateResourceResponse> {
+    try {
+      const response = await api.post<CreateResourceResponse>('/suspendCronJob', { namespace, name, suspend }, dryRunConfig(dryRun))
+      return response.data
+    } catch (error: any) {
+      console.error('Erro ao atualizar cronjob:', error)
+      const errorMessage = error?.response?.data?.message || error?.message || 'Erro ao atualizar cronjob'
+      throw new Error(errorMessage)
+    }
+  },
+
+  async triggerCronJob(name: string, namespace: string, dryRun = false): Promise<CreateResourceResponse> {
+    try {
+      const response = await api.post<CreateResourceResponse>('/triggerCronJob', { name, namespace }, dryRunConfig(dryRun))
+      return response.data
+    } catch (error: any) {
+      console.error('Erro ao disparar cronjob:', error)
+      const errorMessage = error?.response?.data?.message || error?.message || 'Erro ao disparar cronjob'
+      throw new Error(errorMessage)
+    }
+  },
+
+  async deleteCronJob(name: string, namespace: string, dryRun = false): Promise<CreateResourceResponse> {
+    try {
+      const response = await api.post<CreateResourceResponse>('/deleteCronJob', { name, namespace }, dryRunConfig(dryRun))
+      return response.data
+    } catch (error: any) {
+      console.error('Erro ao deletar cronjob:', error)
+      const errorMessage = error?.response?.data?.message || error?.message || 'Erro ao deletar cronjob'
+      throw new Error(errorMessage)
+    }
+  },
+
+  async listPVCs(namespace: string): Promise<PVCInfo[]> {
+    try {
+      const response = await api.get<ListResult<PVCInfo>>(`/listAllPVCs/${namespace}`)
+      return Array.isArray(response.data?.items) ? response.data.items : []
+    } catch (error) {
+      console.error('Erro ao listar pvcs:', error)
+      return []
+    }
+  },
+
+  async listStorageClasses(): Promise<StorageClassInfo[]> {
+    try {
+      const response = await api.get<ListResult<StorageClassInfo>>('/listAllStorageClasses')
+      return Array.isArray(response.data?.items) ? response.data.items : []
+    } catch (error) {
+      console.error('Erro ao listar storage classes:', error)
+      return []
+    }
+  },
+
+  async listPersistentVolumes(): Promise<PersistentVolumeInfo[]> {
+    try {
+      const response = await api.get<ListResult<PersistentVolumeInfo>>('/listAllPVs')
+      return Array.isArray(response.data?.items) ? response.data.items : []
+    } catch (error) {
+      console.error('Erro ao listar persistent volumes:', error)
+      return []
+    }
+  },
+
+  async createPVC(data: CreatePVCRequest, dryRun = false): Promise<CreateResourceResponse> {
+    try {
+      const response = await api.post<CreateResourceResponse>('/createPVC', data, dryRunConfig(dryRun))
+      return response.data
+    } catch (error: any) {
+      console.error('Erro ao criar pvc:', error)
+      const errorMessage = error?.response?.data?.message || error?.message || 'Erro ao criar pvc'
+      throw new Error(errorMessage)
+    }
+  },
+
+  async expandPVC(namespace: string, name: string, storage: string, dryRun = false): Promise<CreateResourceResponse> {
+    try {
+      const response = await api.post<CreateResourceResponse>('/expandPVC', { namespace, name, storage }, dryRunConfig(dryRun))
+      return response.data
+    } catch (error: any) {
+      console.error('Erro ao expandir pvc:', error)
+      const errorMessage = error?.response?.data?.message || error?.message || 'Erro ao expandir pvc'
+      throw new Error(errorMessage)
+    }
+  },
+
+  async deletePVC(name: string, namespace: string, dryRun = false): Promise<CreateResourceResponse> {
+    try {
+      const response = await api.post<CreateResourceResponse>('/deletePVC', { name, namespace }, dryRunConfig(dryRun))
+      return response.data
+    } catch (error: any) {
+      console.error('Erro ao deletar pvc:', error)
+      const errorMessage = error?.response?.data?.message || error?.message || 'Erro ao deletar pvc'
+      throw new Error(errorMessage)
+    }
+  },
+
+  async listHPAs(namespace: string): Promise<HPAInfo[]> {
+    try {
+      const response = await api.get<ListResult<HPAInfo>>(`/listAllHPAs/${namespace}`)
+      return Array.isArray(response.data?.items) ? response.data.items : []
+    } catch (error) {
+      console.error('Erro ao listar hpas:', error)
+      return []
+    }
+  },
+
+  async createHPA(data: HPARequest, dryRun = false): Promise<CreateResourceResponse> {
+    try {
+      const response = await api.post<CreateResourceResponse>('/createHPA', data, dryRunConfig(dryRun))
+      return response.data
+    } catch (error: any) {
+      console.error('Erro ao criar hpa:', error)
+      const errorMessage = error?.response?.data?.message || error?.message || 'Erro ao criar hpa'
+      throw new Error(errorMessage)
+    }
+  },
+
+  async updateHPA(data: HPARequest, dryRun = false): Promise<CreateResourceResponse> {
+    try {
+      const response = await api.post<CreateResourceResponse>('/updateHPA', data, dryRunConfig(dryRun))
+      return response.data
+    } catch (error: any) {
+      console.error('Erro ao atualizar hpa:', error)
+      const errorMessage = error?.response?.data?.message || error?.message || 'Erro ao atualizar hpa'
+      throw new Error(errorMessage)
+    }
+  },
+
+  async deleteHPA(name: string, namespace: string, dryRun = false): Promise<CreateResourceResponse> {
+    try {
+      const response = await api.post<CreateResourceResponse>('/deleteHPA', { name, namespace }, dryRunConfig(dryRun))
+      return response.data
+    } catch (error: any) {
+      console.error('Erro ao deletar hpa:', error)
+      const errorMessage = error?.response?.data?.message || error?.message || 'Erro ao deletar hpa'
+      throw new Error(errorMessage)
+    }
+  },
+
   async listServices(namespace: string): Promise<ServiceInfo[]> {
     try {
-      const response = await api.get<ServiceInfo[]>(`/listAllServices/${namespace}`)
-      return Array.isArray(response.data) ? response.data : []
+      const response = await api.get<ListResult<ServiceInfo>>(`/listAllServices/${namespace}`)
+      return Array.isArray(response.data?.items) ? response.data.items : []
     } catch (error) {
       console.error('Erro ao listar services:', error)
       return []
     }
   },
 
-  async createApplication(data: CreateApplicationRequest): Promise<CreateResourceResponse> {
+  async listConfigMaps(namespace: string): Promise<ConfigMapInfo[]> {
+    try {
+      const response = await api.get<ListResult<ConfigMapInfo>>(`/listAllConfigMaps/${namespace}`)
+      return Array.isArray(response.data?.items) ? response.data.items : []
+    } catch (error) {
+      console.error('Erro ao listar configmaps:', error)
+      return []
+    }
+  },
+
+  // Cria um ConfigMap com pares chave/valor e arquivos (cada arquivo vira uma chave com o nome do arquivo)
+  async createConfigMap(
+    namespace: string,
+    name: string,
+    data: Record<string, string> = {},
+    files: File[] = [],
+    dryRun = false,
+  ): Promise<CreateResourceResponse> {
+    try {
+      const form = new FormData()
+      form.append('namespace', namespace)
+      form.append('name', name)
+      Object.entries(data).forEach(([key, value]) => form.append('literal', `${key}=${value}`))
+      files.forEach((file) => form.append('file', file, file.name))
+      const response = await api.post<CreateResourceResponse>('/createConfigMap', form, {
+        ...dryRunConfig(dryRun),
+        headers: { 'Content-Type': 'multipart/form-data' },
+      })
+      return response.data
+    } catch (error: any) {
+      console.error('Erro ao criar configmap:', error)
+      const errorMessage = error?.response?.data?.message || error?.message || 'Erro ao criar configmap'
+      throw new Error(errorMessage)
+    }
+  },
+
+  async updateConfigMap(data: UpdateConfigMapRequest, dryRun = false): Promise<CreateResourceResponse> {
+    try {
+      const response = await api.post<CreateResourceResponse>('/updateConfigMap', data, dryRunConfig(dryRun))
+      return response.data
+    } catch (error: any) {
+      console.error('Erro ao atualizar configmap:', error)
+      const errorMessage = error?.response?.data?.message || error?.message || 'Erro ao atualizar configmap'
+      throw new Error(errorMessage)
+    }
+  },
+
+  async deleteConfigMap(name: string, namespace: string, dryRun = false): Promise<CreateResourceResponse> {
+    try {
+      const response = await api.post<CreateResourceResponse>('/deleteConfigMap', {
+        name,
+        namespace,
+      }, dryRunConfig(dryRun))
+      return response.data
+    } catch (error: any) {
+      console.error('Erro ao deletar configmap:', error)
+      const errorMessage = error?.response?.data?.message || error?.message || 'Erro ao deletar configmap'
+      throw new Error(errorMessage)
+    }
+  },
+
+  async listSecrets(namespace: string): Promise<SecretInfo[]> {
+    try {
+      const response = await api.get<ListResult<SecretInfo>>(`/listAllSecrets/${namespace}`)
+      return Array.isArray(response.data?.items) ? response.data.items : []
+    } catch (error) {
+      console.error('Erro ao listar secrets:', error)
+      return []
+    }
+  },
+
+  async createSecret(data: CreateSecretRequest, dryRun = false): Promise<CreateResourceResponse> {
     try {
-      const response = await api.post<CreateResourceResponse>('/createApplication', data)
+      const response = await api.post<CreateResourceResponse>('/createSecret', data, dryRunConfig(dryRun))
+      return response.data
+    } catch (error: any) {
+      console.error('Erro ao criar secret:', error)
+      const errorMessage = error?.response?.data?.message || error?.message || 'Erro ao criar secret'
+      throw new Error(errorMessage)
+    }
+  },
+
+  async updateSecret(data: UpdateSecretRequest, dryRun = false): Promise<CreateResourceResponse> {
+    try {
+      const response = await api.post<CreateResourceResponse>('/updateSecret', data, dryRunConfig(dryRun))
+      return response.data
+    } catch (error: any) {
+      console.error('Erro ao atualizar secret:', error)
+      const errorMessage = error?.response?.data?.message || error?.message || 'Erro ao atualizar secret'
+      throw new Error(errorMessage)
+    }
+  },
+
+  async createApplication(data: CreateApplicationRequest, dryRun = false): Promise<CreateResourceResponse> {
+    try {
+      const response = await api.post<CreateResourceResponse>('/createApplication', data, dryRunConfig(dryRun))
       return response.data
     } catch (error: any) {
       console.error('Erro ao criar aplicação:', error)
@@ -194,9 +1453,66 @@ export const k8sService = {
     }
   },
 
-  async updateDeployment(data: UpdateDeploymentRequest): Promise<CreateResourceResponse> {
+  // Aplica um manifesto YAML/JSON (vários documentos) com server-side apply
+  async applyManifest(manifest: string, options: ApplyOptions = {}): Promise<ApplyResult[]> {
+    try {
+      const params = new URLSearchParams()
+      if (options.namespace) params.set('namespace', options.namespace)
+      if (options.force) params.set('force', 'true')
+      if (options.dryRun) params.set('dryRun', 'true')
+      const response = await api.post<{ results: ApplyResult[] }>(`/apply?${params}`, manifest, {
+        headers: { 'Content-Type': 'application/yaml' },
+      })
+      return response.data.results
+    } catch (error: any) {
+      console.error('Erro ao aplicar manifesto:', error)
+      const errorMessage = error?.response?.data || error?.message || 'Erro ao aplicar manifesto'
+      throw new Error(errorMessage)
+    }
+  },
+
+  // Mostra o que o apply do manifesto mudaria, sem alterar o cluster
+  async diffManifest(manifest: string, options: ApplyOptions = {}): Promise<DiffResult[]> {
+    try {
+      const params = new URLSearchParams()
+      if (options.namespace) params.set('namespace', options.namespace)
+      if (options.force) params.set('force', 'true')
+      const response = await api.post<{ results: DiffResult[] }>(`/diff?${params}`, manifest, {
+        headers: { 'Content-Type': 'application/yaml' },
+      })
+      return response.data.results
+    } catch (error: any) {
+      console.error('Erro ao calcular diff:', error)
+      const errorMessage = error?.response?.data || error?.message || 'Erro ao calcular diff'
+      throw new Error(errorMessage)
+    }
+  },
+
+  async diffCreateResource(data: CreateResourceRequest): Promise<DiffResult> {
+    try {
+      const response = await api.post<DiffResult>('/diff/createResource', data)
+      return response.data
+    } catch (error: any) {
+      console.error('Erro ao calcular diff:', error)
+      const errorMessage = error?.response?.data || error?.message || 'Erro ao calcular diff'
+      throw new Error(errorMessage)
+    }
+  },
+
+  async diffUpdateDeployment(data: UpdateDeploymentRequest): Promise<DiffResult> {
+    try {
+      const response = await api.post<DiffResult>('/diff/updateDeployment', data)
+      return response.data
+    } catch (error: any) {
+      console.error('Erro ao calcular diff:', error)
+      const errorMessage = error?.response?.data || error?.message || 'Erro ao calcular diff'
+      throw new Error(errorMessage)
+    }
+  },
+
+  async updateDeployment(data: UpdateDeploymentRequest, dryRun = false): Promise<CreateResourceResponse> {
     try {
-      const response = await api.post<CreateResourceResponse>('/updateDeployment', data)
+      const response = await api.post<CreateResourceResponse>('/updateDeployment', data, dryRunConfig(dryRun))
       return response.data
     } catch (error: any) {
       console.error('Erro ao atualizar deployment:', error)